@@ -148,6 +148,7 @@ func BenchmarkConstructAndValidateData(b *testing.B) {
 		"TestIfStmts":           validateIfImplementation,
 		"TestValueWrapperLong":  validateResponseValueWrapperLong,
 		"TestValueWrapperShort": validateResponseValueWrapperShort,
+		"TestPlan":              responsePlan.Validate, // Built once, as it is not constructed per value.
 	}
 	tests := map[string]struct {
 		resp   Response
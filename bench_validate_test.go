@@ -6,6 +6,7 @@ import (
 
 	"github.com/Jh123x/go-validate/errs"
 	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/plan"
 	"github.com/Jh123x/go-validate/ttypes"
 	"github.com/Jh123x/go-validate/validator"
 	"github.com/Jh123x/go-validate/wrapper"
@@ -105,6 +106,23 @@ func validateOnlyResponseValueWrapperShort() ttypes.ValTest[*Response] {
 	return func(resp *Response) error { return validator.Validate(resp) }
 }
 
+// responsePlan is a benchmark for the precompiled Plan, whose rules are built once.
+var responsePlan = plan.New(
+	options.VField("code", func(r *Response) int { return r.Code }, options.VIsNotDefault[int]()),
+	options.VField("message", func(r *Response) string { return r.Message }, options.VIsNotDefault[string]()),
+	options.VWithRequire(func(r *Response) bool { return r.Extras != nil }, errTest),
+	options.VOr(
+		options.VAnd(
+			options.VField("optional", func(r *Response) string { return r.Optional }, options.VIsDefault[string]()),
+			options.VField("setIfOptSet", func(r *Response) string { return r.SetIfOptSet }, options.VIsDefault[string]()),
+		),
+		options.VAnd(
+			options.VField("optional", func(r *Response) string { return r.Optional }, options.VIsNotDefault[string]()),
+			options.VField("setIfOptSet", func(r *Response) string { return r.SetIfOptSet }, options.VIsNotDefault[string]()),
+		),
+	),
+)
+
 // BenchmarkOnlyValidate Data benchmarks the different validators only for their validation cost.
 func BenchmarkOnlyValidateData(b *testing.B) {
 	algorithms := map[string]ttypes.ValTest[*Response]{
@@ -115,6 +133,7 @@ func BenchmarkOnlyValidateData(b *testing.B) {
 		"TestIfStmts":           validateIfImplementation,
 		"TestValueWrapperLong":  validateOnlyResponseValueWrapperLong(),
 		"TestValueWrapperShort": validateOnlyResponseValueWrapperShort(),
+		"TestPlan":              responsePlan.Validate,
 	}
 	tests := map[string]struct {
 		resp   Response
@@ -0,0 +1,70 @@
+package errs
+
+// Codes are stable, machine-readable identifiers of the built-in errors.
+// Once released, a code is never changed, so that clients can map codes to their own behaviour.
+const (
+	CodeIsEmpty                 = "is_empty"
+	CodeIsNotEmpty              = "is_not_empty"
+	CodeIsDefault               = "is_default"
+	CodeIsNotDefault            = "is_not_default"
+	CodeInvalidLength           = "invalid_length"
+	CodeOutOfRange              = "out_of_range"
+	CodePattern                 = "pattern"
+	CodeInvalidType             = "invalid_type"
+	CodeOr                      = "or"
+	CodeContains                = "contains"
+	CodeInvalidURI              = "invalid_uri"
+	CodeInvalidJson             = "invalid_json"
+	CodeInvalidEmail            = "invalid_email"
+	CodeInvalidJsonKind         = "invalid_json_kind"
+	CodeMissingJsonKey          = "missing_json_key"
+	CodeJsonDepth               = "json_depth"
+	CodeJsonSize                = "json_size"
+	CodeDuplicateJsonKey        = "duplicate_json_key"
+	CodeJsonTrailingData        = "json_trailing_data"
+	CodeExactlyOne              = "exactly_one"
+	CodeNot                     = "not"
+	CodeExpr                    = "expr"
+	CodeExprEval                = "expr_eval"
+	CodeConvert                 = "convert"
+	CodeIsNil                   = "is_nil"
+	CodeIsNotNil                = "is_not_nil"
+	CodeRequired                = "required"
+	CodeOneOf                   = "one_of"
+	CodeNoneOf                  = "none_of"
+	CodeUnique                  = "unique"
+	CodeSorted                  = "sorted"
+	CodeSubsetOf                = "subset_of"
+	CodeSupersetOf              = "superset_of"
+	CodeDisjoint                = "disjoint"
+	CodePasswordTooShort        = "password_too_short"
+	CodePasswordTooLong         = "password_too_long"
+	CodePasswordClass           = "password_class"
+	CodePasswordRepeats         = "password_repeats"
+	CodePasswordPersonal        = "password_personal"
+	CodePasswordCommon          = "password_common"
+	CodePasswordEntropy         = "password_entropy"
+	CodeSchemaFalse             = "schema_false"
+	CodeSchemaType              = "schema_type"
+	CodeSchemaEnum              = "schema_enum"
+	CodeSchemaConst             = "schema_const"
+	CodeSchemaNot               = "schema_not"
+	CodeSchemaMinimum           = "schema_minimum"
+	CodeSchemaMaximum           = "schema_maximum"
+	CodeSchemaExclusiveMinimum  = "schema_exclusive_minimum"
+	CodeSchemaExclusiveMaximum  = "schema_exclusive_maximum"
+	CodeSchemaMultipleOf        = "schema_multiple_of"
+	CodeSchemaMinLength         = "schema_min_length"
+	CodeSchemaMaxLength         = "schema_max_length"
+	CodeSchemaPattern           = "schema_pattern"
+	CodeSchemaFormat            = "schema_format"
+	CodeSchemaMinItems          = "schema_min_items"
+	CodeSchemaMaxItems          = "schema_max_items"
+	CodeSchemaUniqueItems       = "schema_unique_items"
+	CodeSchemaContains          = "schema_contains"
+	CodeSchemaMaxContains       = "schema_max_contains"
+	CodeSchemaRequired          = "schema_required"
+	CodeSchemaMinProperties     = "schema_min_properties"
+	CodeSchemaMaxProperties     = "schema_max_properties"
+	CodeSchemaDependentRequired = "schema_dependent_required"
+)
@@ -0,0 +1,101 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCodes tests that the codes of the built-in errors never change.
+// Clients depend on these values: add new codes, but never edit or remove the ones below.
+func TestCodes(t *testing.T) {
+	tests := map[string]struct {
+		err          ValidateError
+		expectedCode string
+	}{
+		"IsEmptyError":                 {err: IsEmptyError, expectedCode: "is_empty"},
+		"IsNotEmptyErr":                {err: IsNotEmptyErr, expectedCode: "is_not_empty"},
+		"IsDefaultErr":                 {err: IsDefaultErr, expectedCode: "is_default"},
+		"IsNotDefaultErr":              {err: IsNotDefaultErr, expectedCode: "is_not_default"},
+		"InvalidLengthError":           {err: InvalidLengthError, expectedCode: "invalid_length"},
+		"OutOfRangeError":              {err: OutOfRangeError, expectedCode: "out_of_range"},
+		"PatternError":                 {err: PatternError, expectedCode: "pattern"},
+		"InvalidTypeError":             {err: InvalidTypeError, expectedCode: "invalid_type"},
+		"OrError":                      {err: OrError, expectedCode: "or"},
+		"ContainsError":                {err: ContainsError, expectedCode: "contains"},
+		"InvalidURIError":              {err: InvalidURIError, expectedCode: "invalid_uri"},
+		"InvalidJsonError":             {err: InvalidJsonError, expectedCode: "invalid_json"},
+		"InvalidEmailError":            {err: InvalidEmailError, expectedCode: "invalid_email"},
+		"InvalidJsonKindError":         {err: InvalidJsonKindError, expectedCode: "invalid_json_kind"},
+		"MissingJsonKeyError":          {err: MissingJsonKeyError, expectedCode: "missing_json_key"},
+		"JsonDepthError":               {err: JsonDepthError, expectedCode: "json_depth"},
+		"JsonSizeError":                {err: JsonSizeError, expectedCode: "json_size"},
+		"DuplicateJsonKeyError":        {err: DuplicateJsonKeyError, expectedCode: "duplicate_json_key"},
+		"JsonTrailingDataError":        {err: JsonTrailingDataError, expectedCode: "json_trailing_data"},
+		"ExactlyOneError":              {err: ExactlyOneError, expectedCode: "exactly_one"},
+		"NotError":                     {err: NotError, expectedCode: "not"},
+		"ExprError":                    {err: ExprError, expectedCode: "expr"},
+		"ExprEvalError":                {err: ExprEvalError, expectedCode: "expr_eval"},
+		"ConvertError":                 {err: ConvertError, expectedCode: "convert"},
+		"IsNilError":                   {err: IsNilError, expectedCode: "is_nil"},
+		"IsNotNilError":                {err: IsNotNilError, expectedCode: "is_not_nil"},
+		"RequiredError":                {err: RequiredError, expectedCode: "required"},
+		"OneOfError":                   {err: OneOfError, expectedCode: "one_of"},
+		"NoneOfError":                  {err: NoneOfError, expectedCode: "none_of"},
+		"UniqueError":                  {err: UniqueError, expectedCode: "unique"},
+		"SortedError":                  {err: SortedError, expectedCode: "sorted"},
+		"SubsetOfError":                {err: SubsetOfError, expectedCode: "subset_of"},
+		"SupersetOfError":              {err: SupersetOfError, expectedCode: "superset_of"},
+		"DisjointError":                {err: DisjointError, expectedCode: "disjoint"},
+		"PasswordTooShortError":        {err: PasswordTooShortError, expectedCode: "password_too_short"},
+		"PasswordTooLongError":         {err: PasswordTooLongError, expectedCode: "password_too_long"},
+		"PasswordClassError":           {err: PasswordClassError, expectedCode: "password_class"},
+		"PasswordRepeatsError":         {err: PasswordRepeatsError, expectedCode: "password_repeats"},
+		"PasswordPersonalError":        {err: PasswordPersonalError, expectedCode: "password_personal"},
+		"PasswordCommonError":          {err: PasswordCommonError, expectedCode: "password_common"},
+		"PasswordEntropyError":         {err: PasswordEntropyError, expectedCode: "password_entropy"},
+		"SchemaFalseError":             {err: SchemaFalseError, expectedCode: "schema_false"},
+		"SchemaTypeError":              {err: SchemaTypeError, expectedCode: "schema_type"},
+		"SchemaEnumError":              {err: SchemaEnumError, expectedCode: "schema_enum"},
+		"SchemaConstError":             {err: SchemaConstError, expectedCode: "schema_const"},
+		"SchemaNotError":               {err: SchemaNotError, expectedCode: "schema_not"},
+		"SchemaMinimumError":           {err: SchemaMinimumError, expectedCode: "schema_minimum"},
+		"SchemaMaximumError":           {err: SchemaMaximumError, expectedCode: "schema_maximum"},
+		"SchemaExclusiveMinimumError":  {err: SchemaExclusiveMinimumError, expectedCode: "schema_exclusive_minimum"},
+		"SchemaExclusiveMaximumError":  {err: SchemaExclusiveMaximumError, expectedCode: "schema_exclusive_maximum"},
+		"SchemaMultipleOfError":        {err: SchemaMultipleOfError, expectedCode: "schema_multiple_of"},
+		"SchemaMinLengthError":         {err: SchemaMinLengthError, expectedCode: "schema_min_length"},
+		"SchemaMaxLengthError":         {err: SchemaMaxLengthError, expectedCode: "schema_max_length"},
+		"SchemaPatternError":           {err: SchemaPatternError, expectedCode: "schema_pattern"},
+		"SchemaFormatError":            {err: SchemaFormatError, expectedCode: "schema_format"},
+		"SchemaMinItemsError":          {err: SchemaMinItemsError, expectedCode: "schema_min_items"},
+		"SchemaMaxItemsError":          {err: SchemaMaxItemsError, expectedCode: "schema_max_items"},
+		"SchemaUniqueItemsError":       {err: SchemaUniqueItemsError, expectedCode: "schema_unique_items"},
+		"SchemaContainsError":          {err: SchemaContainsError, expectedCode: "schema_contains"},
+		"SchemaMaxContainsError":       {err: SchemaMaxContainsError, expectedCode: "schema_max_contains"},
+		"SchemaRequiredError":          {err: SchemaRequiredError, expectedCode: "schema_required"},
+		"SchemaMinPropertiesError":     {err: SchemaMinPropertiesError, expectedCode: "schema_min_properties"},
+		"SchemaMaxPropertiesError":     {err: SchemaMaxPropertiesError, expectedCode: "schema_max_properties"},
+		"SchemaDependentRequiredError": {err: SchemaDependentRequiredError, expectedCode: "schema_dependent_required"},
+	}
+
+	seen := make(map[string]string, len(tests))
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedCode, testCase.err.Code())
+		})
+		if other, ok := seen[testCase.expectedCode]; ok {
+			t.Errorf("%s and %s have the same code %q", testName, other, testCase.expectedCode)
+		}
+		seen[testCase.expectedCode] = testName
+	}
+}
+
+// TestCode_Custom tests that errors created without a code have none.
+func TestCode_Custom(t *testing.T) {
+	err := NewValidateError("Custom", "custom error")
+	assert.Equal(t, "", err.Code())
+	assert.Equal(t, "custom", NewValidateErrorWithCode("custom", "Custom", "custom error").Code())
+	assert.False(t, errors.Is(IsEmptyError, NewValidateError("IsEmpty", "value is not empty")))
+}
@@ -1,18 +1,79 @@
 package errs
 
 const (
-	ErrorFormat = "[validation error] error validating %s:%s"
+	ErrorFormat      = "[validation error] error validating %s:%s"
+	PathErrorFormat  = "%s: %s"
+	NamedErrorFormat = "[%s] %s"
+	StageErrorFormat = "stage %s: %s"
+	ErrorsSeparator  = "; "
 )
 
 var (
-	IsEmptyError       = NewValidateError("IsEmpty", "value is not empty")
-	IsNotEmptyErr      = NewValidateError("IsNotEmpty", "value is empty")
-	IsDefaultErr       = NewValidateError("IsDefault", "value is not default")
-	IsNotDefaultErr    = NewValidateError("IsNotDefault", "value is default")
-	InvalidLengthError = NewValidateError("IsLength", "invalid length")
-	OrError            = NewValidateError("Or", "no options passed")
-	ContainsError      = NewValidateError("Contains", "value not found in array")
-	InvalidURIError    = NewValidateError("IsValidURL", "invalid url")
-	InvalidJsonError   = NewValidateError("IsValidJson", "invalid json")
-	InvalidEmailError  = NewValidateError("IsValidEmail", "invalid email")
+	IsEmptyError       = NewValidateErrorWithCode(CodeIsEmpty, "IsEmpty", "value is not empty")
+	IsNotEmptyErr      = NewValidateErrorWithCode(CodeIsNotEmpty, "IsNotEmpty", "value is empty")
+	IsDefaultErr       = NewValidateErrorWithCode(CodeIsDefault, "IsDefault", "value is not default")
+	IsNotDefaultErr    = NewValidateErrorWithCode(CodeIsNotDefault, "IsNotDefault", "value is default")
+	InvalidLengthError = NewValidateErrorWithCode(CodeInvalidLength, "IsLength", "invalid length")
+	OutOfRangeError    = NewValidateErrorWithCode(CodeOutOfRange, "IsInRange", "value is out of range")
+	PatternError       = NewValidateErrorWithCode(CodePattern, "Matches", "value does not match pattern")
+	InvalidTypeError   = NewValidateErrorWithCode(CodeInvalidType, "IsType", "value has an unexpected type")
+	OrError            = NewValidateErrorWithCode(CodeOr, "Or", "no options passed")
+	ContainsError      = NewValidateErrorWithCode(CodeContains, "Contains", "value not found in array")
+	InvalidURIError    = NewValidateErrorWithCode(CodeInvalidURI, "IsValidURL", "invalid url")
+	InvalidJsonError   = NewValidateErrorWithCode(CodeInvalidJson, "IsValidJson", "invalid json")
+	InvalidEmailError  = NewValidateErrorWithCode(CodeInvalidEmail, "IsValidEmail", "invalid email")
+
+	InvalidJsonKindError  = NewValidateErrorWithCode(CodeInvalidJsonKind, "IsJsonKind", "unexpected json kind")
+	MissingJsonKeyError   = NewValidateErrorWithCode(CodeMissingJsonKey, "HasJsonKeys", "missing required json key")
+	JsonDepthError        = NewValidateErrorWithCode(CodeJsonDepth, "IsJsonMaxDepth", "json nesting too deep")
+	JsonSizeError         = NewValidateErrorWithCode(CodeJsonSize, "IsJsonMaxSize", "json payload too large")
+	DuplicateJsonKeyError = NewValidateErrorWithCode(CodeDuplicateJsonKey, "IsJsonNoDuplicateKeys", "duplicate json key")
+	JsonTrailingDataError = NewValidateErrorWithCode(CodeJsonTrailingData, "IsJsonNoTrailingData", "unexpected data after json value")
+	ExactlyOneError       = NewValidateErrorWithCode(CodeExactlyOne, "ExactlyOne", "not exactly one option passed")
+	NotError              = NewValidateErrorWithCode(CodeNot, "Not", "option passed")
+	ExprError             = NewValidateErrorWithCode(CodeExpr, "Expr", "expression is false")
+	ExprEvalError         = NewValidateErrorWithCode(CodeExprEval, "Expr", "expression cannot be evaluated")
+	ConvertError          = NewValidateErrorWithCode(CodeConvert, "Map", "value cannot be converted")
+	IsNilError            = NewValidateErrorWithCode(CodeIsNil, "IsNil", "value is not nil")
+	IsNotNilError         = NewValidateErrorWithCode(CodeIsNotNil, "IsNotNil", "value is nil")
+	RequiredError         = NewValidateErrorWithCode(CodeRequired, "Required", "value is required")
+	OneOfError            = NewValidateErrorWithCode(CodeOneOf, "OneOf", "value is not one of the allowed values")
+	NoneOfError           = NewValidateErrorWithCode(CodeNoneOf, "NoneOf", "value is one of the disallowed values")
+	UniqueError           = NewValidateErrorWithCode(CodeUnique, "Unique", "value is a duplicate")
+	SortedError           = NewValidateErrorWithCode(CodeSorted, "Sorted", "value is out of order")
+	SubsetOfError         = NewValidateErrorWithCode(CodeSubsetOf, "SubsetOf", "value is not in the set")
+	SupersetOfError       = NewValidateErrorWithCode(CodeSupersetOf, "SupersetOf", "values are missing")
+	DisjointError         = NewValidateErrorWithCode(CodeDisjoint, "Disjoint", "value is in the set")
+
+	PasswordTooShortError = NewValidateErrorWithCode(CodePasswordTooShort, "PasswordMinLength", "password is too short")
+	PasswordTooLongError  = NewValidateErrorWithCode(CodePasswordTooLong, "PasswordMaxLength", "password is too long")
+	PasswordClassError    = NewValidateErrorWithCode(CodePasswordClass, "PasswordClasses", "password is missing a character class")
+	PasswordRepeatsError  = NewValidateErrorWithCode(CodePasswordRepeats, "PasswordMaxRepeats", "password repeats a character too many times")
+	PasswordPersonalError = NewValidateErrorWithCode(CodePasswordPersonal, "PasswordPersonal", "password contains personal information")
+	PasswordCommonError   = NewValidateErrorWithCode(CodePasswordCommon, "PasswordNoCommon", "password is too common")
+	PasswordEntropyError  = NewValidateErrorWithCode(CodePasswordEntropy, "PasswordMinEntropy", "password is too easy to guess")
+
+	SchemaFalseError             = NewValidateErrorWithCode(CodeSchemaFalse, "false", "no value is allowed")
+	SchemaTypeError              = NewValidateErrorWithCode(CodeSchemaType, "type", "invalid type")
+	SchemaEnumError              = NewValidateErrorWithCode(CodeSchemaEnum, "enum", "value is not one of the allowed values")
+	SchemaConstError             = NewValidateErrorWithCode(CodeSchemaConst, "const", "value does not match the constant")
+	SchemaNotError               = NewValidateErrorWithCode(CodeSchemaNot, "not", "value matches a disallowed schema")
+	SchemaMinimumError           = NewValidateErrorWithCode(CodeSchemaMinimum, "minimum", "value is too small")
+	SchemaMaximumError           = NewValidateErrorWithCode(CodeSchemaMaximum, "maximum", "value is too large")
+	SchemaExclusiveMinimumError  = NewValidateErrorWithCode(CodeSchemaExclusiveMinimum, "exclusiveMinimum", "value is too small")
+	SchemaExclusiveMaximumError  = NewValidateErrorWithCode(CodeSchemaExclusiveMaximum, "exclusiveMaximum", "value is too large")
+	SchemaMultipleOfError        = NewValidateErrorWithCode(CodeSchemaMultipleOf, "multipleOf", "value is not a multiple")
+	SchemaMinLengthError         = NewValidateErrorWithCode(CodeSchemaMinLength, "minLength", "string is too short")
+	SchemaMaxLengthError         = NewValidateErrorWithCode(CodeSchemaMaxLength, "maxLength", "string is too long")
+	SchemaPatternError           = NewValidateErrorWithCode(CodeSchemaPattern, "pattern", "string does not match pattern")
+	SchemaFormatError            = NewValidateErrorWithCode(CodeSchemaFormat, "format", "string does not match format")
+	SchemaMinItemsError          = NewValidateErrorWithCode(CodeSchemaMinItems, "minItems", "array has too few items")
+	SchemaMaxItemsError          = NewValidateErrorWithCode(CodeSchemaMaxItems, "maxItems", "array has too many items")
+	SchemaUniqueItemsError       = NewValidateErrorWithCode(CodeSchemaUniqueItems, "uniqueItems", "array items are not unique")
+	SchemaContainsError          = NewValidateErrorWithCode(CodeSchemaContains, "contains", "array does not contain enough matching items")
+	SchemaMaxContainsError       = NewValidateErrorWithCode(CodeSchemaMaxContains, "maxContains", "array contains too many matching items")
+	SchemaRequiredError          = NewValidateErrorWithCode(CodeSchemaRequired, "required", "missing required property")
+	SchemaMinPropertiesError     = NewValidateErrorWithCode(CodeSchemaMinProperties, "minProperties", "object has too few properties")
+	SchemaMaxPropertiesError     = NewValidateErrorWithCode(CodeSchemaMaxProperties, "maxProperties", "object has too many properties")
+	SchemaDependentRequiredError = NewValidateErrorWithCode(CodeSchemaDependentRequired, "dependentRequired", "missing dependent property")
 )
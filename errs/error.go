@@ -1,11 +1,13 @@
 package errs
 
 import (
+	"encoding/json"
 	"fmt"
 )
 
 // ValidateError is an error message used for validation.
 type ValidateError struct {
+	code      string
 	checkName string
 	errMsg    string
 }
@@ -14,10 +16,42 @@ var _ error = (*ValidateError)(nil)
 
 // NewValidateError returns a new ValidateError with the given field name and error message.
 func NewValidateError(fieldName, errMsg string) ValidateError {
-	return ValidateError{fieldName, errMsg}
+	return ValidateError{checkName: fieldName, errMsg: errMsg}
+}
+
+// NewValidateErrorWithCode returns a new ValidateError with a stable code identifying it.
+func NewValidateErrorWithCode(code, fieldName, errMsg string) ValidateError {
+	return ValidateError{code: code, checkName: fieldName, errMsg: errMsg}
 }
 
 // Error returns the error message.
 func (v ValidateError) Error() string {
 	return fmt.Sprintf(ErrorFormat, v.checkName, v.errMsg)
 }
+
+// Code returns the stable code of the error, such as CodeIsEmpty.
+// Errors created with NewValidateError have no code.
+func (v ValidateError) Code() string {
+	return v.code
+}
+
+// Rule returns the name of the check which failed.
+func (v ValidateError) Rule() string {
+	return v.checkName
+}
+
+// Message returns the error message without the name of the check.
+func (v ValidateError) Message() string {
+	return v.errMsg
+}
+
+// WithParams returns the error wrapped in a ParamsError holding the parameters of the check which failed,
+// such as the bounds of a range. The error itself is unchanged, so errors.Is matches it.
+func (v ValidateError) WithParams(params map[string]any) error {
+	return ParamsError{Err: v, params: &errorParams{values: params}}
+}
+
+// MarshalJSON marshals the error as an object with its code, rule, message and params.
+func (v ValidateError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Describe(v))
+}
@@ -1,6 +1,8 @@
 package errs
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,3 +29,79 @@ func TestValidateError_Error(t *testing.T) {
 		})
 	}
 }
+
+// TestMarshalJSON tests the JSON representation of the errors.
+func TestMarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected string
+	}{
+		"validate error": {
+			err:      IsNotEmptyErr,
+			expected: `{"code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"}`,
+		},
+		"validate error with params": {
+			err:      InvalidLengthError.WithParams(map[string]any{"min": 1, "max": 5}),
+			expected: `{"code":"invalid_length","rule":"IsLength","message":"invalid length","params":{"min":1,"max":5}}`,
+		},
+		"path error": {
+			err:      PathError{Pointer: "/a/0", Err: IsEmptyError},
+			expected: `{"pointer":"/a/0","code":"is_empty","rule":"IsEmpty","message":"value is not empty"}`,
+		},
+		"path error of other error": {
+			err:      PathError{Pointer: "/a", Err: fmt.Errorf("test error")},
+			expected: `{"pointer":"/a","message":"test error"}`,
+		},
+		"errors": {
+			err:      Errors{IsEmptyError, Errors{PathError{Pointer: "/a", Err: IsNotEmptyErr}}, fmt.Errorf("test error")},
+			expected: `[{"code":"is_empty","rule":"IsEmpty","message":"value is not empty"},{"pointer":"/a","code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"},{"message":"test error"}]`,
+		},
+		"errors with wrapped path error": {
+			err:      Errors{fmt.Errorf("request: %w", PathError{Pointer: "/a", Err: IsNotEmptyErr})},
+			expected: `[{"pointer":"/a","code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"}]`,
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			data, err := json.Marshal(testCase.err)
+			assert.Nil(t, err)
+			assert.JSONEq(t, testCase.expected, string(data))
+		})
+	}
+}
+
+// TestDescribe tests that Describe finds the members of wrapped errors.
+func TestDescribe(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected Description
+	}{
+		"other error": {
+			err:      fmt.Errorf("test error"),
+			expected: Description{Message: "test error"},
+		},
+		"path error": {
+			err:      PathError{Pointer: "/a", Err: InvalidLengthError.WithParams(map[string]any{"min": 1})},
+			expected: Description{Pointer: "/a", Code: CodeInvalidLength, Rule: "IsLength", Message: "invalid length", Params: map[string]any{"min": 1}},
+		},
+		"wrapped path error": {
+			err:      fmt.Errorf("request: %w", PathError{Pointer: "/a", Err: IsNotEmptyErr}),
+			expected: Description{Pointer: "/a", Code: CodeIsNotEmpty, Rule: "IsNotEmpty", Message: "value is empty"},
+		},
+		"path error of other error": {
+			err:      WithSeverity(SeverityWarning, PathError{Pointer: "/a", Err: fmt.Errorf("test error")}),
+			expected: Description{Pointer: "/a", Severity: "warning", Message: "test error"},
+		},
+		"named and staged error": {
+			err:      WithPath("a", WithName("adult", "", WithStage("parse", IsEmptyError))),
+			expected: Description{Pointer: "/a", Name: "adult", Stage: "parse", Code: CodeIsEmpty, Rule: "IsEmpty", Message: "value is not empty"},
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Describe(testCase.err))
+		})
+	}
+}
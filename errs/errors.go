@@ -0,0 +1,44 @@
+package errs
+
+import (
+	"strings"
+)
+
+// Errors is a list of errors found in the same value.
+type Errors []error
+
+var _ error = Errors{}
+
+// Error returns the error messages joined by ErrorsSeparator.
+func (e Errors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, ErrorsSeparator)
+}
+
+// Unwrap returns the errors, so that errors.Is and errors.As check each of them.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// Flatten returns the errors in err, expanding nested Errors.
+// It returns nil if err is nil.
+func Flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if f, ok := err.(*formattedError); ok {
+		return Flatten(f.err)
+	}
+	list, ok := err.(Errors)
+	if !ok {
+		return []error{err}
+	}
+	flat := make([]error, 0, len(list))
+	for _, item := range list {
+		flat = append(flat, Flatten(item)...)
+	}
+	return flat
+}
@@ -0,0 +1,45 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestErrors tests that Errors joins the messages and unwraps into each error.
+func TestErrors(t *testing.T) {
+	err := Errors{IsNotEmptyErr, PathError{Pointer: "/a", Err: InvalidLengthError}}
+
+	assert.Equal(t, IsNotEmptyErr.Error()+"; /a: "+InvalidLengthError.Error(), err.Error())
+	assert.True(t, errors.Is(err, IsNotEmptyErr))
+	assert.True(t, errors.Is(err, InvalidLengthError))
+	assert.False(t, errors.Is(err, IsEmptyError))
+
+	var pathErr PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "/a", pathErr.Pointer)
+}
+
+// TestFlatten tests that Flatten expands nested Errors.
+func TestFlatten(t *testing.T) {
+	errTest := fmt.Errorf("test error")
+	tests := map[string]struct {
+		err      error
+		expected []error
+	}{
+		"nil":    {err: nil, expected: nil},
+		"single": {err: errTest, expected: []error{errTest}},
+		"nested": {
+			err:      Errors{errTest, Errors{IsEmptyError, Errors{IsNotEmptyErr}}},
+			expected: []error{errTest, IsEmptyError, IsNotEmptyErr},
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, Flatten(testCase.err))
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package errs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Formatter renders an error returned by a validator.
+type Formatter interface {
+	Format(err error) string
+}
+
+// FormatterFunc is a function which implements Formatter.
+type FormatterFunc func(err error) string
+
+// Format calls f(err).
+func (f FormatterFunc) Format(err error) string {
+	return f(err)
+}
+
+var (
+	// DefaultFormatter renders errors as their Error method does,
+	// such as "[validation error] error validating IsNotEmpty:value is empty".
+	DefaultFormatter Formatter = FormatterFunc(formatDefault)
+	// PlainFormatter renders the messages of the errors, such as "/name: value is empty".
+	PlainFormatter Formatter = FormatterFunc(formatPlain)
+	// CompactFormatter renders the codes of the errors, or their rule if they have no code,
+	// such as "/name: is_not_empty".
+	CompactFormatter Formatter = FormatterFunc(formatCompact)
+	// JSONFormatter renders the errors as a JSON array, as Errors.MarshalJSON does.
+	JSONFormatter Formatter = FormatterFunc(formatJSON)
+	// LogfmtFormatter renders each error as a logfmt line,
+	// such as `pointer=/name code=is_not_empty rule=IsNotEmpty message="value is empty"`.
+	LogfmtFormatter Formatter = FormatterFunc(formatLogfmt)
+)
+
+// formattedError is an error rendered by a Formatter.
+type formattedError struct {
+	err       error
+	formatter Formatter
+}
+
+// Formatted returns err rendered by the formatter.
+// The returned error unwraps to err, so that errors.Is, errors.As and Flatten see the original errors.
+// It returns err if err or the formatter is nil.
+func Formatted(err error, formatter Formatter) error {
+	if err == nil || formatter == nil {
+		return err
+	}
+	if f, ok := err.(*formattedError); ok {
+		err = f.err
+	}
+	return &formattedError{err: err, formatter: formatter}
+}
+
+// Error returns the error rendered by the formatter.
+func (f *formattedError) Error() string {
+	return f.formatter.Format(f.err)
+}
+
+// Unwrap returns the original error.
+func (f *formattedError) Unwrap() error {
+	return f.err
+}
+
+// MarshalJSON marshals the original error.
+func (f *formattedError) MarshalJSON() ([]byte, error) {
+	if _, ok := f.err.(json.Marshaler); ok {
+		return json.Marshal(f.err)
+	}
+	return json.Marshal(Describe(f.err))
+}
+
+func formatDefault(err error) string {
+	return err.Error()
+}
+
+func formatPlain(err error) string {
+	return formatEach(err, ErrorsSeparator, func(item Description) string {
+		return withPointer(item.Pointer, item.Message)
+	})
+}
+
+func formatCompact(err error) string {
+	return formatEach(err, ErrorsSeparator, func(item Description) string {
+		switch {
+		case item.Code != "":
+			return withPointer(item.Pointer, item.Code)
+		case item.Rule != "":
+			return withPointer(item.Pointer, item.Rule)
+		default:
+			return withPointer(item.Pointer, item.Message)
+		}
+	})
+}
+
+func formatJSON(err error) string {
+	data, marshalErr := json.Marshal(Errors(Flatten(err)))
+	if marshalErr != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+func formatLogfmt(err error) string {
+	return formatEach(err, "\n", func(item Description) string {
+		var b strings.Builder
+		writeLogfmt(&b, "pointer", item.Pointer)
+		writeLogfmt(&b, "severity", item.Severity)
+		writeLogfmt(&b, "name", item.Name)
+		writeLogfmt(&b, "stage", item.Stage)
+		writeLogfmt(&b, "code", item.Code)
+		writeLogfmt(&b, "rule", item.Rule)
+		writeLogfmt(&b, "message", item.Message)
+		keys := make([]string, 0, len(item.Params))
+		for key := range item.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			writeLogfmt(&b, "params."+key, fmt.Sprint(item.Params[key]))
+		}
+		return b.String()
+	})
+}
+
+// formatEach renders each of the errors in err with format, joined by sep.
+func formatEach(err error, sep string, format func(Description) string) string {
+	list := Flatten(err)
+	msgs := make([]string, 0, len(list))
+	for _, item := range list {
+		msgs = append(msgs, format(Describe(item)))
+	}
+	return strings.Join(msgs, sep)
+}
+
+func withPointer(pointer, msg string) string {
+	if pointer == "" {
+		return msg
+	}
+	return fmt.Sprintf(PathErrorFormat, pointer, msg)
+}
+
+// writeLogfmt writes the key and value, quoting the value if needed. Empty values except the message are skipped.
+func writeLogfmt(b *strings.Builder, key, val string) {
+	if val == "" && key != "message" {
+		return
+	}
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(key)
+	b.WriteByte('=')
+	if val == "" || strings.ContainsAny(val, " =\"\\\n\t") {
+		val = strconv.Quote(val)
+	}
+	b.WriteString(val)
+}
@@ -0,0 +1,127 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var formatTestErr = Errors{
+	PathError{Pointer: "/name", Err: IsNotEmptyErr},
+	PathError{Pointer: "/age", Err: OutOfRangeError.WithParams(map[string]any{"min": 18, "max": 150})},
+	NewValidateError("Custom", "custom failed"),
+	errors.New("other error"),
+}
+
+// TestFormatters tests the output of the built-in formatters.
+func TestFormatters(t *testing.T) {
+	tests := map[string]struct {
+		formatter Formatter
+		err       error
+		expected  string
+	}{
+		"default": {
+			formatter: DefaultFormatter,
+			err:       formatTestErr,
+			expected:  formatTestErr.Error(),
+		},
+		"plain": {
+			formatter: PlainFormatter,
+			err:       formatTestErr,
+			expected:  "/name: value is empty; /age: value is out of range; custom failed; other error",
+		},
+		"plain single": {
+			formatter: PlainFormatter,
+			err:       IsNotEmptyErr,
+			expected:  "value is empty",
+		},
+		"plain wrapped path error": {
+			formatter: PlainFormatter,
+			err:       fmt.Errorf("request: %w", PathError{Pointer: "/name", Err: IsNotEmptyErr}),
+			expected:  "/name: value is empty",
+		},
+		"compact": {
+			formatter: CompactFormatter,
+			err:       formatTestErr,
+			expected:  "/name: is_not_empty; /age: out_of_range; Custom; other error",
+		},
+		"json": {
+			formatter: JSONFormatter,
+			err:       formatTestErr,
+			expected: `[{"pointer":"/name","code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"},` +
+				`{"pointer":"/age","code":"out_of_range","rule":"IsInRange","message":"value is out of range","params":{"max":150,"min":18}},` +
+				`{"rule":"Custom","message":"custom failed"},` +
+				`{"message":"other error"}]`,
+		},
+		"json single": {
+			formatter: JSONFormatter,
+			err:       IsNotEmptyErr,
+			expected:  `[{"code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"}]`,
+		},
+		"json unsupported params": {
+			formatter: JSONFormatter,
+			err:       OutOfRangeError.WithParams(map[string]any{"min": math.Inf(-1)}),
+			expected:  OutOfRangeError.Error(),
+		},
+		"logfmt": {
+			formatter: LogfmtFormatter,
+			err:       formatTestErr,
+			expected: `pointer=/name code=is_not_empty rule=IsNotEmpty message="value is empty"` + "\n" +
+				`pointer=/age code=out_of_range rule=IsInRange message="value is out of range" params.max=150 params.min=18` + "\n" +
+				`rule=Custom message="custom failed"` + "\n" +
+				`message="other error"`,
+		},
+		"logfmt empty message": {
+			formatter: LogfmtFormatter,
+			err:       NewValidateError("Custom", ""),
+			expected:  `rule=Custom message=""`,
+		},
+		"func": {
+			formatter: FormatterFunc(func(err error) string { return "invalid" }),
+			err:       formatTestErr,
+			expected:  "invalid",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.formatter.Format(tc.err))
+			assert.Equal(t, tc.expected, Formatted(tc.err, tc.formatter).Error())
+		})
+	}
+}
+
+// TestFormatted tests that formatted errors keep the original errors.
+func TestFormatted(t *testing.T) {
+	assert.Nil(t, Formatted(nil, PlainFormatter))
+	assert.Equal(t, IsNotEmptyErr, Formatted(IsNotEmptyErr, nil))
+
+	err := Formatted(formatTestErr, PlainFormatter)
+	assert.True(t, errors.Is(err, IsNotEmptyErr))
+	assert.True(t, errors.Is(err, OutOfRangeError))
+	assert.Equal(t, []error(formatTestErr), Flatten(err))
+
+	var pathErr PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "/name", pathErr.Pointer)
+
+	t.Run("reformatting replaces the formatter", func(t *testing.T) {
+		assert.Equal(t, "is_not_empty", Formatted(Formatted(IsNotEmptyErr, PlainFormatter), CompactFormatter).Error())
+	})
+	t.Run("with path keeps the formatter", func(t *testing.T) {
+		assert.Equal(t, "/user/name: value is empty", WithPath("user", Formatted(PathError{Pointer: "/name", Err: IsNotEmptyErr}, PlainFormatter)).Error())
+	})
+	t.Run("json marshals the original error", func(t *testing.T) {
+		data, marshalErr := json.Marshal(Formatted(IsNotEmptyErr, PlainFormatter))
+		assert.Nil(t, marshalErr)
+		assert.JSONEq(t, `{"code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"}`, string(data))
+
+		data, marshalErr = json.Marshal(Formatted(errors.New("other error"), PlainFormatter))
+		assert.Nil(t, marshalErr)
+		assert.JSONEq(t, `{"message":"other error"}`, string(data))
+	})
+}
@@ -0,0 +1,78 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Description holds the members describing an error, as marshalled to JSON by the errors of this package.
+// Pointer is the JSON Pointer of a PathError, with "" being the value itself.
+// Severity is only set for warnings and infos, Name only for errors of named rules,
+// and Stage only for errors of stages converting the value.
+type Description struct {
+	Pointer  string         `json:"pointer,omitempty"`
+	Severity string         `json:"severity,omitempty"`
+	Name     string         `json:"name,omitempty"`
+	Stage    string         `json:"stage,omitempty"`
+	Code     string         `json:"code,omitempty"`
+	Rule     string         `json:"rule,omitempty"`
+	Message  string         `json:"message"`
+	Params   map[string]any `json:"params,omitempty"`
+}
+
+// Describe describes err, using the pointer of a PathError and the code, rule, message and params of a ValidateError it wraps.
+// It describes a single error, and aggregated errors should be flattened first.
+func Describe(err error) Description {
+	inner := err
+	var pathErr PathError
+	if errors.As(err, &pathErr) {
+		inner = pathErr.Err
+	}
+	out := Description{Pointer: pathErr.Pointer, Message: messageOf(inner)}
+	var validateErr ValidateError
+	if errors.As(inner, &validateErr) {
+		out = Description{Pointer: pathErr.Pointer, Code: validateErr.code, Rule: validateErr.checkName, Message: validateErr.errMsg, Params: ParamsOf(inner)}
+	}
+	if severity := severityOf(err); severity != SeverityError {
+		out.Severity = severity.String()
+	}
+	out.Name = NameOf(err)
+	out.Stage = StageOf(err)
+	return out
+}
+
+// MarshalJSON marshals the error as an object with its pointer, code, rule, message and params.
+func (p PathError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Describe(p))
+}
+
+// MarshalJSON marshals the errors as an array, with nested Errors flattened.
+// Errors which are not from this package are marshalled as an object with their message.
+func (e Errors) MarshalJSON() ([]byte, error) {
+	flat := Flatten(e)
+	out := make([]any, 0, len(flat))
+	for _, err := range flat {
+		if _, ok := err.(json.Marshaler); ok {
+			out = append(out, err)
+			continue
+		}
+		out = append(out, Describe(err))
+	}
+	return json.Marshal(out)
+}
+
+// messageOf returns the message of err without the names of the rules and the stages wrapping it.
+func messageOf(err error) string {
+	for {
+		switch e := err.(type) {
+		case NamedError:
+			err = e.Err
+		case StageError:
+			err = e.Err
+		case severityError:
+			err = e.err
+		default:
+			return err.Error()
+		}
+	}
+}
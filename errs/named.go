@@ -0,0 +1,64 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NamedError is an error of a rule given a name, such as "age-adult".
+type NamedError struct {
+	Name        string
+	Description string
+	Err         error
+}
+
+var _ error = NamedError{}
+
+// Error returns the error message prefixed with the name of the rule.
+func (n NamedError) Error() string {
+	return fmt.Sprintf(NamedErrorFormat, n.Name, n.Err)
+}
+
+// Unwrap returns the underlying error.
+func (n NamedError) Unwrap() error {
+	return n.Err
+}
+
+// WithName returns err as the error of the rule with the name and description.
+// The name is set inside a PathError, so that its pointer is kept.
+// If err is an Errors, each of the errors is given the name.
+func WithName(name, description string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return mapInner(err, func(err error) error {
+		return NamedError{Name: name, Description: description, Err: err}
+	})
+}
+
+// NameOf returns the name of the outermost named rule of err, or "" if there is none.
+func NameOf(err error) string {
+	var named NamedError
+	if errors.As(err, &named) {
+		return named.Name
+	}
+	return ""
+}
+
+// mapInner applies wrap to each error of err, inside the PathError and formatter of the error.
+func mapInner(err error, wrap func(error) error) error {
+	switch e := err.(type) {
+	case Errors:
+		out := make(Errors, 0, len(e))
+		for _, item := range e {
+			out = append(out, mapInner(item, wrap))
+		}
+		return out
+	case *formattedError:
+		return Formatted(mapInner(e.err, wrap), e.formatter)
+	case PathError:
+		return PathError{Pointer: e.Pointer, Err: mapInner(e.Err, wrap)}
+	default:
+		return wrap(err)
+	}
+}
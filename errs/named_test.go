@@ -0,0 +1,84 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithName tests that errors keep the name of their rule when wrapped.
+func TestWithName(t *testing.T) {
+	named := WithName("not-empty", "must not be empty", IsNotEmptyErr)
+	assert.Equal(t, "[not-empty] "+IsNotEmptyErr.Error(), named.Error())
+	assert.True(t, errors.Is(named, IsNotEmptyErr))
+	assert.Equal(t, "not-empty", NameOf(named))
+
+	tests := map[string]struct {
+		err             error
+		expectedNames   []string
+		expectedPointer string
+	}{
+		"nil": {},
+		"error": {
+			err:           IsNotEmptyErr,
+			expectedNames: []string{"not-empty"},
+		},
+		"path": {
+			err:             WithPath("name", IsNotEmptyErr),
+			expectedNames:   []string{"not-empty"},
+			expectedPointer: "/name",
+		},
+		"errors": {
+			err:           Errors{IsNotEmptyErr, WithSeverity(SeverityWarning, IsEmptyError)},
+			expectedNames: []string{"not-empty", "not-empty"},
+		},
+		"formatted": {
+			err:           Formatted(IsNotEmptyErr, PlainFormatter),
+			expectedNames: []string{"not-empty"},
+		},
+	}
+
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := WithName("not-empty", "", tc.err)
+			if tc.expectedNames == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			var names []string
+			for _, item := range Flatten(err) {
+				names = append(names, NameOf(item))
+			}
+			assert.Equal(t, tc.expectedNames, names)
+
+			var pathErr PathError
+			if tc.expectedPointer != "" && assert.True(t, errors.As(err, &pathErr)) {
+				assert.Equal(t, tc.expectedPointer, pathErr.Pointer)
+			}
+		})
+	}
+}
+
+// TestNameOf tests that NameOf returns the outermost name.
+func TestNameOf(t *testing.T) {
+	assert.Equal(t, "", NameOf(nil))
+	assert.Equal(t, "", NameOf(IsNotEmptyErr))
+	assert.Equal(t, "outer", NameOf(WithName("outer", "", WithName("inner", "", IsNotEmptyErr))))
+}
+
+// TestNamedError_MarshalJSON tests that named errors marshal with their name and plain message.
+func TestNamedError_MarshalJSON(t *testing.T) {
+	err := Errors{
+		WithName("not-empty", "", WithPath("name", IsNotEmptyErr)),
+		WithName("custom", "", WithSeverity(SeverityWarning, errors.New("custom error"))),
+	}
+	data, marshalErr := json.Marshal(err)
+	assert.Nil(t, marshalErr)
+	assert.JSONEq(t, `[
+		{"pointer":"/name","name":"not-empty","code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"},
+		{"severity":"warning","name":"custom","message":"custom error"}
+	]`, string(data))
+}
@@ -0,0 +1,51 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ParamsError is an error holding the parameters of the check which failed, such as the bounds of a range.
+type ParamsError struct {
+	Err error
+	// params is a pointer so that ParamsError stays comparable.
+	params *errorParams
+}
+
+type errorParams struct {
+	values map[string]any
+}
+
+var _ error = ParamsError{}
+
+// Error returns the message of the underlying error.
+func (p ParamsError) Error() string {
+	return p.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (p ParamsError) Unwrap() error {
+	return p.Err
+}
+
+// Params returns the parameters of the check which failed.
+func (p ParamsError) Params() map[string]any {
+	if p.params == nil {
+		return nil
+	}
+	return p.params.values
+}
+
+// MarshalJSON marshals the error as an object with its code, rule, message and params.
+func (p ParamsError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Describe(p))
+}
+
+// ParamsOf returns the parameters of the outermost ParamsError of err, or nil if there is none.
+func ParamsOf(err error) map[string]any {
+	var withParams ParamsError
+	if errors.As(err, &withParams) {
+		return withParams.Params()
+	}
+	return nil
+}
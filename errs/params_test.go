@@ -0,0 +1,30 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithParams tests that parameters are attached without changing the identity of the error.
+func TestWithParams(t *testing.T) {
+	base := NewValidateError("IsInRange", "value is out of range")
+	withParams := base.WithParams(map[string]any{"min": 1})
+
+	assert.Equal(t, base.Error(), withParams.Error())
+	assert.Equal(t, map[string]any{"min": 1}, ParamsOf(withParams))
+	assert.Equal(t, map[string]any{"min": 1}, ParamsOf(WithPath("age", withParams)))
+	assert.Nil(t, ParamsOf(base))
+	assert.Nil(t, ParamsOf(nil))
+
+	assert.True(t, errors.Is(withParams, base))
+	assert.True(t, errors.Is(fmt.Errorf("wrapped: %w", withParams), base))
+	assert.False(t, errors.Is(withParams, NewValidateError("IsInRange", "other")))
+	assert.False(t, errors.Is(withParams, fmt.Errorf("value is out of range")))
+
+	var validateErr ValidateError
+	assert.True(t, errors.As(withParams, &validateErr))
+	assert.Equal(t, base, validateErr)
+}
@@ -0,0 +1,68 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathError is an error found at a location inside the validated value.
+// The location is a JSON Pointer (RFC 6901), with "" being the value itself.
+type PathError struct {
+	Pointer string
+	Err     error
+}
+
+var _ error = PathError{}
+
+// Error returns the error message prefixed with the location.
+func (p PathError) Error() string {
+	return fmt.Sprintf(PathErrorFormat, p.Pointer, p.Err)
+}
+
+// Unwrap returns the underlying error.
+func (p PathError) Unwrap() error {
+	return p.Err
+}
+
+// WithPath prefixes the location of err with the reference token.
+// If err is already a PathError, the token is prepended to its pointer.
+// If err is an Errors, the token is prepended to each of the errors.
+// If err is Formatted, the token is prepended to the original error, keeping the formatter.
+func WithPath(token string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if f, ok := err.(*formattedError); ok {
+		return Formatted(WithPath(token, f.err), f.formatter)
+	}
+
+	if list, ok := err.(Errors); ok {
+		prefixed := make(Errors, 0, len(list))
+		for _, item := range list {
+			prefixed = append(prefixed, WithPath(token, item))
+		}
+		return prefixed
+	}
+
+	token = EscapePointerToken(token)
+	if pathErr, ok := err.(PathError); ok {
+		return PathError{Pointer: "/" + token + pathErr.Pointer, Err: pathErr.Err}
+	}
+	return PathError{Pointer: "/" + token, Err: err}
+}
+
+// EscapePointerToken escapes a reference token for use in a JSON Pointer.
+func EscapePointerToken(token string) string {
+	return pointerEscaper.Replace(token)
+}
+
+// UnescapePointerToken reverses EscapePointerToken.
+func UnescapePointerToken(token string) string {
+	return pointerUnescaper.Replace(token)
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
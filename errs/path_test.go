@@ -0,0 +1,71 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithPath tests that WithPath builds the JSON Pointer of the error.
+func TestWithPath(t *testing.T) {
+	errTest := fmt.Errorf("test error")
+	tests := map[string]struct {
+		tokens      []string
+		err         error
+		expectedErr error
+		expectedMsg string
+	}{
+		"nil error stays nil": {
+			tokens:      []string{"a"},
+			err:         nil,
+			expectedErr: nil,
+		},
+		"single token": {
+			tokens:      []string{"a"},
+			err:         errTest,
+			expectedErr: PathError{Pointer: "/a", Err: errTest},
+			expectedMsg: "/a: test error",
+		},
+		"nested tokens are prepended": {
+			tokens:      []string{"0", "b"},
+			err:         errTest,
+			expectedErr: PathError{Pointer: "/b/0", Err: errTest},
+			expectedMsg: "/b/0: test error",
+		},
+		"tokens are escaped": {
+			tokens:      []string{"a/b~c"},
+			err:         errTest,
+			expectedErr: PathError{Pointer: "/a~1b~0c", Err: errTest},
+			expectedMsg: "/a~1b~0c: test error",
+		},
+		"token is prepended to each error": {
+			tokens:      []string{"a"},
+			err:         Errors{errTest, PathError{Pointer: "/b", Err: errTest}},
+			expectedErr: Errors{PathError{Pointer: "/a", Err: errTest}, PathError{Pointer: "/a/b", Err: errTest}},
+			expectedMsg: "/a: test error; /a/b: test error",
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := testCase.err
+			for _, token := range testCase.tokens {
+				err = WithPath(token, err)
+			}
+			assert.Equal(t, testCase.expectedErr, err)
+			if err != nil {
+				assert.Equal(t, testCase.expectedMsg, err.Error())
+				assert.True(t, errors.Is(err, errTest))
+			}
+		})
+	}
+}
+
+// TestUnescapePointerToken tests that escaping a token can be reversed.
+func TestUnescapePointerToken(t *testing.T) {
+	for _, token := range []string{"", "a", "a/b", "~1", "~0/~"} {
+		assert.Equal(t, token, UnescapePointerToken(EscapePointerToken(token)))
+	}
+}
@@ -0,0 +1,71 @@
+package errs
+
+import "encoding/json"
+
+// Result holds the errors found by a validator, grouped by severity.
+type Result struct {
+	Errors   Errors
+	Warnings Errors
+	Infos    Errors
+}
+
+// NewResult groups the errors in err by severity.
+func NewResult(err error) Result {
+	var result Result
+	for _, item := range Flatten(err) {
+		switch severityOf(item) {
+		case SeverityWarning:
+			result.Warnings = append(result.Warnings, item)
+		case SeverityInfo:
+			result.Infos = append(result.Infos, item)
+		default:
+			result.Errors = append(result.Errors, item)
+		}
+	}
+	return result
+}
+
+// Valid reports whether there are no errors of SeverityError.
+func (r Result) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Err returns the errors of SeverityError, or nil if there are none.
+// A single error is returned as is.
+func (r Result) Err() error {
+	switch len(r.Errors) {
+	case 0:
+		return nil
+	case 1:
+		return r.Errors[0]
+	default:
+		return r.Errors
+	}
+}
+
+// Merge returns a result holding the errors of both results.
+func (r Result) Merge(other Result) Result {
+	return Result{
+		Errors:   appendErrors(r.Errors, other.Errors),
+		Warnings: appendErrors(r.Warnings, other.Warnings),
+		Infos:    appendErrors(r.Infos, other.Infos),
+	}
+}
+
+func appendErrors(a, b Errors) Errors {
+	if len(a)+len(b) == 0 {
+		return nil
+	}
+	out := make(Errors, 0, len(a)+len(b))
+	return append(append(out, a...), b...)
+}
+
+// MarshalJSON marshals the result as an object with whether it is valid and its errors by severity.
+func (r Result) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Valid    bool   `json:"valid"`
+		Errors   Errors `json:"errors,omitempty"`
+		Warnings Errors `json:"warnings,omitempty"`
+		Infos    Errors `json:"infos,omitempty"`
+	}{r.Valid(), r.Errors, r.Warnings, r.Infos})
+}
@@ -0,0 +1,83 @@
+package errs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResult tests that Result groups errors by severity.
+func TestResult(t *testing.T) {
+	warning := WithSeverity(SeverityWarning, IsNotEmptyErr)
+	info := WithSeverity(SeverityInfo, IsEmptyError)
+
+	tests := map[string]struct {
+		err           error
+		expected      Result
+		expectedValid bool
+		expectedErr   error
+	}{
+		"nil": {
+			expectedValid: true,
+		},
+		"single error": {
+			err:         IsDefaultErr,
+			expected:    Result{Errors: Errors{IsDefaultErr}},
+			expectedErr: IsDefaultErr,
+		},
+		"warnings and infos": {
+			err:           Errors{warning, info},
+			expected:      Result{Warnings: Errors{warning}, Infos: Errors{info}},
+			expectedValid: true,
+		},
+		"mixed": {
+			err:         Errors{warning, IsDefaultErr, Errors{info, IsNotDefaultErr}},
+			expected:    Result{Errors: Errors{IsDefaultErr, IsNotDefaultErr}, Warnings: Errors{warning}, Infos: Errors{info}},
+			expectedErr: Errors{IsDefaultErr, IsNotDefaultErr},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := NewResult(tc.err)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.expectedValid, result.Valid())
+			assert.Equal(t, tc.expectedErr, result.Err())
+		})
+	}
+}
+
+// TestResult_Merge tests that merging results does not modify them.
+func TestResult_Merge(t *testing.T) {
+	warning := WithSeverity(SeverityWarning, IsNotEmptyErr)
+	first := Result{Warnings: make(Errors, 1, 2)}
+	first.Warnings[0] = warning
+	second := Result{Errors: Errors{IsDefaultErr}, Warnings: Errors{warning}}
+
+	merged := first.Merge(second)
+	assert.Equal(t, Result{Errors: Errors{IsDefaultErr}, Warnings: Errors{warning, warning}}, merged)
+	assert.Equal(t, Errors{warning}, first.Warnings)
+	assert.Equal(t, Result{}, Result{}.Merge(Result{}))
+
+	merged.Warnings[0] = IsEmptyError
+	assert.Equal(t, Errors{warning}, first.Warnings)
+}
+
+// TestResult_MarshalJSON tests the JSON representation of a result.
+func TestResult_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(NewResult(Errors{
+		WithPath("nickname", WithSeverity(SeverityWarning, IsNotEmptyErr)),
+		IsDefaultErr,
+	}))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"valid": false,
+		"errors": [{"code":"is_default","rule":"IsDefault","message":"value is not default"}],
+		"warnings": [{"pointer":"/nickname","severity":"warning","code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"}]
+	}`, string(data))
+
+	data, err = json.Marshal(Result{})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"valid": true}`, string(data))
+}
@@ -0,0 +1,156 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Severity is how serious an error is. Only errors of SeverityError reject a value.
+type Severity int
+
+const (
+	// SeverityError rejects the value. It is the severity of errors without one.
+	SeverityError Severity = iota
+	// SeverityWarning is an advisory error, such as a deprecated field being used.
+	SeverityWarning
+	// SeverityInfo is an informational error.
+	SeverityInfo
+)
+
+var severityNames = map[Severity]string{
+	SeverityError:   "error",
+	SeverityWarning: "warning",
+	SeverityInfo:    "info",
+}
+
+// String returns the name of the severity, such as "warning".
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText returns the name of the severity.
+func (s Severity) MarshalText() ([]byte, error) {
+	if _, ok := severityNames[s]; !ok {
+		return nil, fmt.Errorf("invalid severity %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses the name of a severity.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range severityNames {
+		if name == string(text) {
+			*s = severity
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid severity %q", text)
+}
+
+// severityError is an error with a severity other than SeverityError.
+type severityError struct {
+	severity Severity
+	err      error
+}
+
+// Error returns the message of the error.
+func (s severityError) Error() string {
+	return s.err.Error()
+}
+
+// Unwrap returns the error.
+func (s severityError) Unwrap() error {
+	return s.err
+}
+
+// Severity returns the severity of the error.
+func (s severityError) Severity() Severity {
+	return s.severity
+}
+
+// WithSeverity returns err with the severity.
+// If err is an Errors, each of the errors is given the severity.
+// The severity is set inside a PathError, so that its pointer is kept.
+func WithSeverity(severity Severity, err error) error {
+	if err == nil {
+		return nil
+	}
+	return mapInner(err, func(err error) error {
+		if s, ok := err.(severityError); ok {
+			err = s.err
+		}
+		if severity == SeverityError {
+			return err
+		}
+		return severityError{severity: severity, err: err}
+	})
+}
+
+// SeverityOf returns the severity of err, which is the most serious severity of the errors in it.
+// Errors without a severity are of SeverityError.
+func SeverityOf(err error) Severity {
+	severity := SeverityInfo
+	for _, item := range Flatten(err) {
+		if itemSeverity := severityOf(item); itemSeverity < severity {
+			severity = itemSeverity
+		}
+	}
+	return severity
+}
+
+// severityOf returns the severity of a single error.
+// The chain of err is unwrapped by hand while it is linear, as errors.As allocates its target.
+func severityOf(err error) Severity {
+	for err != nil {
+		switch e := err.(type) {
+		case interface{ Severity() Severity }:
+			return e.Severity()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Unwrap() []error }, interface{ As(any) bool }:
+			var s interface{ Severity() Severity }
+			if errors.As(err, &s) {
+				return s.Severity()
+			}
+			return SeverityError
+		default:
+			return SeverityError
+		}
+	}
+	return SeverityError
+}
+
+// OnlyErrors returns the errors in err of SeverityError, dropping warnings and infos.
+// It returns err unchanged if all of its errors are of SeverityError, and nil if none are.
+func OnlyErrors(err error) error {
+	switch err.(type) {
+	case nil:
+		return nil
+	case Errors, *formattedError:
+	default:
+		if severityOf(err) != SeverityError {
+			return nil
+		}
+		return err
+	}
+	list := Flatten(err)
+	found := make(Errors, 0, len(list))
+	for _, item := range list {
+		if severityOf(item) == SeverityError {
+			found = append(found, item)
+		}
+	}
+	switch len(found) {
+	case len(list):
+		return err
+	case 0:
+		return nil
+	case 1:
+		return found[0]
+	default:
+		return found
+	}
+}
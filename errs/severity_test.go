@@ -0,0 +1,177 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSeverity_Text tests the names of the severities.
+func TestSeverity_Text(t *testing.T) {
+	tests := map[string]struct {
+		severity Severity
+		name     string
+	}{
+		"error":   {severity: SeverityError, name: "error"},
+		"warning": {severity: SeverityWarning, name: "warning"},
+		"info":    {severity: SeverityInfo, name: "info"},
+	}
+
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, tc.name, tc.severity.String())
+
+			text, err := tc.severity.MarshalText()
+			assert.Nil(t, err)
+			assert.Equal(t, tc.name, string(text))
+
+			var parsed Severity
+			assert.Nil(t, parsed.UnmarshalText(text))
+			assert.Equal(t, tc.severity, parsed)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, "Severity(5)", Severity(5).String())
+		_, err := Severity(5).MarshalText()
+		assert.NotNil(t, err)
+
+		var parsed Severity
+		assert.NotNil(t, parsed.UnmarshalText([]byte("fatal")))
+	})
+}
+
+// TestWithSeverity tests that errors keep their severity when wrapped.
+func TestWithSeverity(t *testing.T) {
+	warning := WithSeverity(SeverityWarning, IsNotEmptyErr)
+	assert.Equal(t, IsNotEmptyErr.Error(), warning.Error())
+	assert.True(t, errors.Is(warning, IsNotEmptyErr))
+	assert.Equal(t, SeverityWarning, SeverityOf(warning))
+
+	tests := map[string]struct {
+		err              error
+		expectedSeverity Severity
+		expectedPointer  string
+	}{
+		"nil": {
+			expectedSeverity: SeverityInfo,
+		},
+		"without severity": {
+			err:              IsNotEmptyErr,
+			expectedSeverity: SeverityError,
+		},
+		"warning": {
+			err:              warning,
+			expectedSeverity: SeverityWarning,
+		},
+		"info replaces warning": {
+			err:              WithSeverity(SeverityInfo, warning),
+			expectedSeverity: SeverityInfo,
+		},
+		"error removes the severity": {
+			err:              WithSeverity(SeverityError, warning),
+			expectedSeverity: SeverityError,
+		},
+		"path error": {
+			err:              WithPath("user", WithSeverity(SeverityWarning, PathError{Pointer: "/name", Err: IsNotEmptyErr})),
+			expectedSeverity: SeverityWarning,
+			expectedPointer:  "/user/name",
+		},
+		"errors": {
+			err:              WithSeverity(SeverityInfo, Errors{IsNotEmptyErr, warning}),
+			expectedSeverity: SeverityInfo,
+		},
+		"most serious of errors": {
+			err:              Errors{WithSeverity(SeverityInfo, IsNotEmptyErr), warning},
+			expectedSeverity: SeverityWarning,
+		},
+		"formatted": {
+			err:              WithSeverity(SeverityWarning, Formatted(IsNotEmptyErr, PlainFormatter)),
+			expectedSeverity: SeverityWarning,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSeverity, SeverityOf(tc.err))
+			if tc.expectedPointer != "" {
+				pathErr, ok := tc.err.(PathError)
+				assert.True(t, ok)
+				assert.Equal(t, tc.expectedPointer, pathErr.Pointer)
+			}
+		})
+	}
+
+	t.Run("formatted keeps the formatter", func(t *testing.T) {
+		assert.Equal(t, "value is empty", WithSeverity(SeverityWarning, Formatted(IsNotEmptyErr, PlainFormatter)).Error())
+	})
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(Errors{WithPath("name", warning), WithSeverity(SeverityInfo, errors.New("other error"))})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `[
+			{"pointer":"/name","severity":"warning","code":"is_not_empty","rule":"IsNotEmpty","message":"value is empty"},
+			{"severity":"info","message":"other error"}
+		]`, string(data))
+	})
+	t.Run("logfmt", func(t *testing.T) {
+		assert.Equal(t, `severity=warning code=is_not_empty rule=IsNotEmpty message="value is empty"`, LogfmtFormatter.Format(warning))
+	})
+}
+
+// TestOnlyErrors tests that warnings and infos are dropped.
+func TestOnlyErrors(t *testing.T) {
+	warning := WithSeverity(SeverityWarning, IsNotEmptyErr)
+	info := WithSeverity(SeverityInfo, IsEmptyError)
+
+	tests := map[string]struct {
+		err      error
+		expected error
+	}{
+		"nil": {},
+		"error": {
+			err:      IsNotEmptyErr,
+			expected: IsNotEmptyErr,
+		},
+		"all errors": {
+			err:      Errors{IsNotEmptyErr, Errors{IsEmptyError}},
+			expected: Errors{IsNotEmptyErr, Errors{IsEmptyError}},
+		},
+		"warning": {
+			err: warning,
+		},
+		"wrapped warning": {
+			err: fmt.Errorf("context: %w", PathError{Pointer: "/a", Err: warning}),
+		},
+		"joined warning": {
+			err: errors.Join(warning),
+		},
+		"joined error": {
+			err:      errors.Join(IsNotEmptyErr),
+			expected: errors.Join(IsNotEmptyErr),
+		},
+		"path without error": {
+			err:      PathError{Pointer: "/a"},
+			expected: PathError{Pointer: "/a"},
+		},
+		"warnings and infos": {
+			err: Errors{warning, info},
+		},
+		"single error": {
+			err:      Errors{warning, IsDefaultErr, info},
+			expected: IsDefaultErr,
+		},
+		"many errors": {
+			err:      Errors{warning, IsDefaultErr, info, IsNotDefaultErr},
+			expected: Errors{IsDefaultErr, IsNotDefaultErr},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, OnlyErrors(tc.err))
+		})
+	}
+}
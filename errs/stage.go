@@ -0,0 +1,46 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StageError is an error of a stage converting the value, such as the stage converting a string to an int.
+type StageError struct {
+	Stage string
+	Err   error
+}
+
+var _ error = StageError{}
+
+// Error returns the error message prefixed with the stage.
+func (s StageError) Error() string {
+	return fmt.Sprintf(StageErrorFormat, s.Stage, s.Err)
+}
+
+// Unwrap returns the underlying error.
+func (s StageError) Unwrap() error {
+	return s.Err
+}
+
+// WithStage returns err as the error of the stage.
+// The stage is set inside a PathError, so that its pointer is kept.
+// If err is an Errors, each of the errors is given the stage.
+func WithStage(stage string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return mapInner(err, func(err error) error {
+		return StageError{Stage: stage, Err: err}
+	})
+}
+
+// StageOf returns the stage which failed, which is the innermost stage of err, or "" if there is none.
+func StageOf(err error) string {
+	stage := ""
+	var staged StageError
+	for errors.As(err, &staged) {
+		stage, err = staged.Stage, staged.Err
+	}
+	return stage
+}
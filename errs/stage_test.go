@@ -0,0 +1,85 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithStage tests that errors keep the stage which failed when wrapped.
+func TestWithStage(t *testing.T) {
+	staged := WithStage("int", OutOfRangeError)
+	assert.Equal(t, "stage int: "+OutOfRangeError.Error(), staged.Error())
+	assert.True(t, errors.Is(staged, OutOfRangeError))
+	assert.Equal(t, "int", StageOf(staged))
+
+	tests := map[string]struct {
+		err             error
+		expectedStages  []string
+		expectedPointer string
+	}{
+		"nil": {},
+		"error": {
+			err:            OutOfRangeError,
+			expectedStages: []string{"int"},
+		},
+		"path": {
+			err:             WithPath("age", OutOfRangeError),
+			expectedStages:  []string{"int"},
+			expectedPointer: "/age",
+		},
+		"errors": {
+			err:            Errors{OutOfRangeError, WithSeverity(SeverityWarning, IsEmptyError)},
+			expectedStages: []string{"int", "int"},
+		},
+		"formatted": {
+			err:            Formatted(OutOfRangeError, PlainFormatter),
+			expectedStages: []string{"int"},
+		},
+	}
+
+	for testName, tc := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := WithStage("int", tc.err)
+			if tc.expectedStages == nil {
+				assert.Nil(t, err)
+				return
+			}
+
+			var stages []string
+			for _, item := range Flatten(err) {
+				stages = append(stages, StageOf(item))
+			}
+			assert.Equal(t, tc.expectedStages, stages)
+
+			var pathErr PathError
+			if tc.expectedPointer != "" && assert.True(t, errors.As(err, &pathErr)) {
+				assert.Equal(t, tc.expectedPointer, pathErr.Pointer)
+			}
+		})
+	}
+}
+
+// TestStageOf tests that StageOf returns the innermost stage, which is the stage that failed.
+func TestStageOf(t *testing.T) {
+	assert.Equal(t, "", StageOf(nil))
+	assert.Equal(t, "", StageOf(OutOfRangeError))
+	assert.Equal(t, "inner", StageOf(WithStage("outer", WithName("rule", "", WithStage("inner", OutOfRangeError)))))
+}
+
+// TestStageError_MarshalJSON tests that errors of stages marshal with their stage and plain message.
+func TestStageError_MarshalJSON(t *testing.T) {
+	err := Errors{
+		WithStage("int", WithPath("age", ConvertError.WithParams(map[string]any{"error": "invalid syntax"}))),
+		WithStage("custom", WithName("rule", "", errors.New("custom error"))),
+	}
+	data, marshalErr := json.Marshal(err)
+	assert.Nil(t, marshalErr)
+	assert.JSONEq(t, `[
+		{"pointer":"/age","stage":"int","code":"convert","rule":"Map","message":"value cannot be converted","params":{"error":"invalid syntax"}},
+		{"name":"rule","stage":"custom","message":"custom error"}
+	]`, string(data))
+	assert.Equal(t, `pointer=/age stage=int code=convert rule=Map message="value cannot be converted" params.error="invalid syntax"`, LogfmtFormatter.Format(err[0]))
+}
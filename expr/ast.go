@@ -0,0 +1,54 @@
+package expr
+
+import "regexp"
+
+// node is a node of the syntax tree.
+// Every node embeds the Position of its first token, or of its operator for operations.
+type node interface {
+	position() Position
+}
+
+type literalNode struct {
+	Position
+	val any
+}
+
+type identNode struct {
+	Position
+	name string
+}
+
+type unaryNode struct {
+	Position
+	op tokenKind
+	x  node
+}
+
+type binaryNode struct {
+	Position
+	op   tokenKind
+	x, y node
+}
+
+type memberNode struct {
+	Position
+	x    node
+	name string
+}
+
+type indexNode struct {
+	Position
+	x, idx node
+}
+
+type callNode struct {
+	Position
+	name string
+	args []node
+	re   *regexp.Regexp
+}
+
+type listNode struct {
+	Position
+	elems []node
+}
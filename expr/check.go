@@ -0,0 +1,205 @@
+package expr
+
+import (
+	"regexp"
+)
+
+// checker checks the types of a syntax tree against the type of the value it is evaluated on.
+type checker struct {
+	root *exprType
+}
+
+// check returns the type of n.
+func (c *checker) check(n node) (*exprType, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return literalType(n.val), nil
+	case *identNode:
+		return c.field(c.root, n.name, n.Position)
+	case *memberNode:
+		x, err := c.check(n.x)
+		if err != nil {
+			return nil, err
+		}
+		return c.field(x, n.name, n.Position)
+	case *indexNode:
+		return c.index(n)
+	case *unaryNode:
+		return c.unary(n)
+	case *binaryNode:
+		return c.binary(n)
+	case *callNode:
+		return c.call(n)
+	case *listNode:
+		return c.list(n)
+	}
+	panic("expr: unknown node")
+}
+
+func (c *checker) field(x *exprType, name string, pos Position) (*exprType, error) {
+	switch x.kind {
+	case anyKind:
+		return anyType, nil
+	case mapKind:
+		return x.elem, nil
+	case objectKind:
+		if field, ok := x.fields[name]; ok {
+			return field, nil
+		}
+		return nil, newError(ErrType, pos, "unknown field %q", name)
+	}
+	return nil, newError(ErrType, pos, "cannot access field %q of %s", name, x)
+}
+
+func (c *checker) index(n *indexNode) (*exprType, error) {
+	x, idx, err := c.operands(n.x, n.idx)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case x.kind == anyKind:
+		return anyType, nil
+	case x.kind == listKind && idx.is(numberKind):
+		return x.elem, nil
+	case x.kind == mapKind && idx.is(stringKind):
+		return x.elem, nil
+	}
+	return nil, newError(ErrType, n.Position, "cannot index %s with %s", x, idx)
+}
+
+func (c *checker) unary(n *unaryNode) (*exprType, error) {
+	x, err := c.check(n.x)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == tokNot {
+		return expect(x, boolType, n.Position, "!")
+	}
+	return expect(x, numberType, n.Position, "-")
+}
+
+func (c *checker) binary(n *binaryNode) (*exprType, error) {
+	x, y, err := c.operands(n.x, n.y)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case tokAnd, tokOr:
+		if x.is(boolKind) && y.is(boolKind) {
+			return boolType, nil
+		}
+	case tokEq, tokNe:
+		if x.is(y.kind, nullKind) || y.is(nullKind) {
+			return boolType, nil
+		}
+	case tokLt, tokLe, tokGt, tokGe:
+		if (x.is(numberKind) && y.is(numberKind)) || (x.is(stringKind) && y.is(stringKind)) {
+			return boolType, nil
+		}
+	case tokPlus:
+		switch {
+		case x.kind == anyKind && y.kind == anyKind:
+			return anyType, nil
+		case x.is(numberKind) && y.is(numberKind):
+			return numberType, nil
+		case x.is(stringKind) && y.is(stringKind):
+			return stringType, nil
+		}
+	case tokIn:
+		switch {
+		case y.kind == anyKind, y.kind == mapKind && x.is(stringKind), y.kind == stringKind && x.is(stringKind):
+			return boolType, nil
+		case y.kind == listKind && (x.is(y.elem.kind, nullKind) || y.elem.is(nullKind)):
+			return boolType, nil
+		}
+	default:
+		if x.is(numberKind) && y.is(numberKind) {
+			return numberType, nil
+		}
+	}
+	return nil, newError(ErrType, n.Position, "invalid operation %s %s %s", x, opNames[n.op], y)
+}
+
+func (c *checker) call(n *callNode) (*exprType, error) {
+	fn, ok := functions[n.name]
+	if !ok {
+		return nil, newError(ErrType, n.Position, "unknown function %q", n.name)
+	}
+	if len(n.args) != len(fn.params) {
+		return nil, newError(ErrType, n.Position, "%s expects %d arguments, found %d", n.name, len(fn.params), len(n.args))
+	}
+	for i, arg := range n.args {
+		argType, err := c.check(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !argType.is(fn.params[i]...) {
+			return nil, newError(ErrType, arg.position(), "invalid argument %s to %s", argType, n.name)
+		}
+	}
+
+	if n.name == "matches" {
+		lit, ok := n.args[1].(*literalNode)
+		if !ok {
+			return nil, newError(ErrType, n.args[1].position(), "the pattern of matches must be a string literal")
+		}
+		re, err := regexp.Compile(lit.val.(string))
+		if err != nil {
+			return nil, newError(ErrType, lit.Position, "invalid pattern: %s", err)
+		}
+		n.re = re
+	}
+	return fn.result, nil
+}
+
+func (c *checker) list(n *listNode) (*exprType, error) {
+	var elem *exprType
+	for _, x := range n.elems {
+		typ, err := c.check(x)
+		if err != nil {
+			return nil, err
+		}
+		if elem == nil {
+			elem = typ
+		} else if elem.kind != typ.kind {
+			elem = anyType
+		}
+	}
+	if elem == nil {
+		elem = anyType
+	}
+	return &exprType{kind: listKind, elem: elem}, nil
+}
+
+func (c *checker) operands(x, y node) (*exprType, *exprType, error) {
+	xType, err := c.check(x)
+	if err != nil {
+		return nil, nil, err
+	}
+	yType, err := c.check(y)
+	if err != nil {
+		return nil, nil, err
+	}
+	return xType, yType, nil
+}
+
+// expect returns want if the operand of op has a type which can be used as want.
+func expect(x, want *exprType, pos Position, op string) (*exprType, error) {
+	if !x.is(want.kind) {
+		return nil, newError(ErrType, pos, "invalid operation %s%s", op, x)
+	}
+	return want, nil
+}
+
+func literalType(val any) *exprType {
+	switch val.(type) {
+	case bool:
+		return boolType
+	case float64:
+		return numberType
+	case string:
+		return stringType
+	}
+	return nullType
+}
@@ -0,0 +1,372 @@
+package expr
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Jh123x/go-validate/errs"
+)
+
+var opNames = map[tokenKind]string{
+	tokAnd: "&&", tokOr: "||", tokEq: "==", tokNe: "!=", tokLt: "<", tokLe: "<=", tokGt: ">", tokGe: ">=",
+	tokIn: "in", tokPlus: "+", tokMinus: "-", tokStar: "*", tokSlash: "/", tokPercent: "%",
+}
+
+type function struct {
+	params [][]kind
+	result *exprType
+}
+
+// functions are the functions which can be called in an expression.
+var functions = map[string]function{
+	"len":     {params: [][]kind{{stringKind, listKind, mapKind}}, result: numberType},
+	"lower":   {params: [][]kind{{stringKind}}, result: stringType},
+	"upper":   {params: [][]kind{{stringKind}}, result: stringType},
+	"matches": {params: [][]kind{{stringKind}, {stringKind}}, result: boolType},
+}
+
+// eval evaluates n on the root value.
+// Numbers are evaluated as float64, lists, maps and objects are left as they are.
+// Values with unexpected types return errs.ExprEvalError.
+func eval(n node, root any) (any, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.val, nil
+	case *identNode:
+		return field(root, n.name)
+	case *memberNode:
+		x, err := eval(n.x, root)
+		if err != nil {
+			return nil, err
+		}
+		return field(x, n.name)
+	case *indexNode:
+		return evalIndex(n, root)
+	case *unaryNode:
+		return evalUnary(n, root)
+	case *binaryNode:
+		return evalBinary(n, root)
+	case *callNode:
+		return evalCall(n, root)
+	case *listNode:
+		list := make([]any, 0, len(n.elems))
+		for _, elem := range n.elems {
+			val, err := eval(elem, root)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+		}
+		return list, nil
+	}
+	panic("expr: unknown node")
+}
+
+func evalIndex(n *indexNode, root any) (any, error) {
+	x, err := eval(n.x, root)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := eval(n.idx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, ok := idx.(string); ok {
+		return field(x, key)
+	}
+	i, ok := idx.(float64)
+	v := reflect.ValueOf(x)
+	if !ok || i != math.Trunc(i) || !isList(v) {
+		return nil, errs.ExprEvalError
+	}
+	// The bounds are checked before converting the index, as indexes past the range of an int wrap around.
+	if i < 0 || i >= float64(v.Len()) {
+		return nil, errs.ExprEvalError
+	}
+	return normalize(v.Index(int(i)).Interface())
+}
+
+func evalUnary(n *unaryNode, root any) (any, error) {
+	x, err := eval(n.x, root)
+	if err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case bool:
+		if n.op == tokNot {
+			return !x, nil
+		}
+	case float64:
+		if n.op == tokMinus {
+			return -x, nil
+		}
+	}
+	return nil, errs.ExprEvalError
+}
+
+func evalBinary(n *binaryNode, root any) (any, error) {
+	x, err := eval(n.x, root)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == tokAnd || n.op == tokOr {
+		return evalLogical(n, x, root)
+	}
+
+	y, err := eval(n.y, root)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case tokEq:
+		return equal(x, y), nil
+	case tokNe:
+		return !equal(x, y), nil
+	case tokIn:
+		return contains(y, x)
+	}
+
+	if xStr, ok := x.(string); ok {
+		if yStr, ok := y.(string); ok {
+			return compareStrings(n.op, xStr, yStr)
+		}
+		return nil, errs.ExprEvalError
+	}
+	xNum, xOk := x.(float64)
+	yNum, yOk := y.(float64)
+	if !xOk || !yOk {
+		return nil, errs.ExprEvalError
+	}
+	return arithmetic(n.op, xNum, yNum)
+}
+
+// evalLogical evaluates && and ||, only evaluating the right operand if it is needed.
+func evalLogical(n *binaryNode, x any, root any) (any, error) {
+	xBool, ok := x.(bool)
+	if !ok {
+		return nil, errs.ExprEvalError
+	}
+	if xBool == (n.op == tokOr) {
+		return xBool, nil
+	}
+	y, err := eval(n.y, root)
+	if err != nil {
+		return nil, err
+	}
+	yBool, ok := y.(bool)
+	if !ok {
+		return nil, errs.ExprEvalError
+	}
+	return yBool, nil
+}
+
+func compareStrings(op tokenKind, x, y string) (any, error) {
+	switch op {
+	case tokLt:
+		return x < y, nil
+	case tokLe:
+		return x <= y, nil
+	case tokGt:
+		return x > y, nil
+	case tokGe:
+		return x >= y, nil
+	case tokPlus:
+		return x + y, nil
+	}
+	return nil, errs.ExprEvalError
+}
+
+func arithmetic(op tokenKind, x, y float64) (any, error) {
+	switch op {
+	case tokLt:
+		return x < y, nil
+	case tokLe:
+		return x <= y, nil
+	case tokGt:
+		return x > y, nil
+	case tokGe:
+		return x >= y, nil
+	case tokPlus:
+		return x + y, nil
+	case tokMinus:
+		return x - y, nil
+	case tokStar:
+		return x * y, nil
+	}
+	if y == 0 {
+		return nil, errs.ExprEvalError
+	}
+	if op == tokSlash {
+		return x / y, nil
+	}
+	return math.Mod(x, y), nil
+}
+
+func evalCall(n *callNode, root any) (any, error) {
+	args := make([]any, 0, len(n.args))
+	for _, arg := range n.args {
+		val, err := eval(arg, root)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, val)
+	}
+
+	if n.name == "len" {
+		if str, ok := args[0].(string); ok {
+			return float64(utf8.RuneCountInString(str)), nil
+		}
+		if v := reflect.ValueOf(args[0]); isList(v) || v.Kind() == reflect.Map {
+			return float64(v.Len()), nil
+		}
+		return nil, errs.ExprEvalError
+	}
+
+	str, ok := args[0].(string)
+	if !ok {
+		return nil, errs.ExprEvalError
+	}
+	switch n.name {
+	case "lower":
+		return strings.ToLower(str), nil
+	case "upper":
+		return strings.ToUpper(str), nil
+	}
+	return n.re.MatchString(str), nil
+}
+
+// field returns the field name of a map or struct.
+// Missing map keys are null.
+func field(x any, name string) (any, error) {
+	if m, ok := x.(map[string]any); ok {
+		return normalize(m[name])
+	}
+
+	v := reflect.ValueOf(x)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		val := v.MapIndex(reflect.ValueOf(name).Convert(v.Type().Key()))
+		if !val.IsValid() {
+			return nil, nil
+		}
+		return normalize(val.Interface())
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.IsExported() && (f.Name == name || jsonName(f) == name) {
+				return normalize(v.Field(i).Interface())
+			}
+		}
+	}
+	return nil, errs.ExprEvalError
+}
+
+// normalize converts booleans, numbers and strings of any Go type to bool, float64 and string,
+// dereferences pointers and converts nil pointers to null.
+func normalize(val any) (any, error) {
+	switch v := val.(type) {
+	case nil, bool, float64, string:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case json.Number:
+		num, err := v.Float64()
+		if err != nil {
+			return nil, errs.ExprEvalError
+		}
+		return num, nil
+	}
+
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return normalize(v.Elem().Interface())
+	}
+	return val, nil
+}
+
+// equal compares two evaluated values.
+// Lists are equal if their items are, maps and objects are compared deeply.
+func equal(x, y any) bool {
+	switch x.(type) {
+	case nil, bool, float64, string:
+		return x == y
+	}
+
+	xVal, yVal := reflect.ValueOf(x), reflect.ValueOf(y)
+	if !isList(xVal) || !isList(yVal) {
+		return reflect.DeepEqual(x, y)
+	}
+	if xVal.Len() != yVal.Len() {
+		return false
+	}
+	for i := 0; i < xVal.Len(); i++ {
+		xItem, xErr := normalize(xVal.Index(i).Interface())
+		yItem, yErr := normalize(yVal.Index(i).Interface())
+		if xErr != nil || yErr != nil || !equal(xItem, yItem) {
+			return false
+		}
+	}
+	return true
+}
+
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// contains reports whether elem is an item of a list, a key of a map or a substring of a string.
+func contains(container, elem any) (bool, error) {
+	if str, ok := container.(string); ok {
+		substr, ok := elem.(string)
+		if !ok {
+			return false, errs.ExprEvalError
+		}
+		return strings.Contains(str, substr), nil
+	}
+
+	v := reflect.ValueOf(container)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			item, err := normalize(v.Index(i).Interface())
+			if err != nil {
+				return false, err
+			}
+			if equal(elem, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case reflect.Map:
+		key, ok := elem.(string)
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return false, errs.ExprEvalError
+		}
+		return v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())).IsValid(), nil
+	}
+	return false, errs.ExprEvalError
+}
@@ -0,0 +1,104 @@
+// Package expr implements a small expression language for validation rules, such as
+//
+//	len(name) > 3 && age >= 18 || country in ["SG", "MY"]
+//
+// Expressions are type checked when they are compiled and evaluated on a map or a struct.
+// They cannot call Go code or modify the value, so they are safe to load from configuration.
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+var (
+	// ErrSyntax is wrapped by the errors returned for expressions which cannot be parsed.
+	ErrSyntax = errors.New("syntax error")
+	// ErrType is wrapped by the errors returned for expressions which do not type check.
+	ErrType = errors.New("type error")
+)
+
+// Position is a position in the source of an expression.
+// Line and Column start at 1, Column counts characters and Offset counts bytes.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+func (p Position) position() Position {
+	return p
+}
+
+// Error is an error in the source of an expression.
+type Error struct {
+	Err error
+	Pos Position
+	Msg string
+}
+
+func newError(err error, pos Position, format string, args ...any) *Error {
+	return &Error{Err: err, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at %s: %s", e.Err, e.Pos, e.Msg)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Compile compiles the boolean expression src into a test on values of type T.
+// T should be a struct, a map with string keys or a pointer to one of them.
+// Fields of structs are named by their Go name or their json tag, and are type checked
+// when the expression is compiled. Values of maps with interface values are checked when
+// the expression is evaluated.
+//
+// The test returns errs.ExprError if the expression is false and errs.ExprEvalError
+// if the expression cannot be evaluated on the value, such as when a field has the wrong type.
+func Compile[T any](src string) (ttypes.ValTest[T], error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{root: typeOf(reflect.TypeOf((*T)(nil)).Elem(), make(map[reflect.Type]*exprType))}
+	typ, err := c.check(root)
+	if err != nil {
+		return nil, err
+	}
+	if !typ.is(boolKind) {
+		return nil, newError(ErrType, root.position(), "expression is %s, not bool", typ)
+	}
+
+	return func(val T) error {
+		result, err := eval(root, val)
+		if err != nil {
+			return err
+		}
+		if ok, isBool := result.(bool); !isBool {
+			return errs.ExprEvalError
+		} else if !ok {
+			return errs.ExprError
+		}
+		return nil
+	}, nil
+}
+
+// MustCompile is like Compile but panics if the expression cannot be compiled.
+func MustCompile[T any](src string) ttypes.ValTest[T] {
+	test, err := Compile[T](src)
+	if err != nil {
+		panic(err)
+	}
+	return test
+}
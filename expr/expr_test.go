@@ -0,0 +1,290 @@
+package expr
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	Country string `json:"country"`
+	Postal  *string
+}
+
+type person struct {
+	Name    string            `json:"name"`
+	Age     int               `json:"age"`
+	Score   float32           `json:"-"`
+	Tags    []string          `json:"tags,omitempty"`
+	Labels  map[string]string `json:"labels"`
+	Address *address          `json:"address"`
+	Friends []*person
+	private int
+}
+
+func TestCompile_Map(t *testing.T) {
+	test, err := Compile[map[string]any](`len(name) > 3 && age >= 18 || country in ["SG", "MY"]`)
+	require.Nil(t, err)
+
+	tests := map[string]struct {
+		value       map[string]any
+		expectedErr error
+	}{
+		"adult":             {value: map[string]any{"name": "jh123x", "age": 18}},
+		"local":             {value: map[string]any{"name": "jh", "age": 1, "country": "SG"}},
+		"json number":       {value: map[string]any{"name": "jh123x", "age": json.Number("20")}},
+		"false":             {value: map[string]any{"name": "jh", "age": 20, "country": "US"}, expectedErr: errs.ExprError},
+		"missing field":     {value: map[string]any{"name": "jh123x"}, expectedErr: errs.ExprEvalError},
+		"wrong field type":  {value: map[string]any{"name": 1}, expectedErr: errs.ExprEvalError},
+		"invalid json num":  {value: map[string]any{"name": "jh123x", "age": json.Number("a")}, expectedErr: errs.ExprEvalError},
+		"short circuit":     {value: map[string]any{"name": "jh", "country": "MY"}},
+		"right side needed": {value: map[string]any{"name": "jh", "age": 18, "country": 1}, expectedErr: errs.ExprError},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, test(tc.value))
+		})
+	}
+}
+
+func TestCompile_Struct(t *testing.T) {
+	postal := "123456"
+	value := &person{
+		Name:    "Jh123x",
+		Age:     20,
+		Score:   0.5,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"team": "core"},
+		Address: &address{Country: "SG", Postal: &postal},
+		Friends: []*person{{Name: "friend"}},
+	}
+
+	tests := map[string]struct {
+		expr        string
+		expectedErr error
+	}{
+		"json names":          {expr: `name == "Jh123x" && age == 20`},
+		"go names":            {expr: `Name == "Jh123x" && Score == 0.5`},
+		"nested field":        {expr: `address.country == "SG" && address.Postal == "123456"`},
+		"nil pointer is null": {expr: `Friends[0].Address == null`},
+		"list index":          {expr: `tags[1] == "b" && Friends[0].name == "friend"`},
+		"map index":           {expr: `labels["team"] == "core" && labels.team == "core"`},
+		"missing map key":     {expr: `labels["other"] == null`},
+		"in list":             {expr: `"a" in tags && !("c" in tags)`},
+		"in map":              {expr: `"team" in labels`},
+		"in string":           {expr: `"123" in name`},
+		"string functions":    {expr: `lower(name) == "jh123x" && upper(name) == "JH123X"`},
+		"matches":             {expr: `matches(address.Postal, "^[0-9]{6}$")`},
+		"len":                 {expr: `len(tags) == 2 && len(labels) == 1 && len("日本") == 2`},
+		"arithmetic":          {expr: `(age + 1) * 2 - 2 == 40 && age / 4 == 5 && age % 3 == 2 && -age < 0`},
+		"string compare":      {expr: `name + "!" > "Jh" && name >= "Jh" && name < "Z" && name <= "Z"`},
+		"number compare":      {expr: `age > 1 && age <= 20 && !(age < 20)`},
+		"not equal":           {expr: `name != "other" && tags != ["a"] && tags == ["a", "b"]`},
+		"false":               {expr: `age > 20`, expectedErr: errs.ExprError},
+		"index out of range":  {expr: `tags[2] == "c"`, expectedErr: errs.ExprEvalError},
+		"fractional index":    {expr: `tags[0.5] == "c"`, expectedErr: errs.ExprEvalError},
+		"negative index":      {expr: `tags[-1] == "c"`, expectedErr: errs.ExprEvalError},
+		"index past int":      {expr: `tags[1e300] == "c"`, expectedErr: errs.ExprEvalError},
+		"index wrapping int":  {expr: `tags[9.3e18] == "c"`, expectedErr: errs.ExprEvalError},
+		"division by zero":    {expr: `age / (age - 20) == 1`, expectedErr: errs.ExprEvalError},
+		"modulo by zero":      {expr: `age % 0 == 1`, expectedErr: errs.ExprEvalError},
+		"field of null":       {expr: `Friends[0].Address.country == "SG"`, expectedErr: errs.ExprEvalError},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			test, err := Compile[*person](tc.expr)
+			require.Nil(t, err)
+			assert.Equal(t, tc.expectedErr, test(value))
+		})
+	}
+}
+
+func TestCompile_Any(t *testing.T) {
+	tests := map[string]struct {
+		expr        string
+		value       any
+		expectedErr error
+	}{
+		"map":                  {expr: `a.b[0] == 1`, value: map[string]any{"a": map[string][]int{"b": {1}}}},
+		"named types":          {expr: `a == 1 && b == true && c == "c"`, value: map[string]any{"a": uint8(1), "b": flag(true), "c": name("c")}},
+		"float":                {expr: `a == 1.5 && b == 2`, value: map[string]any{"a": float32(1.5), "b": uint(2)}},
+		"interface pointer":    {expr: `a == 1`, value: map[string]any{"a": ptr[any](1)}},
+		"in array":             {expr: `1 in a`, value: map[string]any{"a": [2]int{1, 2}}},
+		"in named map":         {expr: `"a" in m && !("b" in m)`, value: map[string]any{"m": map[name]int{"a": 1}}},
+		"named key map":        {expr: `m == 1`, value: map[name]int{"m": 1}},
+		"not bool":             {expr: `a`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"not not bool":         {expr: `!a`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"negative of string":   {expr: `-a == 1`, value: map[string]any{"a": "1"}, expectedErr: errs.ExprEvalError},
+		"and not bool":         {expr: `a && true`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"and right not bool":   {expr: `true && a`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"compare mixed":        {expr: `a < 1`, value: map[string]any{"a": "1"}, expectedErr: errs.ExprEvalError},
+		"compare mixed string": {expr: `a < "1"`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"subtract strings":     {expr: `a - b == 0`, value: map[string]any{"a": "1", "b": "1"}, expectedErr: errs.ExprEvalError},
+		"in number":            {expr: `1 in a`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"in string number":     {expr: `a in "1"`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"in map number":        {expr: `1 in a`, value: map[string]any{"a": map[string]any{}}, expectedErr: errs.ExprEvalError},
+		"in invalid list item": {expr: `1 in a`, value: map[string]any{"a": []any{json.Number("a")}}, expectedErr: errs.ExprEvalError},
+		"len of number":        {expr: `len(a) == 1`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"lower of number":      {expr: `lower(a) == "1"`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"index a number":       {expr: `a[0] == 1`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"index past int":       {expr: `a[1e300] == 1`, value: map[string]any{"a": []int{1}}, expectedErr: errs.ExprEvalError},
+		"index wrapping int":   {expr: `a[9.3e18] == 1`, value: map[string]any{"a": []int{1}}, expectedErr: errs.ExprEvalError},
+		"fractional index":     {expr: `a[0.5] == 1`, value: map[string]any{"a": []int{1}}, expectedErr: errs.ExprEvalError},
+		"field of a number":    {expr: `a.b == 1`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+		"field of int map":     {expr: `a.b == 1`, value: map[string]any{"a": map[int]int{}}, expectedErr: errs.ExprEvalError},
+		"unknown field":        {expr: `a.b == 1`, value: map[string]any{"a": address{}}, expectedErr: errs.ExprEvalError},
+		"error in operand":     {expr: `-(a.b) == 1 || [a.b] == [] || lower(a.b) == "" || a.b[0] == 1`, value: map[string]any{"a": 1}, expectedErr: errs.ExprEvalError},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			test, err := Compile[any](tc.expr)
+			require.Nil(t, err)
+			assert.Equal(t, tc.expectedErr, test(tc.value))
+		})
+	}
+}
+
+type (
+	flag bool
+	name string
+)
+
+func ptr[T any](val T) *T {
+	return &val
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := map[string]struct {
+		expr        string
+		expectedErr error
+		expectedMsg string
+	}{
+		"unexpected character":   {expr: `age @ 1`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:5: unexpected character '@'`},
+		"invalid number":         {expr: `age > 1e`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:7: invalid number "1e"`},
+		"number with letters":    {expr: `age > 1a`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:7: invalid number "1a"`},
+		"unterminated string":    {expr: `name == "abc`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:9: unterminated string`},
+		"unterminated escape":    {expr: `name == "abc\`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:9: unterminated string`},
+		"string across lines":    {expr: "name == \"a\nb\"", expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:9: unterminated string`},
+		"invalid escape":         {expr: `name == "\q"`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:9: invalid string "\q"`},
+		"missing operand":        {expr: `age >`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:6: unexpected end of input`},
+		"trailing tokens":        {expr: `age > 1 1`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:9: unexpected "1"`},
+		"chained comparison":     {expr: `1 < age < 2`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:9: unexpected "<"`},
+		"unclosed paren":         {expr: "(age > 1\n && true", expectedErr: ErrSyntax, expectedMsg: `syntax error at 2:9: expected ")", found end of input`},
+		"unclosed list":          {expr: `age in [1, 2`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:13: expected "]", found end of input`},
+		"unclosed index":         {expr: `tags[1 == "a"`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:14: expected "]", found end of input`},
+		"missing field name":     {expr: `address. == 1`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:10: expected field name, found "=="`},
+		"error in list":          {expr: `age in [1, )]`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:12: unexpected ")"`},
+		"error in call":          {expr: `len(>)`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:5: unexpected ">"`},
+		"error in parens":        {expr: `(>)`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:2: unexpected ">"`},
+		"error in index":         {expr: `tags[>]`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:6: unexpected ">"`},
+		"error after not":        {expr: `!>`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:2: unexpected ">"`},
+		"error after operator":   {expr: `age > 1 && >`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:12: unexpected ">"`},
+		"error in compare":       {expr: `age == >`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:8: unexpected ">"`},
+		"error in postfix":       {expr: `(>).a`, expectedErr: ErrSyntax, expectedMsg: `syntax error at 1:2: unexpected ">"`},
+		"unknown field":          {expr: `nam == "a"`, expectedErr: ErrType, expectedMsg: `type error at 1:1: unknown field "nam"`},
+		"unexported field":       {expr: `private == 1`, expectedErr: ErrType, expectedMsg: `type error at 1:1: unknown field "private"`},
+		"ignored json name":      {expr: `score == 1`, expectedErr: ErrType, expectedMsg: `type error at 1:1: unknown field "score"`},
+		"unknown nested field":   {expr: `address.city == "a"`, expectedErr: ErrType, expectedMsg: `type error at 1:9: unknown field "city"`},
+		"field of a string":      {expr: `name.first == "a"`, expectedErr: ErrType, expectedMsg: `type error at 1:6: cannot access field "first" of string`},
+		"index a string":         {expr: `name[0] == "a"`, expectedErr: ErrType, expectedMsg: `type error at 1:5: cannot index string with number`},
+		"index list by string":   {expr: `tags["a"] == "a"`, expectedErr: ErrType, expectedMsg: `type error at 1:5: cannot index list with string`},
+		"mismatched compare":     {expr: `age == "20"`, expectedErr: ErrType, expectedMsg: `type error at 1:5: invalid operation number == string`},
+		"mismatched order":       {expr: `name < 1`, expectedErr: ErrType, expectedMsg: `type error at 1:6: invalid operation string < number`},
+		"add bool":               {expr: `age + true == 1`, expectedErr: ErrType, expectedMsg: `type error at 1:5: invalid operation number + bool`},
+		"multiply strings":       {expr: `name * 2 == 1`, expectedErr: ErrType, expectedMsg: `type error at 1:6: invalid operation string * number`},
+		"and numbers":            {expr: `age && true`, expectedErr: ErrType, expectedMsg: `type error at 1:5: invalid operation number && bool`},
+		"in number":              {expr: `1 in age`, expectedErr: ErrType, expectedMsg: `type error at 1:3: invalid operation number in number`},
+		"in wrong list type":     {expr: `1 in tags`, expectedErr: ErrType, expectedMsg: `type error at 1:3: invalid operation number in list`},
+		"in map number":          {expr: `1 in labels`, expectedErr: ErrType, expectedMsg: `type error at 1:3: invalid operation number in map`},
+		"not number":             {expr: `!age`, expectedErr: ErrType, expectedMsg: `type error at 1:1: invalid operation !number`},
+		"negative string":        {expr: `-name == 1`, expectedErr: ErrType, expectedMsg: `type error at 1:1: invalid operation -string`},
+		"unknown function":       {expr: `size(tags) == 1`, expectedErr: ErrType, expectedMsg: `type error at 1:1: unknown function "size"`},
+		"wrong argument count":   {expr: `len(tags, name) == 1`, expectedErr: ErrType, expectedMsg: `type error at 1:1: len expects 1 arguments, found 2`},
+		"wrong argument type":    {expr: `len(age) == 1`, expectedErr: ErrType, expectedMsg: `type error at 1:5: invalid argument number to len`},
+		"pattern not a literal":  {expr: `matches(name, name)`, expectedErr: ErrType, expectedMsg: `type error at 1:15: the pattern of matches must be a string literal`},
+		"invalid pattern":        {expr: `matches(name, "(")`, expectedErr: ErrType, expectedMsg: "type error at 1:15: invalid pattern: error parsing regexp: missing closing ): `(`"},
+		"not a boolean":          {expr: `age + 1`, expectedErr: ErrType, expectedMsg: `type error at 1:5: expression is number, not bool`},
+		"error in left operand":  {expr: `nam + 1`, expectedErr: ErrType, expectedMsg: `type error at 1:1: unknown field "nam"`},
+		"error in right operand": {expr: `1 + nam`, expectedErr: ErrType, expectedMsg: `type error at 1:5: unknown field "nam"`},
+		"error in unary":         {expr: `!nam`, expectedErr: ErrType, expectedMsg: `type error at 1:2: unknown field "nam"`},
+		"error in member":        {expr: `nam.a`, expectedErr: ErrType, expectedMsg: `type error at 1:1: unknown field "nam"`},
+		"error in argument":      {expr: `len(nam)`, expectedErr: ErrType, expectedMsg: `type error at 1:5: unknown field "nam"`},
+		"error in list item":     {expr: `1 in [nam]`, expectedErr: ErrType, expectedMsg: `type error at 1:7: unknown field "nam"`},
+		"columns count runes":    {expr: `"日本" == nam`, expectedErr: ErrType, expectedMsg: `type error at 1:9: unknown field "nam"`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			test, err := Compile[person](tc.expr)
+			assert.Nil(t, test)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.EqualError(t, err, tc.expectedMsg)
+		})
+	}
+}
+
+func TestCompile_Depth(t *testing.T) {
+	nested := func(n int, open, close string) string {
+		return strings.Repeat(open, n) + "true" + strings.Repeat(close, n)
+	}
+	chain := func(n int) string {
+		return "true" + strings.Repeat(" && true", n)
+	}
+
+	tests := map[string]struct {
+		expr        string
+		expectedMsg string
+	}{
+		"parentheses":      {expr: nested(maxDepth-1, "(", ")")},
+		"deep parentheses": {expr: nested(maxDepth, "(", ")"), expectedMsg: `syntax error at 1:1001: expression is nested more than 1000 times`},
+		"lists":            {expr: nested(maxDepth-1, "[", "]") + " == []"},
+		"deep lists":       {expr: nested(maxDepth, "[", "]") + " == []", expectedMsg: `syntax error at 1:1001: expression is nested more than 1000 times`},
+		"unary operators":  {expr: nested(maxDepth-1, "!", "")},
+		"deep unary":       {expr: nested(maxDepth, "!", ""), expectedMsg: `syntax error at 1:1001: expression is nested more than 1000 times`},
+		"operations":       {expr: chain(maxDepth - 1)},
+		"deep operations":  {expr: chain(maxDepth), expectedMsg: `syntax error at 1:8001: expression is nested more than 1000 times`},
+		"fields":           {expr: "a" + strings.Repeat(".a", maxDepth-1) + " == null"},
+		"deep fields":      {expr: "a" + strings.Repeat(".a", maxDepth) + " == null", expectedMsg: `syntax error at 1:2000: expression is nested more than 1000 times`},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile[any](tc.expr)
+			if tc.expectedMsg == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrSyntax)
+			assert.EqualError(t, err, tc.expectedMsg)
+		})
+	}
+}
+
+func TestCompile_StaticTypes(t *testing.T) {
+	valid := []string{
+		`[] == [1] && [1, "a"] == [1, "a"] && 1 in [1, 2] && null in [1] && 1 in [null]`,
+		`Friends[0].Friends[0].name == "a" && Address == null && null != Address`,
+		`labels["a"] + "b" == "ab" && address.country + labels.a > ""`,
+	}
+	for _, src := range valid {
+		_, err := Compile[person](src)
+		assert.Nil(t, err, src)
+	}
+
+	_, err := Compile[map[int]string](`a == 1`)
+	assert.Nil(t, err)
+	_, err = Compile[any](`a + b`)
+	assert.Nil(t, err)
+}
+
+func TestMustCompile(t *testing.T) {
+	assert.Nil(t, MustCompile[map[string]int](`a == 1`)(map[string]int{"a": 1}))
+	assert.Panics(t, func() { MustCompile[any](`(`) })
+}
@@ -0,0 +1,230 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokTrue
+	tokFalse
+	tokNull
+	tokIn
+	tokAnd
+	tokOr
+	tokNot
+	tokEq
+	tokNe
+	tokLt
+	tokLe
+	tokGt
+	tokGe
+	tokPlus
+	tokMinus
+	tokStar
+	tokSlash
+	tokPercent
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+	tokDot
+)
+
+var keywords = map[string]tokenKind{
+	"true":  tokTrue,
+	"false": tokFalse,
+	"null":  tokNull,
+	"in":    tokIn,
+}
+
+// operators maps the operators to their tokens, longest first where they share a prefix.
+var operators = []struct {
+	text string
+	kind tokenKind
+}{
+	{"&&", tokAnd}, {"||", tokOr}, {"==", tokEq}, {"!=", tokNe}, {"<=", tokLe}, {">=", tokGe},
+	{"!", tokNot}, {"<", tokLt}, {">", tokGt}, {"+", tokPlus}, {"-", tokMinus}, {"*", tokStar},
+	{"/", tokSlash}, {"%", tokPercent}, {"(", tokLParen}, {")", tokRParen}, {"[", tokLBracket},
+	{"]", tokRBracket}, {",", tokComma}, {".", tokDot},
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  Position
+	num  float64
+	str  string
+}
+
+// describe returns the token as it is shown in error messages.
+func (t token) describe() string {
+	if t.kind == tokEOF {
+		return "end of input"
+	}
+	return strconv.Quote(t.text)
+}
+
+type lexer struct {
+	src string
+	pos Position
+}
+
+// lex splits src into tokens, ending with an EOF token.
+func lex(src string) ([]token, error) {
+	l := &lexer{src: src, pos: Position{Line: 1, Column: 1}}
+	tokens := make([]token, 0, len(src)/2+1)
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.kind == tokEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos.Offset < len(l.src) {
+		r, _ := utf8.DecodeRuneInString(l.src[l.pos.Offset:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.advance()
+	}
+
+	start := l.pos
+	if start.Offset >= len(l.src) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	rest := l.src[start.Offset:]
+	r, _ := utf8.DecodeRuneInString(rest)
+	switch {
+	case isIdentStart(r):
+		return l.ident(start), nil
+	case r >= '0' && r <= '9':
+		return l.number(start)
+	case r == '"':
+		return l.string(start)
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op.text) {
+			l.skip(len(op.text))
+			return token{kind: op.kind, text: op.text, pos: start}, nil
+		}
+	}
+	return token{}, newError(ErrSyntax, start, "unexpected character %q", r)
+}
+
+func (l *lexer) ident(start Position) token {
+	l.skipWhile(func(r rune) bool { return isIdentStart(r) || unicode.IsDigit(r) })
+	text := l.src[start.Offset:l.pos.Offset]
+	kind, ok := keywords[text]
+	if !ok {
+		kind = tokIdent
+	}
+	return token{kind: kind, text: text, pos: start}
+}
+
+func (l *lexer) number(start Position) (token, error) {
+	l.skipWhile(isDigit)
+	if l.peek() == '.' {
+		l.skip(1)
+		l.skipWhile(isDigit)
+	}
+	if r := l.peek(); r == 'e' || r == 'E' {
+		l.skip(1)
+		if r := l.peek(); r == '+' || r == '-' {
+			l.skip(1)
+		}
+		l.skipWhile(isDigit)
+	}
+	l.skipWhile(func(r rune) bool { return isIdentStart(r) || unicode.IsDigit(r) })
+
+	text := l.src[start.Offset:l.pos.Offset]
+	num, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return token{}, newError(ErrSyntax, start, "invalid number %q", text)
+	}
+	return token{kind: tokNumber, text: text, pos: start, num: num}, nil
+}
+
+func (l *lexer) string(start Position) (token, error) {
+	l.skip(1)
+	for {
+		switch r := l.peek(); r {
+		case -1, '\n':
+			return token{}, newError(ErrSyntax, start, "unterminated string")
+		case '\\':
+			l.skip(1)
+			if l.peek() == -1 {
+				return token{}, newError(ErrSyntax, start, "unterminated string")
+			}
+			l.advance()
+		case '"':
+			l.skip(1)
+			text := l.src[start.Offset:l.pos.Offset]
+			str, err := strconv.Unquote(text)
+			if err != nil {
+				return token{}, newError(ErrSyntax, start, "invalid string %s", text)
+			}
+			return token{kind: tokString, text: text, pos: start, str: str}, nil
+		default:
+			l.advance()
+		}
+	}
+}
+
+// peek returns the next rune, or -1 at the end of the input.
+func (l *lexer) peek() rune {
+	if l.pos.Offset >= len(l.src) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos.Offset:])
+	return r
+}
+
+// advance moves past the next rune.
+func (l *lexer) advance() {
+	r, size := utf8.DecodeRuneInString(l.src[l.pos.Offset:])
+	l.pos.Offset += size
+	if r == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+		return
+	}
+	l.pos.Column++
+}
+
+// skip skips n bytes of single byte characters.
+func (l *lexer) skip(n int) {
+	l.pos.Offset += n
+	l.pos.Column += n
+}
+
+func (l *lexer) skipWhile(accept func(rune) bool) {
+	for r := l.peek(); r != -1 && accept(r); r = l.peek() {
+		l.advance()
+	}
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
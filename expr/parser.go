@@ -0,0 +1,249 @@
+package expr
+
+// parser is a recursive descent parser. From the lowest to the highest precedence, the grammar is:
+//
+//	or      = and { "||" and }
+//	and     = compare { "&&" compare }
+//	compare = sum [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" ) sum ]
+//	sum     = product { ( "+" | "-" ) product }
+//	product = unary { ( "*" | "/" | "%" ) unary }
+//	unary   = ( "!" | "-" ) unary | postfix
+//	postfix = primary { "." ident | "[" or "]" }
+//	primary = number | string | "true" | "false" | "null" | ident [ "(" [ or { "," or } ] ")" ]
+//	        | "(" or ")" | "[" [ or { "," or } ] "]"
+//
+// Expressions are nested at most maxDepth times, counting parentheses, lists, indexes, fields, unary operators and
+// each operation of a chain such as "a + b + c", which bounds the recursion of the parser and of the evaluator.
+type parser struct {
+	tokens []token
+	next   int
+	depth  int
+}
+
+const maxDepth = 1000
+
+// parse parses src into a syntax tree.
+func parse(src string) (node, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.unexpected(tok)
+	}
+	return root, nil
+}
+
+func (p *parser) or() (node, error) {
+	return p.binary(p.and, tokOr)
+}
+
+func (p *parser) and() (node, error) {
+	return p.binary(p.compare, tokAnd)
+}
+
+func (p *parser) compare() (node, error) {
+	x, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek(); op.kind {
+	case tokEq, tokNe, tokLt, tokLe, tokGt, tokGe, tokIn:
+		p.next++
+		y, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryNode{Position: op.pos, op: op.kind, x: x, y: y}, nil
+	}
+	return x, nil
+}
+
+func (p *parser) sum() (node, error) {
+	return p.binary(p.product, tokPlus, tokMinus)
+}
+
+func (p *parser) product() (node, error) {
+	return p.binary(p.unary, tokStar, tokSlash, tokPercent)
+}
+
+// binary parses left associative operations of ops between operands parsed by operand.
+func (p *parser) binary(operand func() (node, error), ops ...tokenKind) (node, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	defer p.leave(p.depth)
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return x, nil
+		}
+		if err := p.enter(op); err != nil {
+			return nil, err
+		}
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{Position: op.pos, op: op.kind, x: x, y: y}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	if err := p.enter(p.peek()); err != nil {
+		return nil, err
+	}
+	defer p.leave(p.depth - 1)
+	if op, ok := p.accept(tokNot, tokMinus); ok {
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{Position: op.pos, op: op.kind, x: x}, nil
+	}
+	return p.postfix()
+}
+
+func (p *parser) postfix() (node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	defer p.leave(p.depth)
+	for {
+		tok, ok := p.accept(tokDot, tokLBracket)
+		if !ok {
+			return x, nil
+		}
+		if err := p.enter(tok); err != nil {
+			return nil, err
+		}
+		if tok.kind == tokDot {
+			name, err := p.expect(tokIdent, "field name")
+			if err != nil {
+				return nil, err
+			}
+			x = &memberNode{Position: name.pos, x: x, name: name.text}
+			continue
+		}
+
+		idx, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRBracket, `"]"`); err != nil {
+			return nil, err
+		}
+		x = &indexNode{Position: tok.pos, x: x, idx: idx}
+	}
+}
+
+func (p *parser) primary() (node, error) {
+	tok := p.peek()
+	p.next++
+	switch tok.kind {
+	case tokNumber:
+		return &literalNode{Position: tok.pos, val: tok.num}, nil
+	case tokString:
+		return &literalNode{Position: tok.pos, val: tok.str}, nil
+	case tokTrue, tokFalse:
+		return &literalNode{Position: tok.pos, val: tok.kind == tokTrue}, nil
+	case tokNull:
+		return &literalNode{Position: tok.pos}, nil
+	case tokIdent:
+		if _, ok := p.accept(tokLParen); !ok {
+			return &identNode{Position: tok.pos, name: tok.text}, nil
+		}
+		args, err := p.list(tokRParen, `")"`)
+		if err != nil {
+			return nil, err
+		}
+		return &callNode{Position: tok.pos, name: tok.text, args: args}, nil
+	case tokLParen:
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return x, nil
+	case tokLBracket:
+		elems, err := p.list(tokRBracket, `"]"`)
+		if err != nil {
+			return nil, err
+		}
+		return &listNode{Position: tok.pos, elems: elems}, nil
+	}
+	return nil, p.unexpected(tok)
+}
+
+// list parses a comma separated list of expressions ending with the end token.
+func (p *parser) list(end tokenKind, endName string) ([]node, error) {
+	nodes := make([]node, 0)
+	if _, ok := p.accept(end); ok {
+		return nodes, nil
+	}
+	for {
+		x, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, x)
+		if _, ok := p.accept(tokComma); ok {
+			continue
+		}
+		if _, err := p.expect(end, endName); err != nil {
+			return nil, err
+		}
+		return nodes, nil
+	}
+}
+
+// enter increments the depth of the expression being parsed at tok, which must not exceed maxDepth.
+func (p *parser) enter(tok token) error {
+	if p.depth++; p.depth > maxDepth {
+		return newError(ErrSyntax, tok.pos, "expression is nested more than %d times", maxDepth)
+	}
+	return nil
+}
+
+// leave restores the depth of the expression being parsed.
+func (p *parser) leave(depth int) {
+	p.depth = depth
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+// accept consumes the next token if it is one of kinds.
+func (p *parser) accept(kinds ...tokenKind) (token, bool) {
+	tok := p.peek()
+	for _, kind := range kinds {
+		if tok.kind == kind {
+			p.next++
+			return tok, true
+		}
+	}
+	return tok, false
+}
+
+// expect consumes the next token, which must be of the kind described by name.
+func (p *parser) expect(kind tokenKind, name string) (token, error) {
+	tok, ok := p.accept(kind)
+	if !ok {
+		return tok, newError(ErrSyntax, tok.pos, "expected %s, found %s", name, tok.describe())
+	}
+	return tok, nil
+}
+
+func (p *parser) unexpected(tok token) error {
+	return newError(ErrSyntax, tok.pos, "unexpected %s", tok.describe())
+}
@@ -0,0 +1,123 @@
+package expr
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+type kind int
+
+const (
+	anyKind kind = iota
+	nullKind
+	boolKind
+	numberKind
+	stringKind
+	listKind
+	mapKind
+	objectKind
+)
+
+var kindNames = map[kind]string{
+	anyKind:    "any",
+	nullKind:   "null",
+	boolKind:   "bool",
+	numberKind: "number",
+	stringKind: "string",
+	listKind:   "list",
+	mapKind:    "map",
+	objectKind: "object",
+}
+
+// exprType is the static type of an expression.
+// Values of type any are only checked when the expression is evaluated.
+type exprType struct {
+	kind kind
+	// elem is the type of the items of a list or the values of a map.
+	elem *exprType
+	// fields are the types of the fields of an object.
+	fields map[string]*exprType
+}
+
+var (
+	anyType    = &exprType{kind: anyKind}
+	nullType   = &exprType{kind: nullKind}
+	boolType   = &exprType{kind: boolKind}
+	numberType = &exprType{kind: numberKind}
+	stringType = &exprType{kind: stringKind}
+)
+
+func (t *exprType) String() string {
+	return kindNames[t.kind]
+}
+
+// is reports whether a value of type t can be used where one of kinds is expected.
+func (t *exprType) is(kinds ...kind) bool {
+	if t.kind == anyKind {
+		return true
+	}
+	for _, k := range kinds {
+		if t.kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// typeOf returns the static type of values of the Go type t.
+// seen holds the struct types being converted, so that recursive types terminate.
+func typeOf(t reflect.Type, seen map[reflect.Type]*exprType) *exprType {
+	if t == jsonNumberType {
+		return numberType
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return boolType
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return numberType
+	case reflect.String:
+		return stringType
+	case reflect.Slice, reflect.Array:
+		return &exprType{kind: listKind, elem: typeOf(t.Elem(), seen)}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return anyType
+		}
+		return &exprType{kind: mapKind, elem: typeOf(t.Elem(), seen)}
+	case reflect.Pointer:
+		return typeOf(t.Elem(), seen)
+	case reflect.Struct:
+		if typ, ok := seen[t]; ok {
+			return typ
+		}
+		typ := &exprType{kind: objectKind, fields: make(map[string]*exprType)}
+		seen[t] = typ
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldType := typeOf(field.Type, seen)
+			typ.fields[field.Name] = fieldType
+			if name := jsonName(field); name != "" {
+				typ.fields[name] = fieldType
+			}
+		}
+		return typ
+	}
+	return anyType
+}
+
+// jsonName returns the name of the field in its json tag.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
@@ -6,10 +6,11 @@ require (
 	github.com/gozelle/lo v0.0.0-20230404085901-7f533ca6b597 // For Parallel Map
 	github.com/invopop/validation v0.3.0 // For Benchmark
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/text v0.14.0 // For Unicode Normalization
+	gopkg.in/yaml.v3 v3.0.1 // For Rule Definitions
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,55 @@
+package hooks
+
+import (
+	"context"
+	"expvar"
+	"runtime/trace"
+	"sync"
+)
+
+// mu serialises the lookup and publication of expvar maps, which panics if a name is published twice.
+var mu sync.Mutex
+
+// FailureCounter returns the hooks counting the failures of each rule in m, keyed by rule name.
+func FailureCounter(m *expvar.Map) Hooks {
+	return Hooks{
+		OnFailure: func(rule Rule, _ error) { m.Add(rule.Name, 1) },
+	}
+}
+
+// ExpvarFailures returns the hooks counting the failures of each rule in the expvar map
+// published under name, which is published if it does not exist yet.
+// It panics if another kind of variable is published under name.
+func ExpvarFailures(name string) Hooks {
+	return FailureCounter(publishedMap(name))
+}
+
+func publishedMap(name string) *expvar.Map {
+	mu.Lock()
+	defer mu.Unlock()
+	if v := expvar.Get(name); v != nil {
+		return v.(*expvar.Map)
+	}
+	return expvar.NewMap(name)
+}
+
+// Regions returns the hooks emitting a runtime/trace region for each rule, named after the rule.
+// The regions are only recorded while an execution trace is being taken, such as with go test -trace.
+func Regions() Hooks {
+	return regions(startRegion)
+}
+
+// regions returns the hooks calling start with the name of each rule before it is evaluated.
+func regions(start func(name string) (end func())) Hooks {
+	return Hooks{
+		BeforeRule: func(rule Rule) func() { return start(rule.Name) },
+	}
+}
+
+// startRegion starts the runtime/trace region named name, if an execution trace is being taken.
+func startRegion(name string) func() {
+	if !trace.IsEnabled() {
+		return nil
+	}
+	return trace.StartRegion(context.Background(), name).End
+}
@@ -0,0 +1,65 @@
+package hooks
+
+import (
+	"expvar"
+	"io"
+	"runtime/trace"
+	"testing"
+
+	"github.com/Jh123x/go-validate/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureCounter(t *testing.T) {
+	m := new(expvar.Map)
+	counter := FailureCounter(m)
+	for i := 0; i < 3; i++ {
+		_ = Run(options.And(options.IsNotEmpty("a"), options.IsEmpty("a")), counter)
+	}
+	_ = Run(options.Warn(options.IsEmpty("a")), counter)
+
+	assert.Nil(t, m.Get("IsNotEmpty"))
+	assert.Equal(t, "3", m.Get("And").String())
+	assert.Equal(t, "4", m.Get("IsEmpty").String())
+}
+
+func TestExpvarFailures(t *testing.T) {
+	_ = Run(options.IsEmpty("a"), ExpvarFailures("hooks_test_failures"))
+	_ = Run(options.IsEmpty("a"), ExpvarFailures("hooks_test_failures"))
+
+	m, ok := expvar.Get("hooks_test_failures").(*expvar.Map)
+	require.True(t, ok)
+	assert.Equal(t, "2", m.Get("IsEmpty").String())
+
+	expvar.NewInt("hooks_test_int")
+	assert.Panics(t, func() { ExpvarFailures("hooks_test_int") })
+}
+
+func TestRegions(t *testing.T) {
+	regions := Regions()
+	assert.Nil(t, regions.BeforeRule(Rule{Name: "IsEmpty"}))
+
+	require.Nil(t, trace.Start(io.Discard))
+	defer trace.Stop()
+	end := regions.BeforeRule(Rule{Name: "IsEmpty"})
+	require.NotNil(t, end)
+	end()
+	assert.Nil(t, Run(options.IsEmpty(""), regions))
+}
+
+// TestNamedRule tests that the regions and the failure counters of a named rule are named after it.
+func TestNamedRule(t *testing.T) {
+	var started []string
+	recordRegions := regions(func(name string) func() {
+		started = append(started, name)
+		return nil
+	})
+	m := new(expvar.Map)
+	rule := options.Named("age-adult", options.IsInRange(1, 18, 150))
+	_ = Run(rule, recordRegions, FailureCounter(m))
+
+	assert.Equal(t, []string{"age-adult", "IsInRange"}, started)
+	assert.Equal(t, "1", m.Get("age-adult").String())
+	assert.Equal(t, "1", m.Get("IsInRange").String())
+}
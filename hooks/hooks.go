@@ -0,0 +1,100 @@
+// Package hooks calls functions around the rules evaluated by a validation,
+// such as to count the rules which fail most or to see them in an execution trace.
+package hooks
+
+import (
+	"time"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/internal/scope"
+)
+
+// Rule is a named rule evaluated by a validation, such as a built-in option, an option given
+// a name with options.Named, or the field of options.VField, which is named after its JSON Pointer.
+// Rules without a name, such as custom options made with options.WithRequire, are not reported.
+type Rule struct {
+	Name        string
+	Description string
+	Input       any
+}
+
+// Hooks are called around each rule evaluated by a validation, on the goroutine evaluating it.
+// The rule is named before it is evaluated, so BeforeRule receives the same rule as AfterRule.
+// Any of the hooks may be nil. They must be safe for concurrent use with ParallelLazyValidator.
+type Hooks struct {
+	// BeforeRule is called before the rule is evaluated.
+	// The function it returns, if any, is called once the rule returns, before AfterRule.
+	BeforeRule func(rule Rule) (end func())
+	// AfterRule is called after the rule is evaluated, with its error, if any, and its duration.
+	AfterRule func(rule Rule, err error, duration time.Duration)
+	// OnFailure is called after the rule fails. Warnings and infos are not failures.
+	OnFailure func(rule Rule, err error)
+}
+
+// Run calls rule, calling the hooks around it and around each of the rules it evaluates.
+// The rules evaluated by custom options, such as a validator called by the option, are not reported.
+func Run(rule func() error, hooks ...Hooks) error {
+	if len(hooks) == 0 {
+		return rule()
+	}
+	return newScope(hooks).Eval(rule)
+}
+
+// RunValue calls rule on val, calling the hooks around it and around each of the rules it evaluates.
+func RunValue[T any](val T, rule func(T) error, hooks ...Hooks) error {
+	if len(hooks) == 0 {
+		return rule(val)
+	}
+	return scope.EvalValue(newScope(hooks), val, rule)
+}
+
+// newScope returns the scope calling the hooks.
+func newScope(hooks []Hooks) *scope.Scope {
+	observers := make([]scope.Observer, 0, len(hooks))
+	for _, h := range hooks {
+		observers = append(observers, observer{hooks: h})
+	}
+	return scope.New(observers, make([]any, len(hooks)))
+}
+
+// observer calls the hooks for the named rules of a scope.
+type observer struct {
+	hooks Hooks
+}
+
+// call is a named rule being evaluated.
+type call struct {
+	rule Rule
+	end  func()
+}
+
+// Enter calls BeforeRule for named rules.
+func (o observer) Enter(_ any, rule scope.Rule, input any) any {
+	if rule.Name == "" {
+		return nil
+	}
+	c := &call{rule: Rule{Name: rule.Name, Description: rule.Description, Input: input}}
+	if o.hooks.BeforeRule != nil {
+		c.end = o.hooks.BeforeRule(c.rule)
+	}
+	return c
+}
+
+// Exit calls AfterRule and OnFailure for named rules.
+func (o observer) Exit(token any, err error, duration time.Duration) {
+	c, ok := token.(*call)
+	if !ok {
+		return
+	}
+	if c.end != nil {
+		c.end()
+	}
+	if o.hooks.AfterRule != nil {
+		o.hooks.AfterRule(c.rule, err, duration)
+	}
+	if o.hooks.OnFailure != nil {
+		if err = errs.OnlyErrors(err); err != nil {
+			o.hooks.OnFailure(c.rule, err)
+		}
+	}
+}
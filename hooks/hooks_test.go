@@ -0,0 +1,120 @@
+package hooks
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder records the calls of its hooks.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) record(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) hooks() Hooks {
+	return Hooks{
+		BeforeRule: func(rule Rule) func() {
+			r.record("before %s %v", rule.Name, rule.Input)
+			return func() { r.record("end %s", rule.Name) }
+		},
+		AfterRule: func(rule Rule, err error, _ time.Duration) {
+			r.record("after %s %v", rule.Name, err != nil)
+		},
+		OnFailure: func(rule Rule, err error) {
+			r.record("failure %s", rule.Name)
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		run      func(h Hooks) error
+		expected []string
+	}{
+		"passed": {
+			run: func(h Hooks) error { return Run(options.IsNotEmpty("a"), h) },
+			expected: []string{
+				"before IsNotEmpty <nil>", "end IsNotEmpty", "after IsNotEmpty false",
+			},
+		},
+		"failed": {
+			run: func(h Hooks) error { return Run(options.Named("name", options.IsNotEmpty("")), h) },
+			expected: []string{
+				"before name <nil>", "before IsNotEmpty <nil>",
+				"end IsNotEmpty", "after IsNotEmpty true", "failure IsNotEmpty",
+				"end name", "after name true", "failure name",
+			},
+		},
+		"values": {
+			run: func(h Hooks) error {
+				return RunValue(1, options.VField("age", func(v int) int { return v }, options.VIsInRange(18, 150)), h)
+			},
+			expected: []string{
+				"before /age 1", "before VIsInRange 1",
+				"end VIsInRange", "after VIsInRange true", "failure VIsInRange",
+				"end /age", "after /age true", "failure /age",
+			},
+		},
+		"warnings": {
+			run: func(h Hooks) error { return Run(options.And(options.Warn(options.IsEmpty("a"))), h) },
+			expected: []string{
+				"before And <nil>", "before Warn <nil>", "before IsEmpty <nil>",
+				"end IsEmpty", "after IsEmpty true", "failure IsEmpty",
+				"end Warn", "after Warn true",
+				"end And", "after And true",
+			},
+		},
+		"unnamed rules": {
+			run:      func(h Hooks) error { return Run(options.WithRequire(func() bool { return false }, assert.AnError), h) },
+			expected: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{}
+			_ = tc.run(r.hooks())
+			assert.Equal(t, tc.expected, r.calls)
+		})
+	}
+}
+
+// TestRun_Hooks tests that every hook is called, in order, and that the hooks may be nil.
+func TestRun_Hooks(t *testing.T) {
+	first, second := &recorder{}, &recorder{}
+	err := Run(options.IsEmpty("a"), first.hooks(), Hooks{}, second.hooks())
+	assert.Equal(t, errs.IsEmptyError, err)
+	assert.Equal(t, first.calls, second.calls)
+	assert.Len(t, first.calls, 4)
+
+	assert.Nil(t, Run(options.IsEmpty("")))
+	assert.Equal(t, errs.IsEmptyError, options.IsEmpty("a")())
+}
+
+// TestRun_Concurrent tests that the hooks are not called for rules evaluated outside of Run.
+func TestRun_Concurrent(t *testing.T) {
+	r := &recorder{}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	_ = Run(func() error {
+		go func() {
+			defer wg.Done()
+			_ = options.IsEmpty("a")()
+		}()
+		wg.Wait()
+		return nil
+	}, r.hooks())
+	assert.Nil(t, r.calls)
+}
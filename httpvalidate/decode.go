@@ -0,0 +1,215 @@
+package httpvalidate
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Jh123x/go-validate/errs"
+)
+
+// Source is the part of the request a value was decoded from.
+type Source string
+
+const (
+	BodySource   Source = "body"
+	QuerySource  Source = "query"
+	HeaderSource Source = "header"
+)
+
+var (
+	// ErrUnsupportedMediaType is returned when the request body is not JSON.
+	ErrUnsupportedMediaType = errors.New("request body must be application/json")
+	// ErrBodyTooLarge is returned when the request body is larger than the maximum size.
+	ErrBodyTooLarge = errors.New("request body is too large")
+	// ErrInvalidBody is returned when the request body is not valid JSON for the value.
+	ErrInvalidBody = errors.New("request body is invalid")
+	// ErrInvalidValue is returned when a query parameter or header cannot be parsed.
+	ErrInvalidValue = errors.New("invalid value")
+)
+
+// DecodeError is an error found while decoding a request.
+// Name is the query parameter or header name, or the JSON Pointer of the value in the body.
+type DecodeError struct {
+	Source Source
+	Name   string
+	Err    error
+}
+
+var _ error = DecodeError{}
+
+func (d DecodeError) Error() string {
+	if d.Name == "" {
+		return fmt.Sprintf("%s: %s", d.Source, d.Err)
+	}
+	return fmt.Sprintf("%s %q: %s", d.Source, d.Name, d.Err)
+}
+
+func (d DecodeError) Unwrap() error {
+	return d.Err
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// Decode decodes the request into a new T.
+// The JSON body is decoded first, then the query parameters into the fields tagged with
+// `query:"name"` and the headers into the fields tagged with `header:"Name"`.
+// T must be a struct to have query parameters and headers decoded into it.
+//
+// The errors are DecodeErrors. The errors of every query parameter and header are returned together as errs.Errors.
+func Decode[T any](r *http.Request, opts ...Option) (T, error) {
+	cfg := newConfig(opts)
+	var val T
+	if err := decodeBody(r, &val, cfg); err != nil {
+		return val, err
+	}
+
+	v := reflect.ValueOf(&val).Elem()
+	if v.Kind() != reflect.Struct {
+		return val, nil
+	}
+	query := r.URL.Query()
+	var found errs.Errors
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if name, ok := field.Tag.Lookup("query"); ok {
+			if values, ok := query[name]; ok {
+				if err := setField(v.Field(i), values); err != nil {
+					found = append(found, DecodeError{Source: QuerySource, Name: name, Err: err})
+				}
+			}
+		}
+		if name, ok := field.Tag.Lookup("header"); ok {
+			if values := r.Header.Values(name); len(values) > 0 {
+				if err := setField(v.Field(i), values); err != nil {
+					found = append(found, DecodeError{Source: HeaderSource, Name: name, Err: err})
+				}
+			}
+		}
+	}
+	if len(found) > 0 {
+		return val, found
+	}
+	return val, nil
+}
+
+func decodeBody(r *http.Request, val any, cfg *config) error {
+	if r.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, cfg.maxBodySize+1))
+	if err != nil {
+		return DecodeError{Source: BodySource, Err: fmt.Errorf("%w: %s", ErrInvalidBody, err)}
+	}
+	if int64(len(body)) > cfg.maxBodySize {
+		return DecodeError{Source: BodySource, Err: ErrBodyTooLarge}
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if !isJSON(r.Header.Get("Content-Type")) {
+		return DecodeError{Source: BodySource, Err: ErrUnsupportedMediaType}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if cfg.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(val); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return DecodeError{Source: BodySource, Name: fieldPointer(typeErr.Field), Err: fmt.Errorf("%w: expected %s", ErrInvalidBody, typeErr.Type)}
+		}
+		return DecodeError{Source: BodySource, Err: fmt.Errorf("%w: %s", ErrInvalidBody, err)}
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return DecodeError{Source: BodySource, Err: fmt.Errorf("%w: unexpected data after the JSON value", ErrInvalidBody)}
+	}
+	return nil
+}
+
+// isJSON reports whether the media type is application/json or a +json type.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// fieldPointer converts the dotted field path of a json.UnmarshalTypeError into a JSON Pointer.
+func fieldPointer(field string) string {
+	tokens := strings.Split(field, ".")
+	for i, token := range tokens {
+		tokens[i] = errs.EscapePointerToken(token)
+	}
+	return "/" + strings.Join(tokens, "/")
+}
+
+// setField parses values into v. Slices take every value, other types take the first one.
+func setField(v reflect.Value, values []string) error {
+	if v.Kind() == reflect.Slice && !v.Addr().Type().Implements(textUnmarshalerType) {
+		slice := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setValue(v, values[0])
+}
+
+func setValue(v reflect.Value, value string) error {
+	if v.Kind() == reflect.Pointer {
+		elem := reflect.New(v.Type().Elem())
+		if err := setValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidValue, err)
+		}
+		return nil
+	}
+
+	var err error
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(value)
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, v.Type().Bits())
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, v.Type().Bits())
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		n, err = strconv.ParseFloat(value, v.Type().Bits())
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("%w: unsupported field type %s", ErrInvalidValue, v.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %q is not a valid %s", ErrInvalidValue, value, v.Type())
+	}
+	return nil
+}
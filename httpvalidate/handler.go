@@ -0,0 +1,99 @@
+// Package httpvalidate decodes HTTP requests into typed values and validates them,
+// responding with an RFC 9457 problem+json document when the request is invalid.
+package httpvalidate
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+// DefaultMaxBodySize is the default maximum size of a request body, in bytes.
+const DefaultMaxBodySize int64 = 1 << 20
+
+type config struct {
+	maxBodySize           int64
+	disallowUnknownFields bool
+}
+
+// Option configures how requests are decoded.
+type Option func(*config)
+
+// WithMaxBodySize sets the maximum size of a request body, in bytes.
+func WithMaxBodySize(size int64) Option {
+	return func(c *config) { c.maxBodySize = size }
+}
+
+// WithDisallowUnknownFields rejects request bodies with fields which are not in the value.
+func WithDisallowUnknownFields() Option {
+	return func(c *config) { c.disallowUnknownFields = true }
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// Handler returns a handler which decodes the request into a T, validates it with test and calls next with it.
+// If the request cannot be decoded or is invalid, next is not called and a problem+json response is written.
+// Warnings and infos do not reject the request, and are retrieved from the request context with ResultFromContext.
+func Handler[T any](test ttypes.ValTest[T], next func(http.ResponseWriter, *http.Request, T), opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, val, ok := decodeAndValidate(w, r, test, opts)
+		if ok {
+			next(w, r, val)
+		}
+	})
+}
+
+type contextKey[T any] struct{}
+
+type resultKey struct{}
+
+// Middleware returns a middleware which decodes the request into a T and validates it with test.
+// The value is stored in the request context and retrieved with FromContext.
+// If the request cannot be decoded or is invalid, a problem+json response is written instead.
+func Middleware[T any](test ttypes.ValTest[T], opts ...Option) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return Handler(test, func(w http.ResponseWriter, r *http.Request, val T) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, val)))
+		}, opts...)
+	}
+}
+
+// FromContext returns the value stored by Middleware.
+func FromContext[T any](ctx context.Context) (T, bool) {
+	val, ok := ctx.Value(contextKey[T]{}).(T)
+	return val, ok
+}
+
+// ResultFromContext returns the warnings and infos found when validating the request.
+func ResultFromContext(ctx context.Context) errs.Result {
+	result, _ := ctx.Value(resultKey{}).(errs.Result)
+	return result
+}
+
+func decodeAndValidate[T any](w http.ResponseWriter, r *http.Request, test ttypes.ValTest[T], opts []Option) (*http.Request, T, bool) {
+	val, err := Decode[T](r, opts...)
+	if err != nil {
+		WriteProblem(w, err)
+		return r, val, false
+	}
+	if test == nil {
+		return r, val, true
+	}
+	err = test(val)
+	if errs.OnlyErrors(err) != nil {
+		WriteProblem(w, err)
+		return r, val, false
+	}
+	if err != nil {
+		r = r.WithContext(context.WithValue(r.Context(), resultKey{}, errs.NewResult(err)))
+	}
+	return r, val, true
+}
@@ -0,0 +1,392 @@
+package httpvalidate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/problem"
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createUser struct {
+	Name    string   `json:"name"`
+	Age     int      `json:"age"`
+	Tags    []string `json:"tags"`
+	DryRun  bool     `query:"dry_run"`
+	Limit   *uint8   `query:"limit"`
+	Ids     []int64  `query:"id"`
+	Score   float64  `query:"score"`
+	TraceID string   `header:"X-Trace-Id"`
+	private string   `query:"private"`
+}
+
+var validateUser = options.VAll(
+	options.VField("name", func(u createUser) string { return u.Name }, options.VIsStringLength(1, 5)),
+	options.VField("age", func(u createUser) int { return u.Age }, options.VIsInRange(18, 150)),
+	options.VField("tags", func(u createUser) []string { return u.Tags }, options.VIsLength[string](0, 2)),
+)
+
+func newRequest(method, target, contentType, body string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func decodeProblem(t *testing.T, res *http.Response) *problem.Details {
+	t.Helper()
+	assert.Equal(t, problem.ContentType, res.Header.Get("Content-Type"))
+	var details problem.Details
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&details))
+	return &details
+}
+
+func TestHandler(t *testing.T) {
+	tests := map[string]struct {
+		request         *http.Request
+		opts            []Option
+		expectedStatus  int
+		expectedValue   createUser
+		expectedProblem *problem.Details
+	}{
+		"valid request": {
+			request: func() *http.Request {
+				r := newRequest(http.MethodPost, "/users?dry_run=true&limit=10&id=1&id=2&score=0.5&private=a", "application/json; charset=utf-8", `{"name":"jh","age":20}`)
+				r.Header.Set("X-Trace-Id", "abc")
+				return r
+			}(),
+			expectedStatus: http.StatusOK,
+			expectedValue: createUser{
+				Name: "jh", Age: 20, DryRun: true, Limit: ptr[uint8](10), Ids: []int64{1, 2}, Score: 0.5, TraceID: "abc",
+			},
+		},
+		"invalid fields are all listed": {
+			request:        newRequest(http.MethodPost, "/users", "application/json", `{"name":"","age":1,"tags":["a","b","c"]}`),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Unprocessable Entity",
+				Status: http.StatusUnprocessableEntity,
+				Detail: "the request is invalid",
+				Errors: []problem.Error{
+					{Pointer: "/name", Code: errs.CodeInvalidLength, Rule: "IsLength", Message: "invalid length", Params: map[string]any{"min": 1.0, "max": 5.0}},
+					{Pointer: "/age", Code: errs.CodeOutOfRange, Rule: "IsInRange", Message: "value is out of range", Params: map[string]any{"min": 18.0, "max": 150.0}},
+					{Pointer: "/tags", Code: errs.CodeInvalidLength, Rule: "IsLength", Message: "invalid length", Params: map[string]any{"min": 0.0, "max": 2.0}},
+				},
+			},
+		},
+		"empty body is validated": {
+			request:        newRequest(http.MethodGet, "/users", "", ""),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Unprocessable Entity",
+				Status: http.StatusUnprocessableEntity,
+				Detail: "the request is invalid",
+				Errors: []problem.Error{
+					{Pointer: "/name", Code: errs.CodeInvalidLength, Rule: "IsLength", Message: "invalid length", Params: map[string]any{"min": 1.0, "max": 5.0}},
+					{Pointer: "/age", Code: errs.CodeOutOfRange, Rule: "IsInRange", Message: "value is out of range", Params: map[string]any{"min": 18.0, "max": 150.0}},
+				},
+			},
+		},
+		"invalid query and header": {
+			request: func() *http.Request {
+				r := newRequest(http.MethodPost, "/users?dry_run=maybe&limit=300&id=1&id=a&score=x", "", "")
+				r.Header.Set("X-Trace-Id", "abc")
+				return r
+			}(),
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{
+					{Parameter: "dry_run", Message: `invalid value: "maybe" is not a valid bool`},
+					{Parameter: "limit", Message: `invalid value: "300" is not a valid uint8`},
+					{Parameter: "id", Message: `invalid value: "a" is not a valid int64`},
+					{Parameter: "score", Message: `invalid value: "x" is not a valid float64`},
+				},
+			},
+		},
+		"invalid json": {
+			request:        newRequest(http.MethodPost, "/users", "application/json", `{"name":`),
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{{Message: "request body is invalid: unexpected EOF"}},
+			},
+		},
+		"wrong json type": {
+			request:        newRequest(http.MethodPost, "/users", "application/json", `{"name":1}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{{Pointer: "/name", Message: "request body is invalid: expected string"}},
+			},
+		},
+		"trailing data": {
+			request:        newRequest(http.MethodPost, "/users", "application/json", `{} {}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{{Message: "request body is invalid: unexpected data after the JSON value"}},
+			},
+		},
+		"trailing closing brace": {
+			request:        newRequest(http.MethodPost, "/users", "application/json", `{"name":"jh"}}`),
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{{Message: "request body is invalid: unexpected data after the JSON value"}},
+			},
+		},
+		"trailing closing bracket": {
+			request:        newRequest(http.MethodPost, "/users", "application/json", `{"name":"jh"}]`),
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{{Message: "request body is invalid: unexpected data after the JSON value"}},
+			},
+		},
+		"unknown field": {
+			request:        newRequest(http.MethodPost, "/users", "application/json", `{"nickname":"jh"}`),
+			opts:           []Option{WithDisallowUnknownFields()},
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{{Message: `request body is invalid: json: unknown field "nickname"`}},
+			},
+		},
+		"not json": {
+			request:        newRequest(http.MethodPost, "/users", "text/plain", `name=jh`),
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Unsupported Media Type",
+				Status: http.StatusUnsupportedMediaType,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{{Message: ErrUnsupportedMediaType.Error()}},
+			},
+		},
+		"body too large": {
+			request:        newRequest(http.MethodPost, "/users", "application/json", `{"name":"jh"}`),
+			opts:           []Option{WithMaxBodySize(5)},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedProblem: &problem.Details{
+				Type:   "about:blank",
+				Title:  "Request Entity Too Large",
+				Status: http.StatusRequestEntityTooLarge,
+				Detail: "the request cannot be decoded",
+				Errors: []problem.Error{{Message: ErrBodyTooLarge.Error()}},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got createUser
+			handler := Handler(validateUser, func(w http.ResponseWriter, r *http.Request, val createUser) {
+				got = val
+				w.WriteHeader(http.StatusOK)
+			}, tc.opts...)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tc.request)
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tc.expectedStatus, res.StatusCode)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedValue, got)
+				return
+			}
+			assert.Equal(t, tc.expectedProblem, decodeProblem(t, res))
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	type query struct {
+		Page int `query:"page"`
+	}
+	test := options.VField("page", func(q query) int { return q.Page }, options.VIsInRange(1, 10))
+	handler := Middleware(test)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q, ok := FromContext[query](r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, 2, q.Page)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodGet, "/?page=2", "", ""))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodGet, "/?page=20", "", ""))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, []problem.Error{{Pointer: "/page", Code: errs.CodeOutOfRange, Rule: "IsInRange", Message: "value is out of range", Params: map[string]any{"min": 1.0, "max": 10.0}}}, decodeProblem(t, rec.Result()).Errors)
+
+	_, ok := FromContext[query](httptest.NewRequest(http.MethodGet, "/", nil).Context())
+	assert.False(t, ok)
+}
+
+func TestHandler_Warnings(t *testing.T) {
+	type query struct {
+		Page int `query:"page"`
+	}
+	test := options.VAnd(
+		options.VWarn(options.VField("page", func(q query) int { return q.Page }, options.VIsInRange(1, 10))),
+		options.VField("page", func(q query) int { return q.Page }, options.VIsInRange(1, 100)),
+	)
+	warning := errs.PathError{
+		Pointer: "/page",
+		Err:     errs.WithSeverity(errs.SeverityWarning, errs.OutOfRangeError.WithParams(map[string]any{"min": 1, "max": 10})),
+	}
+	handler := Handler(test, func(w http.ResponseWriter, r *http.Request, q query) {
+		if q.Page > 10 {
+			assert.Equal(t, errs.Result{Warnings: errs.Errors{warning}}, ResultFromContext(r.Context()))
+		} else {
+			assert.Equal(t, errs.Result{}, ResultFromContext(r.Context()))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodGet, "/?page=2", "", ""))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodGet, "/?page=20", "", ""))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodGet, "/?page=200", "", ""))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, []problem.Error{
+		{Pointer: "/page", Severity: "warning", Code: errs.CodeOutOfRange, Rule: "IsInRange", Message: "value is out of range", Params: map[string]any{"min": 1.0, "max": 10.0}},
+		{Pointer: "/page", Code: errs.CodeOutOfRange, Rule: "IsInRange", Message: "value is out of range", Params: map[string]any{"min": 1.0, "max": 100.0}},
+	}, decodeProblem(t, rec.Result()).Errors)
+}
+
+func TestHandler_NilTest(t *testing.T) {
+	handler := Handler[[]int](nil, func(w http.ResponseWriter, r *http.Request, val []int) {
+		assert.Equal(t, []int{1, 2}, val)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest(http.MethodPost, "/", "application/vnd.api+json", `[1, 2]`))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+type upperText string
+
+func (u *upperText) UnmarshalText(text []byte) error {
+	if strings.ToUpper(string(text)) != string(text) {
+		return io.ErrUnexpectedEOF
+	}
+	*u = upperText(text)
+	return nil
+}
+
+type textList []string
+
+func (l *textList) UnmarshalText(text []byte) error {
+	*l = strings.Split(string(text), ",")
+	return nil
+}
+
+func TestDecode_FieldTypes(t *testing.T) {
+	type params struct {
+		Code    upperText      `header:"X-Code"`
+		List    textList       `query:"list"`
+		Small   int8           `query:"small"`
+		Ratio   float32        `query:"ratio"`
+		Codes   []*upperText   `query:"code"`
+		Unknown map[string]int `query:"unknown"`
+	}
+
+	r := newRequest(http.MethodGet, "/?list=a,b&small=-3&ratio=1.5&code=A&code=B", "", "")
+	r.Header.Set("X-Code", "ABC")
+	val, err := Decode[params](r)
+	require.Nil(t, err)
+	assert.Equal(t, params{
+		Code:  "ABC",
+		List:  textList{"a", "b"},
+		Small: -3,
+		Ratio: 1.5,
+		Codes: []*upperText{ptr[upperText]("A"), ptr[upperText]("B")},
+	}, val)
+
+	r = newRequest(http.MethodGet, "/?code=a&unknown=1", "", "")
+	r.Header.Set("X-Code", "abc")
+	_, err = Decode[params](r)
+	assert.Equal(t, errs.Errors{
+		DecodeError{Source: HeaderSource, Name: "X-Code", Err: err.(errs.Errors)[0].(DecodeError).Err},
+		DecodeError{Source: QuerySource, Name: "code", Err: err.(errs.Errors)[1].(DecodeError).Err},
+		DecodeError{Source: QuerySource, Name: "unknown", Err: err.(errs.Errors)[2].(DecodeError).Err},
+	}, err)
+	assert.EqualError(t, err, `header "X-Code": invalid value: unexpected EOF; `+
+		`query "code": invalid value: unexpected EOF; `+
+		`query "unknown": invalid value: unsupported field type map[string]int`)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
+
+func TestDecode_BodyErrors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", failingReader{})
+	_, err := Decode[createUser](r)
+	assert.ErrorIs(t, err, ErrInvalidBody)
+	assert.EqualError(t, err, "body: request body is invalid: io: read/write on closed pipe")
+
+	type nested struct {
+		A struct {
+			B int `json:"b"`
+		} `json:"a"`
+	}
+	r = newRequest(http.MethodPost, "/", "application/json", `{"a":{"b":"x"}}`)
+	_, err = Decode[nested](r)
+	assert.Equal(t, "/a/b", err.(DecodeError).Name)
+
+	r = httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Body = nil
+	_, err = Decode[createUser](r)
+	assert.Nil(t, err)
+}
+
+func ptr[T any](val T) *T {
+	return &val
+}
+
+var _ ttypes.ValTest[createUser] = validateUser
@@ -0,0 +1,44 @@
+package httpvalidate
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Jh123x/go-validate/problem"
+)
+
+// NewProblem returns the problem details describing err, which was returned by Decode or by a validator.
+// Decoding errors are bad requests and validation errors are unprocessable content.
+func NewProblem(err error) *problem.Details {
+	switch {
+	case errors.Is(err, ErrUnsupportedMediaType):
+		return problem.New(http.StatusUnsupportedMediaType, err, problem.WithDetail(decodeDetail))
+	case errors.Is(err, ErrBodyTooLarge):
+		return problem.New(http.StatusRequestEntityTooLarge, err, problem.WithDetail(decodeDetail))
+	case errors.As(err, new(DecodeError)):
+		return problem.New(http.StatusBadRequest, err, problem.WithDetail(decodeDetail))
+	}
+	return problem.New(http.StatusUnprocessableEntity, err, problem.WithDetail("the request is invalid"))
+}
+
+const decodeDetail = "the request cannot be decoded"
+
+// WriteProblem writes the problem details describing err as a problem+json response.
+func WriteProblem(w http.ResponseWriter, err error) {
+	problem.Write(w, NewProblem(err))
+}
+
+// ProblemError describes the error with the query parameter or header name,
+// or the JSON Pointer of the value in the body.
+func (d DecodeError) ProblemError() problem.Error {
+	out := problem.Error{Message: d.Err.Error()}
+	switch d.Source {
+	case QuerySource:
+		out.Parameter = d.Name
+	case HeaderSource:
+		out.Header = d.Name
+	default:
+		out.Pointer = d.Name
+	}
+	return out
+}
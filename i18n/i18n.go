@@ -0,0 +1,187 @@
+// Package i18n renders validation errors in the language of the user.
+//
+// Messages are templates such as "must be between {min} and {max}", whose placeholders are
+// replaced by the params of the errs.ValidateError. Templates are grouped by locale in catalogs,
+// keyed by the code of the error or, for errors without a code, by the name of its rule.
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Jh123x/go-validate/errs"
+)
+
+// ErrInvalidCatalog is returned when a catalog file cannot be loaded.
+var ErrInvalidCatalog = errors.New("invalid message catalog")
+
+// Catalog maps error codes or rule names to message templates.
+type Catalog map[string]string
+
+// Translator renders errors with the catalogs of its locales.
+// A translator is safe for concurrent use.
+type Translator struct {
+	mu       sync.RWMutex
+	catalogs map[string]Catalog
+	fallback string
+}
+
+// NewTranslator returns a translator without catalogs.
+// Messages missing from the requested locale are looked up in the fallback locale.
+func NewTranslator(fallback string) *Translator {
+	return &Translator{catalogs: make(map[string]Catalog), fallback: normalize(fallback)}
+}
+
+// AddCatalog adds the templates of the catalog to the locale, replacing the templates with the same keys.
+func (t *Translator) AddCatalog(locale string, catalog Catalog) *Translator {
+	locale = normalize(locale)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	existing, ok := t.catalogs[locale]
+	if !ok {
+		existing = make(Catalog, len(catalog))
+		t.catalogs[locale] = existing
+	}
+	for key, template := range catalog {
+		existing[key] = template
+	}
+	return t
+}
+
+// LoadFS adds the catalogs in the files of fsys matching pattern, such as "locales/*.json".
+// Each file is a JSON object of templates, and its name without the extension is its locale.
+// fsys can be an embed.FS or os.DirFS.
+func (t *Translator) LoadFS(fsys fs.FS, pattern string) error {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCatalog, err)
+	}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidCatalog, err)
+		}
+		var catalog Catalog
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidCatalog, file, err)
+		}
+		name := path.Base(file)
+		t.AddCatalog(strings.TrimSuffix(name, path.Ext(name)), catalog)
+	}
+	return nil
+}
+
+// Locales returns the locales with a catalog, sorted.
+func (t *Translator) Locales() []string {
+	t.mu.RLock()
+	locales := make([]string, 0, len(t.catalogs))
+	for locale := range t.catalogs {
+		locales = append(locales, locale)
+	}
+	t.mu.RUnlock()
+	sort.Strings(locales)
+	return locales
+}
+
+// Message renders the message of err in the locale.
+//
+// The template is looked up in the locale ("pt-BR"), then its language ("pt"),
+// then the fallback locale. If no catalog has a template for the error,
+// the message of the errs.ValidateError is used, or the error message for other errors.
+func (t *Translator) Message(locale string, err error) string {
+	var validateErr errs.ValidateError
+	if !errors.As(err, &validateErr) {
+		return err.Error()
+	}
+	template, ok := t.lookup(locale, validateErr)
+	if !ok {
+		return validateErr.Message()
+	}
+	return Render(template, errs.ParamsOf(err))
+}
+
+// Translate renders err in the locale like Message, keeping the pointer of errs.PathError and
+// joining the messages of errs.Errors.
+func (t *Translator) Translate(locale string, err error) string {
+	if err == nil {
+		return ""
+	}
+	list := errs.Flatten(err)
+	msgs := make([]string, 0, len(list))
+	for _, item := range list {
+		var pathErr errs.PathError
+		if errors.As(item, &pathErr) {
+			msgs = append(msgs, fmt.Sprintf(errs.PathErrorFormat, pathErr.Pointer, t.Message(locale, pathErr.Err)))
+			continue
+		}
+		msgs = append(msgs, t.Message(locale, item))
+	}
+	return strings.Join(msgs, errs.ErrorsSeparator)
+}
+
+func (t *Translator) lookup(locale string, err errs.ValidateError) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, candidate := range t.candidates(normalize(locale)) {
+		catalog, ok := t.catalogs[candidate]
+		if !ok {
+			continue
+		}
+		if template, ok := catalog[err.Code()]; ok && err.Code() != "" {
+			return template, true
+		}
+		if template, ok := catalog[err.Rule()]; ok {
+			return template, true
+		}
+	}
+	return "", false
+}
+
+// candidates returns the locales to look templates up in, from the most to the least specific.
+func (t *Translator) candidates(locale string) []string {
+	candidates := []string{locale}
+	if lang, _, ok := strings.Cut(locale, "-"); ok {
+		candidates = append(candidates, lang)
+	}
+	return append(candidates, t.fallback)
+}
+
+// normalize returns the locale in lower case with "-" separators, so that "pt_BR" and "pt-br" match.
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// Render replaces the placeholders of the template, such as "{min}", with the params.
+// Placeholders without a param are left as they are.
+func Render(template string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+		b.WriteString(template[:start])
+		if val, ok := params[template[start+1:end]]; ok {
+			fmt.Fprint(&b, val)
+		} else {
+			b.WriteString(template[start : end+1])
+		}
+		template = template[end+1:]
+	}
+	b.WriteString(template)
+	return b.String()
+}
@@ -0,0 +1,264 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	lengthErr = errs.InvalidLengthError.WithParams(map[string]any{"min": 1, "max": 5})
+	rangeErr  = errs.OutOfRangeError.WithParams(map[string]any{"min": 18.0, "max": 150.0})
+	customErr = errs.NewValidateError("Custom", "custom failed")
+	otherErr  = errors.New("other error")
+)
+
+func newTestTranslator(t *testing.T) *Translator {
+	translator := NewTranslator("en")
+	require.Nil(t, translator.LoadFS(os.DirFS("testdata"), "locales/*.json"))
+	return translator
+}
+
+func TestRender(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		params   map[string]any
+		expected string
+	}{
+		"no params": {
+			template: "must be between {min} and {max}",
+			expected: "must be between {min} and {max}",
+		},
+		"no placeholders": {
+			template: "is required",
+			params:   map[string]any{"min": 1},
+			expected: "is required",
+		},
+		"all params": {
+			template: "must be between {min} and {max}",
+			params:   map[string]any{"min": 1, "max": 2.5},
+			expected: "must be between 1 and 2.5",
+		},
+		"missing param": {
+			template: "must be between {min} and {max}",
+			params:   map[string]any{"min": 1},
+			expected: "must be between 1 and {max}",
+		},
+		"repeated param": {
+			template: "{min}..{min}",
+			params:   map[string]any{"min": 1},
+			expected: "1..1",
+		},
+		"unclosed placeholder": {
+			template: "at least {min} {max",
+			params:   map[string]any{"min": 1, "max": 2},
+			expected: "at least 1 {max",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Render(tc.template, tc.params))
+		})
+	}
+}
+
+func TestTranslator_Message(t *testing.T) {
+	tests := map[string]struct {
+		locale   string
+		err      error
+		expected string
+	}{
+		"exact locale": {
+			locale:   "fr",
+			err:      lengthErr,
+			expected: "doit contenir entre 1 et 5 caractères",
+		},
+		"region locale": {
+			locale:   "pt-BR",
+			err:      rangeErr,
+			expected: "deve estar entre 18 e 150",
+		},
+		"region locale with underscore and case": {
+			locale:   "PT_br",
+			err:      rangeErr,
+			expected: "deve estar entre 18 e 150",
+		},
+		"region falls back to language": {
+			locale:   "pt-BR",
+			err:      lengthErr,
+			expected: "deve ter entre 1 e 5 caracteres",
+		},
+		"unknown region falls back to language": {
+			locale:   "pt-PT",
+			err:      rangeErr,
+			expected: "tem de estar entre 18 e 150",
+		},
+		"falls back to default locale": {
+			locale:   "fr",
+			err:      errs.IsNotEmptyErr,
+			expected: "is required",
+		},
+		"unknown locale": {
+			locale:   "de",
+			err:      rangeErr,
+			expected: "must be between 18 and 150",
+		},
+		"rule name without code": {
+			locale:   "fr",
+			err:      customErr,
+			expected: "is not custom",
+		},
+		"no template": {
+			locale:   "fr",
+			err:      errs.InvalidEmailError,
+			expected: "invalid email",
+		},
+		"wrapped validate error": {
+			locale:   "fr",
+			err:      errs.WithPath("name", lengthErr),
+			expected: "doit contenir entre 1 et 5 caractères",
+		},
+		"other error": {
+			locale:   "fr",
+			err:      otherErr,
+			expected: "other error",
+		},
+	}
+
+	translator := newTestTranslator(t)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, translator.Message(tc.locale, tc.err))
+		})
+	}
+}
+
+func TestTranslator_Translate(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		expected string
+	}{
+		"nil": {},
+		"single error": {
+			err:      lengthErr,
+			expected: "doit contenir entre 1 et 5 caractères",
+		},
+		"path error": {
+			err:      errs.WithPath("name", lengthErr),
+			expected: "/name: doit contenir entre 1 et 5 caractères",
+		},
+		"errors": {
+			err: errs.Errors{
+				errs.WithPath("name", lengthErr),
+				errs.WithPath("age", rangeErr),
+				otherErr,
+			},
+			expected: "/name: doit contenir entre 1 et 5 caractères; /age: doit être compris entre 18 et 150; other error",
+		},
+		"range option": {
+			err:      options.VIsInRange(18, 150)(3),
+			expected: "doit être compris entre 18 et 150",
+		},
+		"length option": {
+			err:      options.IsStringLength("", 1, 5)(),
+			expected: "doit contenir entre 1 et 5 caractères",
+		},
+	}
+
+	translator := newTestTranslator(t)
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, translator.Translate("fr", tc.err))
+		})
+	}
+}
+
+func TestTranslator_LoadFS(t *testing.T) {
+	tests := map[string]struct {
+		fsys            fstest.MapFS
+		pattern         string
+		expectedLocales []string
+		expectedErr     error
+	}{
+		"success": {
+			fsys: fstest.MapFS{
+				"en.json":    {Data: []byte(`{"is_empty": "must be empty"}`)},
+				"es-MX.json": {Data: []byte(`{"is_empty": "debe estar vacío"}`)},
+				"notes.txt":  {Data: []byte(`not a catalog`)},
+			},
+			pattern:         "*.json",
+			expectedLocales: []string{"en", "es-mx"},
+		},
+		"no files": {
+			fsys:            fstest.MapFS{},
+			pattern:         "*.json",
+			expectedLocales: []string{},
+		},
+		"invalid pattern": {
+			fsys:            fstest.MapFS{},
+			pattern:         "[",
+			expectedLocales: []string{},
+			expectedErr:     ErrInvalidCatalog,
+		},
+		"invalid json": {
+			fsys: fstest.MapFS{
+				"en.json": {Data: []byte(`{"is_empty": 1}`)},
+			},
+			pattern:         "*.json",
+			expectedLocales: []string{},
+			expectedErr:     ErrInvalidCatalog,
+		},
+		"unreadable file": {
+			fsys: fstest.MapFS{
+				"en.json": {Mode: os.ModeDir},
+			},
+			pattern:         "*.json",
+			expectedLocales: []string{},
+			expectedErr:     ErrInvalidCatalog,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			translator := NewTranslator("en")
+			assert.ErrorIs(t, translator.LoadFS(tc.fsys, tc.pattern), tc.expectedErr)
+			assert.Equal(t, tc.expectedLocales, translator.Locales())
+		})
+	}
+}
+
+func TestTranslator_AddCatalog(t *testing.T) {
+	translator := NewTranslator("EN").
+		AddCatalog("en", Catalog{"is_empty": "must be empty", "is_not_empty": "is required"}).
+		AddCatalog("en", Catalog{"is_empty": "has to be empty"})
+
+	assert.Equal(t, []string{"en"}, translator.Locales())
+	assert.Equal(t, "has to be empty", translator.Message("de", errs.IsEmptyError))
+	assert.Equal(t, "is required", translator.Message("de", errs.IsNotEmptyErr))
+}
+
+func TestTranslator_Concurrent(t *testing.T) {
+	translator := newTestTranslator(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			translator.AddCatalog("de", Catalog{"out_of_range": "muss zwischen {min} und {max} liegen"})
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NotEmpty(t, translator.Message("de", rangeErr))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, "muss zwischen 18 und 150 liegen", translator.Message("de", rangeErr))
+}
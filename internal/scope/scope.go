@@ -0,0 +1,357 @@
+// Package scope passes the observers and the memoization of a validation down to the rules it evaluates,
+// so that options composed as plain functions can be observed without changing their signatures.
+//
+// Options composing other options, such as combinators, are nodes made into options with Option
+// and ValueOption. They evaluate their operands in the scope they are given with Eval and EvalValue,
+// which only call the operands when the scope is nil, as it is when the validation is not observed.
+package scope
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// Rule describes a rule evaluated in a scope.
+// An empty name marks a rule whose name is unknown, such as a custom option.
+// Rules with a Key are evaluated at most once for each input in a scope with memoization, see WithMemo.
+type Rule struct {
+	Name        string
+	Description string
+	Key         any
+}
+
+// Observer is notified of the rules evaluated in a scope.
+// Enter receives the token returned for the enclosing rule, or the root token of the observer,
+// and returns the token of the rule, which is passed to Exit once the rule returns.
+// Observers are called from every goroutine of the validation, and must be safe for concurrent use.
+type Observer interface {
+	Enter(parent any, rule Rule, input any) any
+	Exit(token any, err error, duration time.Duration)
+}
+
+// Scope is a rule being evaluated in an observed validation, or in a validation with memoization.
+// A nil Scope is a validation which is neither, whose rules are called directly.
+type Scope struct {
+	observers []Observer
+	tokens    []any
+	memo      *memo
+}
+
+// New returns a scope notifying the observers, with their root tokens in the same order.
+func New(observers []Observer, roots []any) *Scope {
+	return &Scope{observers: observers, tokens: roots}
+}
+
+// WithMemo returns the scope with memoization, in which each rule with a Key is evaluated at most once
+// for each input, including on the goroutines it is forked to, and its error is returned again for later
+// evaluations. Rules whose input is not comparable are always evaluated.
+// A scope which already has memoization, such as the scope of a validator calling another one, is returned as is.
+func WithMemo(s *Scope) *Scope {
+	if s == nil {
+		return &Scope{memo: &memo{entries: make(map[memoKey]*memoEntry)}}
+	}
+	if s.memo != nil {
+		return s
+	}
+	memoized := *s
+	memoized.memo = &memo{entries: make(map[memoKey]*memoEntry)}
+	return &memoized
+}
+
+// Join returns the scope notifying the observers of both scopes, such as the observers of a trace and the hooks
+// of a validator explained by it, with the memoization of either. Either scope may be nil.
+func Join(s, other *Scope) *Scope {
+	if s == nil {
+		return other
+	}
+	if other == nil {
+		return s
+	}
+	joined := &Scope{
+		observers: make([]Observer, 0, len(s.observers)+len(other.observers)),
+		tokens:    make([]any, 0, len(s.tokens)+len(other.tokens)),
+		memo:      s.memo,
+	}
+	if joined.memo == nil {
+		joined.memo = other.memo
+	}
+	joined.observers = append(append(joined.observers, s.observers...), other.observers...)
+	joined.tokens = append(append(joined.tokens, s.tokens...), other.tokens...)
+	return joined
+}
+
+// Node is an option evaluating other options, such as a combinator.
+// Evaluate is given the scope of the node, which is nil if the validation is not observed.
+// A node without a name is not reported, and the options it evaluates are reported in its place.
+type Node interface {
+	Rule() Rule
+	Evaluate(s *Scope) error
+}
+
+// ValueNode is a Node validating values of type T.
+type ValueNode[T any] interface {
+	Rule() Rule
+	Evaluate(s *Scope, val T) error
+}
+
+// Option returns the node as an option, which evaluates it in no scope when called.
+func Option(n Node) func() error {
+	return (&option{node: n}).evaluate
+}
+
+// ValueOption returns the node as an option, which evaluates it in no scope when called.
+func ValueOption[T any](n ValueNode[T]) func(T) error {
+	return bindValue(&valueOption[T]{node: n})
+}
+
+type option struct{ node Node }
+
+func (o *option) evaluate() error { return o.node.Evaluate(nil) }
+
+type valueOption[T any] struct{ node ValueNode[T] }
+
+func (o *valueOption[T]) evaluate(val T) error { return o.node.Evaluate(nil, val) }
+
+// bindValue returns the method value of the option, whose code is shared by the options of the same shape.
+// It is not inlined, as the method values made by its inlined copies may have their own code.
+//
+//go:noinline
+func bindValue[T any](o *valueOption[T]) func(T) error { return o.evaluate }
+
+// methodValue is the layout of the method values made by Option and bindValue, which start with their code
+// and their receiver.
+type methodValue struct {
+	code uintptr
+	recv unsafe.Pointer
+}
+
+// methodValueOf returns the method value of the function, which must not be nil.
+func methodValueOf(fn unsafe.Pointer) *methodValue {
+	return *(**methodValue)(fn)
+}
+
+var optionCode = methodValueOf(unsafe.Pointer(&[]func() error{(&option{}).evaluate}[0])).code
+
+// nodeOf returns the node of an option made by Option, or nil.
+func nodeOf(rule func() error) Node {
+	if rule == nil {
+		return nil
+	}
+	m := methodValueOf(unsafe.Pointer(&rule))
+	if m.code != optionCode {
+		return nil
+	}
+	return (*option)(m.recv).node
+}
+
+// valueNodeOf returns the node of an option made by ValueOption, or nil.
+// The options of types of the same shape share their code, which cannot be mistaken for one another
+// as their types must be the same for rule to be one of them.
+func valueNodeOf[T any](rule func(T) error) ValueNode[T] {
+	if rule == nil {
+		return nil
+	}
+	sample := bindValue[T](nil)
+	m := methodValueOf(unsafe.Pointer(&rule))
+	if m.code != methodValueOf(unsafe.Pointer(&sample)).code {
+		return nil
+	}
+	return (*valueOption[T])(m.recv).node
+}
+
+// Eval evaluates the option in the scope, notifying the observers of its rule.
+func (s *Scope) Eval(rule func() error) error {
+	if s == nil {
+		return rule()
+	}
+	return s.start(describe(rule))()
+}
+
+// EvalValue evaluates the option on val in the scope, notifying the observers of its rule.
+func EvalValue[T any](s *Scope, val T, rule func(T) error) error {
+	if s == nil {
+		return rule(val)
+	}
+	return s.start(describeValue(val, rule))()
+}
+
+// EvalRule evaluates the option as the rule, such as a named option, notifying the observers of the rule
+// and then of the option. A rule without a name, such as a memoized option, is not reported.
+func (s *Scope) EvalRule(info Rule, rule func() error) error {
+	if s == nil {
+		return rule()
+	}
+	return s.start(call{rule: info, report: info.Name != "", evaluate: func(s *Scope) error { return s.Eval(rule) }})()
+}
+
+// EvalRuleValue evaluates the option on val as the rule, such as the field of a struct, notifying the
+// observers of the rule and then of the option.
+func EvalRuleValue[T any](s *Scope, info Rule, val T, rule func(T) error) error {
+	if s == nil {
+		return rule(val)
+	}
+	return s.start(call{
+		rule:     info,
+		input:    val,
+		report:   info.Name != "",
+		evaluate: func(s *Scope) error { return EvalValue(s, val, rule) },
+	})()
+}
+
+// Fork returns the option to be evaluated on another goroutine.
+// The observers are notified of the option when Fork is called, so that they see the options
+// in the order they were forked rather than the order the goroutines run in.
+func (s *Scope) Fork(rule func() error) func() error {
+	if s == nil {
+		return rule
+	}
+	return s.start(describe(rule))
+}
+
+// ForkValue returns the option evaluating val to be evaluated on another goroutine, as Fork does.
+func ForkValue[T any](s *Scope, val T, rule func(T) error) func() error {
+	if s == nil {
+		return func() error { return rule(val) }
+	}
+	return s.start(describeValue(val, rule))
+}
+
+// call is an option to evaluate in a scope.
+type call struct {
+	rule     Rule
+	input    any
+	report   bool
+	evaluate func(s *Scope) error
+}
+
+func describe(rule func() error) call {
+	if n := nodeOf(rule); n != nil {
+		info := n.Rule()
+		return call{rule: info, report: info.Name != "", evaluate: n.Evaluate}
+	}
+	return call{rule: Rule{Name: nameOf(rule)}, report: true, evaluate: func(*Scope) error { return rule() }}
+}
+
+func describeValue[T any](val T, rule func(T) error) call {
+	if n := valueNodeOf(rule); n != nil {
+		info := n.Rule()
+		return call{rule: info, input: val, report: info.Name != "", evaluate: func(s *Scope) error { return n.Evaluate(s, val) }}
+	}
+	return call{rule: Rule{Name: nameOf(rule)}, input: val, report: true, evaluate: func(*Scope) error { return rule(val) }}
+}
+
+// start notifies the observers that the rule is entered, returning the function evaluating it,
+// which notifies them that the rule exited once it returns.
+func (s *Scope) start(c call) func() error {
+	if !c.report || len(s.observers) == 0 {
+		return func() error { return s.evaluate(c, s) }
+	}
+	child := &Scope{observers: s.observers, tokens: make([]any, len(s.observers)), memo: s.memo}
+	for i, observer := range s.observers {
+		child.tokens[i] = observer.Enter(s.tokens[i], c.rule, c.input)
+	}
+	return func() error {
+		start := time.Now()
+		var err error
+		defer func() {
+			duration := time.Since(start)
+			for i, observer := range child.observers {
+				observer.Exit(child.tokens[i], err, duration)
+			}
+		}()
+		err = s.evaluate(c, child)
+		return err
+	}
+}
+
+// evaluate evaluates the rule in the child scope, once for each input if the rule has a Key and the scope has memoization.
+func (s *Scope) evaluate(c call, child *Scope) error {
+	if s.memo == nil || c.rule.Key == nil || (c.input != nil && !reflect.ValueOf(c.input).Comparable()) {
+		return c.evaluate(child)
+	}
+	return s.memo.do(memoKey{key: c.rule.Key, input: c.input}, func() error { return c.evaluate(child) })
+}
+
+// memo holds the errors of the rules evaluated in a scope with memoization.
+type memo struct {
+	mu      sync.Mutex
+	entries map[memoKey]*memoEntry
+}
+
+type memoKey struct {
+	key   any
+	input any
+}
+
+// memoEntry is the outcome of a rule, which is known once done is set.
+type memoEntry struct {
+	done bool
+	err  error
+}
+
+// do evaluates the rule once for the key, returning its error again for later evaluations.
+// A rule evaluated again while it is being evaluated, on this goroutine or another one, is evaluated again
+// rather than waited for, as the evaluation in flight may itself be waiting for it, such as a rule
+// validating its value with a parallel validator using the same rule.
+func (m *memo) do(key memoKey, rule func() error) error {
+	m.mu.Lock()
+	if entry, ok := m.entries[key]; ok {
+		done, err := entry.done, entry.err
+		m.mu.Unlock()
+		if !done {
+			return rule()
+		}
+		return err
+	}
+	entry := &memoEntry{}
+	m.entries[key] = entry
+	m.mu.Unlock()
+
+	err := rule()
+	m.mu.Lock()
+	entry.done, entry.err = true, err
+	m.mu.Unlock()
+	return err
+}
+
+// optionsPrefix is the prefix of the functions of the options package.
+var optionsPrefix = strings.TrimSuffix(reflect.TypeOf(Rule{}).PkgPath(), "internal/scope") + "options."
+
+// names caches the names of the functions by their address.
+var names sync.Map
+
+// nameOf returns the name of the option if it is a built-in option, such as "IsLength" for the options
+// returned by options.IsLength, or "" for the other functions, such as custom options.
+func nameOf(rule any) string {
+	pc := reflect.ValueOf(rule).Pointer()
+	if name, ok := names.Load(pc); ok {
+		return name.(string)
+	}
+	name := ""
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name = builtinName(fn.Name())
+	}
+	names.Store(pc, name)
+	return name
+}
+
+// builtinName returns the name of the exported function of the options package defining the function with the symbol,
+// such as "IsLength" for "github.com/Jh123x/go-validate/options.IsLength[...].func1".
+// Options made by WithRequire and VWithRequire are custom options, which have no name.
+func builtinName(symbol string) string {
+	name, ok := strings.CutPrefix(symbol, optionsPrefix)
+	if !ok {
+		return ""
+	}
+	if end := strings.IndexAny(name, "[."); end >= 0 {
+		name = name[:end]
+	}
+	if name == "" || name[0] < 'A' || name[0] > 'Z' || name == "WithRequire" || name == "VWithRequire" {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,348 @@
+package scope
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errTest = errors.New("test error")
+
+// recorder records the rules evaluated as "parent>name" and "name=err".
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) Enter(parent any, rule Rule, input any) any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := rule.Name
+	if rule.Description != "" {
+		name += "[" + rule.Description + "]"
+	}
+	if input != nil {
+		name = fmt.Sprintf("%s(%v)", name, input)
+	}
+	r.events = append(r.events, fmt.Sprintf("%s>%s", parent, name))
+	return name
+}
+
+func (r *recorder) Exit(token any, err error, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf("%s=%v", token, err))
+}
+
+// group is a node evaluating its options in order, named after name.
+type group struct {
+	name    string
+	options []func() error
+}
+
+func (g group) Rule() Rule { return Rule{Name: g.name} }
+
+func (g group) Evaluate(s *Scope) error {
+	var err error
+	for _, option := range g.options {
+		if optionErr := s.Eval(option); optionErr != nil {
+			err = optionErr
+		}
+	}
+	return err
+}
+
+// valueGroup is a node evaluating its options on the value in order, named after name.
+type valueGroup[T any] struct {
+	name    string
+	options []func(T) error
+}
+
+func (g valueGroup[T]) Rule() Rule { return Rule{Name: g.name} }
+
+func (g valueGroup[T]) Evaluate(s *Scope, val T) error {
+	var err error
+	for _, option := range g.options {
+		if optionErr := EvalValue(s, val, option); optionErr != nil {
+			err = optionErr
+		}
+	}
+	return err
+}
+
+func TestEval_WithoutScope(t *testing.T) {
+	var s *Scope
+	assert.Equal(t, errTest, s.Eval(func() error { return errTest }))
+	assert.Equal(t, errTest, EvalValue(s, 1, func(int) error { return errTest }))
+	assert.Equal(t, errTest, EvalRuleValue(s, Rule{Name: "a"}, 1, func(int) error { return errTest }))
+	assert.Equal(t, errTest, s.EvalRule(Rule{Name: "a"}, func() error { return errTest }))
+	assert.Equal(t, errTest, s.Fork(func() error { return errTest })())
+	assert.Equal(t, errTest, ForkValue(s, 1, func(int) error { return errTest })())
+	assert.Equal(t, errTest, Option(group{name: "a", options: []func() error{func() error { return errTest }}})())
+	assert.Equal(t, errTest, ValueOption[int](valueGroup[int]{name: "a", options: []func(int) error{func(int) error { return errTest }}})(1))
+}
+
+func TestEval(t *testing.T) {
+	leaf := func() error { return nil }
+	failing := func() error { return errTest }
+	valueLeaf := func(int) error { return nil }
+
+	tests := map[string]struct {
+		eval     func(s *Scope) error
+		expected []string
+	}{
+		"option": {
+			eval:     func(s *Scope) error { return s.Eval(failing) },
+			expected: []string{"root>", "=test error"},
+		},
+		"node": {
+			eval: func(s *Scope) error {
+				return s.Eval(Option(group{name: "a", options: []func() error{leaf, Option(group{name: "b", options: []func() error{failing}})}}))
+			},
+			expected: []string{"root>a", "a>", "=<nil>", "a>b", "b>", "=test error", "b=test error", "a=test error"},
+		},
+		"transparent node": {
+			eval:     func(s *Scope) error { return s.Eval(Option(group{options: []func() error{leaf, failing}})) },
+			expected: []string{"root>", "=<nil>", "root>", "=test error"},
+		},
+		"value node": {
+			eval: func(s *Scope) error {
+				return EvalValue(s, 1, ValueOption[int](valueGroup[int]{name: "a", options: []func(int) error{valueLeaf}}))
+			},
+			expected: []string{"root>a(1)", "a(1)>(1)", "(1)=<nil>", "a(1)=<nil>"},
+		},
+		"value node of another type": {
+			eval: func(s *Scope) error {
+				return EvalValue(s, int64(1), ValueOption[int64](valueGroup[int64]{name: "a"}))
+			},
+			expected: []string{"root>a(1)", "a(1)=<nil>"},
+		},
+		"rule": {
+			eval: func(s *Scope) error {
+				return s.EvalRule(Rule{Name: "a", Description: "d"}, Option(group{name: "b"}))
+			},
+			expected: []string{"root>a[d]", "a[d]>b", "b=<nil>", "a[d]=<nil>"},
+		},
+		"value rule": {
+			eval: func(s *Scope) error {
+				return EvalRuleValue(s, Rule{Name: "/a"}, 1, valueLeaf)
+			},
+			expected: []string{"root>/a(1)", "/a(1)>(1)", "(1)=<nil>", "/a(1)=<nil>"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &recorder{}
+			_ = tc.eval(New([]Observer{r}, []any{"root"}))
+			assert.Equal(t, tc.expected, r.events)
+		})
+	}
+}
+
+func TestEval_Panic(t *testing.T) {
+	r := &recorder{}
+	assert.Panics(t, func() {
+		_ = New([]Observer{r}, []any{"root"}).Eval(Option(group{name: "a", options: []func() error{func() error { panic("failed") }}}))
+	})
+	assert.Equal(t, []string{"root>a", "a>", "=<nil>", "a=<nil>"}, r.events)
+}
+
+func TestFork(t *testing.T) {
+	r := &recorder{}
+	s := New([]Observer{r}, []any{"root"})
+	forks := []func() error{
+		s.Fork(Option(group{name: "a", options: []func() error{Option(group{name: "c"})}})),
+		ForkValue(s, 1, ValueOption[int](valueGroup[int]{name: "b"})),
+	}
+	var wg sync.WaitGroup
+	for _, fork := range forks {
+		fork := fork
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = fork()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []string{"root>a", "root>b(1)"}, r.events[:2])
+	assert.ElementsMatch(t, []string{"a>c", "c=<nil>", "a=<nil>", "b(1)=<nil>"}, r.events[2:])
+}
+
+func TestBuiltinName(t *testing.T) {
+	tests := map[string]struct {
+		symbol   string
+		expected string
+	}{
+		"function":          {symbol: optionsPrefix + "VIsEmpty[...]", expected: "VIsEmpty"},
+		"closure":           {symbol: optionsPrefix + "IsLength[...].func1", expected: "IsLength"},
+		"plain closure":     {symbol: optionsPrefix + "IsValidURI.func1", expected: "IsValidURI"},
+		"unexported":        {symbol: optionsPrefix + "isValidURI", expected: ""},
+		"custom option":     {symbol: optionsPrefix + "WithRequire.func1", expected: ""},
+		"custom value":      {symbol: optionsPrefix + "VWithRequire[...].func1", expected: ""},
+		"package variable":  {symbol: optionsPrefix + "glob..func1", expected: ""},
+		"other package":     {symbol: "github.com/Jh123x/go-validate/rules.optional.func1", expected: ""},
+		"caller of options": {symbol: "main.main.func1", expected: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, builtinName(tc.symbol))
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	first, second := &recorder{}, &recorder{}
+	s := New([]Observer{first}, []any{"first"})
+	assert.Same(t, s, Join(s, nil))
+	assert.Same(t, s, Join(nil, s))
+
+	joined := Join(s, WithMemo(New([]Observer{second}, []any{"second"})))
+	_ = joined.Eval(Option(group{name: "a"}))
+	assert.Equal(t, []string{"first>a", "a=<nil>"}, first.events)
+	assert.Equal(t, []string{"second>a", "a=<nil>"}, second.events)
+	assert.NotNil(t, joined.memo)
+}
+
+func TestWithMemo(t *testing.T) {
+	var calls int
+	count := func(int) error {
+		calls++
+		return errTest
+	}
+	keyed := Rule{Name: "keyed", Key: "key"}
+
+	tests := map[string]struct {
+		eval          func() error
+		expectedCalls int
+	}{
+		"without memoization": {
+			eval: func() error {
+				_ = EvalRuleValue(nil, keyed, 1, count)
+				return EvalRuleValue(New(nil, nil), keyed, 1, count)
+			},
+			expectedCalls: 2,
+		},
+		"same input": {
+			eval: func() error {
+				s := WithMemo(nil)
+				_ = EvalRuleValue(s, keyed, 1, count)
+				return EvalRuleValue(WithMemo(s), keyed, 1, count)
+			},
+			expectedCalls: 1,
+		},
+		"different inputs": {
+			eval: func() error {
+				s := WithMemo(nil)
+				_ = EvalRuleValue(s, keyed, 1, count)
+				return EvalRuleValue(s, keyed, 2, count)
+			},
+			expectedCalls: 2,
+		},
+		"without key": {
+			eval: func() error {
+				s := WithMemo(nil)
+				_ = EvalRuleValue(s, Rule{Name: "unkeyed"}, 1, count)
+				return EvalRuleValue(s, Rule{Name: "unkeyed"}, 1, count)
+			},
+			expectedCalls: 2,
+		},
+		"not comparable": {
+			eval: func() error {
+				s := WithMemo(nil)
+				rule := func([]int) error { return count(0) }
+				_ = EvalRuleValue(s, keyed, []int{1}, rule)
+				return EvalRuleValue(s, keyed, []int{1}, rule)
+			},
+			expectedCalls: 2,
+		},
+		"unnamed": {
+			eval: func() error {
+				s := WithMemo(nil)
+				_ = s.EvalRule(Rule{Key: "key"}, func() error { return count(0) })
+				return s.EvalRule(Rule{Key: "key"}, func() error { return count(0) })
+			},
+			expectedCalls: 1,
+		},
+		"separate validations": {
+			eval: func() error {
+				_ = EvalRuleValue(WithMemo(nil), keyed, 1, count)
+				return EvalRuleValue(WithMemo(nil), keyed, 1, count)
+			},
+			expectedCalls: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls = 0
+			assert.Equal(t, errTest, tc.eval())
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+// TestWithMemo_Observers tests that the observers see every evaluation of a memoized rule,
+// without the rules inside of it when its error is reused.
+func TestWithMemo_Observers(t *testing.T) {
+	r := &recorder{}
+	keyed := Rule{Name: "a", Key: "a"}
+	s := WithMemo(New([]Observer{r}, []any{"root"}))
+	_ = s.EvalRule(keyed, Option(group{name: "b", options: []func() error{func() error { return errTest }}}))
+	_ = s.EvalRule(keyed, func() error { return nil })
+	assert.Equal(t, []string{"root>a", "a>b", "b>", "=test error", "b=test error", "a=test error", "root>a", "a=test error"}, r.events)
+}
+
+// TestWithMemo_Fork tests that the forks of a scope share its memoized rules.
+func TestWithMemo_Fork(t *testing.T) {
+	var calls atomic.Int32
+	keyed := Rule{Key: "a"}
+	count := func() error {
+		calls.Add(1)
+		return errTest
+	}
+
+	s := WithMemo(nil)
+	_ = s.EvalRule(keyed, count)
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		fork := s.Fork(func() error { return s.EvalRule(keyed, count) })
+		go func() { errs <- fork() }()
+	}
+	for i := 0; i < cap(errs); i++ {
+		assert.Equal(t, errTest, <-errs)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+// TestWithMemo_InFlight tests that a rule evaluated again while it is in flight on another goroutine
+// is evaluated again instead of waiting for itself.
+func TestWithMemo_InFlight(t *testing.T) {
+	var calls atomic.Int32
+	keyed := Rule{Key: "a"}
+	s := WithMemo(nil)
+	var rule func() error
+	rule = func() error {
+		if calls.Add(1) > 1 {
+			return errTest
+		}
+		fork := s.Fork(func() error { return s.EvalRule(keyed, rule) })
+		done := make(chan error)
+		go func() { done <- fork() }()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(time.Second):
+			return errors.New("deadlock")
+		}
+	}
+
+	assert.Equal(t, errTest, s.EvalRule(keyed, rule))
+	assert.Equal(t, int32(2), calls.Load())
+}
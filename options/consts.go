@@ -1,7 +1,76 @@
 package options
 
-import "regexp"
+import (
+	"regexp"
 
-const emailRegexStr = "(?:[a-z0-9!#$%&'*+/=?^_`{|}~-]+(?:\\.[a-z0-9!#$%&'*+/=?^_`{|}~-]+)*|\"(?:[\x01-\x08\x0b\x0c\x0e-\x1f\x21\x23-\x5b\x5d-\x7f]|\\[\x01-\x09\x0b\x0c\x0e-\x7f])*\")@(?:(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\\.)+[a-z0-9](?:[a-z0-9-]*[a-z0-9])?|\\[(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?|[a-z0-9-]*[a-z0-9]:(?:[\x01-\x08\x0b\x0c\x0e-\x1f\x21-\x5a\x53-\x7f]|\\[\x01-\x09\x0b\x0c\x0e-\x7f])+)\\])"
+	"github.com/Jh123x/go-validate/errs"
+)
 
-var emailRegex = regexp.MustCompile(emailRegexStr)
+const (
+	emailLocalRegexStr  = "(?:[a-z0-9!#$%&'*+/=?^_`{|}~-]+(?:\\.[a-z0-9!#$%&'*+/=?^_`{|}~-]+)*|\"(?:[\x01-\x08\x0b\x0c\x0e-\x1f\x21\x23-\x5b\x5d-\x7f]|\\[\x01-\x09\x0b\x0c\x0e-\x7f])*\")"
+	emailDomainRegexStr = "(?:(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\\.)+[a-z0-9](?:[a-z0-9-]*[a-z0-9])?|\\[(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?|[a-z0-9-]*[a-z0-9]:(?:[\x01-\x08\x0b\x0c\x0e-\x1f\x21-\x5a\x53-\x7f]|\\[\x01-\x09\x0b\x0c\x0e-\x7f])+)\\])"
+	emailRegexStr       = emailLocalRegexStr + "@" + emailDomainRegexStr
+)
+
+var (
+	emailRegex = regexp.MustCompile(emailRegexStr)
+	// wholeEmailRegex only matches strings which are an email address as a whole, capturing its parts.
+	wholeEmailRegex = regexp.MustCompile("^(" + emailLocalRegexStr + ")@(" + emailDomainRegexStr + ")$")
+)
+
+// The errors returned by the built-in options, converted to error once so that returning them does not allocate.
+var (
+	isEmptyErr      error = errs.IsEmptyError
+	isNotEmptyErr   error = errs.IsNotEmptyErr
+	isDefaultErr    error = errs.IsDefaultErr
+	isNotDefaultErr error = errs.IsNotDefaultErr
+	containsErr     error = errs.ContainsError
+	orErr           error = errs.OrError
+	exactlyOneErr   error = errs.ExactlyOneError
+	invalidURIErr   error = errs.InvalidURIError
+	invalidJsonErr  error = errs.InvalidJsonError
+	invalidEmailErr error = errs.InvalidEmailError
+	isNilErr        error = errs.IsNilError
+	isNotNilErr     error = errs.IsNotNilError
+	requiredErr     error = errs.RequiredError
+	noneOfErr       error = errs.NoneOfError
+	subsetOfErr     error = errs.SubsetOfError
+	disjointErr     error = errs.DisjointError
+)
+
+// boundsError returns err holding the inclusive bounds the value was not within.
+func boundsError[T any](err errs.ValidateError, min, max T) error {
+	return err.WithParams(map[string]any{"min": min, "max": max})
+}
+
+// patternError returns errs.PatternError holding the pattern the value did not match.
+func patternError(re *regexp.Regexp) error {
+	return errs.PatternError.WithParams(map[string]any{"pattern": re.String()})
+}
+
+// convertError returns errs.ConvertError holding the message of the error of the conversion.
+func convertError(err error) error {
+	return errs.ConvertError.WithParams(map[string]any{"error": err.Error()})
+}
+
+// joinFindings returns the warnings and infos found before err, followed by err.
+// A single error is returned as is, and nil if there are none.
+func joinFindings(findings errs.Errors, err error) error {
+	if err != nil {
+		findings = append(findings, err)
+	}
+	switch len(findings) {
+	case 0:
+		return nil
+	case 1:
+		return findings[0]
+	default:
+		return findings
+	}
+}
+
+// nameKey is the memoization key of a named option.
+type nameKey string
+
+// memoKey is the memoization key of a Memo option, which is unique to the option as it is not of zero size.
+type memoKey struct{ _ byte }
@@ -0,0 +1,262 @@
+package options
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/Jh123x/go-validate/errs"
+	types "github.com/Jh123x/go-validate/ttypes"
+)
+
+// JsonKind is the kind of a JSON value.
+type JsonKind int
+
+const (
+	JsonAnyKind JsonKind = iota
+	JsonObject
+	JsonArray
+	JsonString
+	JsonNumber
+	JsonBool
+	JsonNull
+)
+
+// JsonConstraints describes the structural checks done on a JSON document.
+// The zero value of each field disables the corresponding check.
+type JsonConstraints struct {
+	Kind              JsonKind // Kind of the top-level value.
+	RequiredKeys      []string // Keys the top-level object must contain.
+	MaxDepth          int      // Maximum nesting depth of objects and arrays.
+	MaxSize           int64    // Maximum size of the document in bytes.
+	NoDuplicateKeys   bool     // Rejects objects which repeat a key.
+	AllowTrailingData bool     // Ignores any data after the top-level value, which is rejected otherwise.
+}
+
+// IsValidJsonWith validates that the provided string is a JSON document satisfying the constraints.
+func IsValidJsonWith(jsonStr string, constraints JsonConstraints) types.Validate {
+	return func() error { return validateJson(jsonStr, constraints) }
+}
+
+// VIsValidJsonWith validates that a string is a JSON document satisfying the constraints.
+func VIsValidJsonWith(constraints JsonConstraints) types.ValTest[string] {
+	return func(jsonStr string) error { return validateJson(jsonStr, constraints) }
+}
+
+// validateJson validates the JSON string against the constraints.
+// Each JSON option returns its own function calling it, so that the options are named after themselves.
+func validateJson(jsonStr string, constraints JsonConstraints) error {
+	return ValidateJsonReader(strings.NewReader(jsonStr), constraints)
+}
+
+// ValidateJsonReader validates the JSON document read from r against the constraints.
+// The document is checked token by token, so it is never fully decoded into memory,
+// and at most MaxSize bytes and one more are read from r.
+func ValidateJsonReader(r io.Reader, constraints JsonConstraints) error {
+	if constraints.MaxSize <= 0 {
+		return newJsonScanner(r, constraints).scan()
+	}
+
+	limited := &io.LimitedReader{R: r, N: constraints.MaxSize + 1}
+	s := newJsonScanner(limited, constraints)
+	err := s.scan()
+	// The limit is only reached if r is longer than MaxSize, in which case the document is too large
+	// unless the data after it is allowed and it ended before the limit.
+	if limited.N == 0 && (err != nil || !constraints.AllowTrailingData || s.dec.InputOffset() > constraints.MaxSize) {
+		return errs.JsonSizeError
+	}
+	return err
+}
+
+// IsJsonKind validates that the top-level value of the provided JSON string is of the given kind.
+func IsJsonKind(jsonStr string, kind JsonKind) types.Validate {
+	constraints := JsonConstraints{Kind: kind}
+	return func() error { return validateJson(jsonStr, constraints) }
+}
+
+// VIsJsonKind validates that the top-level value of a JSON string is of the given kind.
+func VIsJsonKind(kind JsonKind) types.ValTest[string] {
+	constraints := JsonConstraints{Kind: kind}
+	return func(jsonStr string) error { return validateJson(jsonStr, constraints) }
+}
+
+// HasJsonKeys validates that the provided JSON string is an object containing all the keys.
+func HasJsonKeys(jsonStr string, keys ...string) types.Validate {
+	constraints := JsonConstraints{Kind: JsonObject, RequiredKeys: keys}
+	return func() error { return validateJson(jsonStr, constraints) }
+}
+
+// VHasJsonKeys validates that a JSON string is an object containing all the keys.
+func VHasJsonKeys(keys ...string) types.ValTest[string] {
+	constraints := JsonConstraints{Kind: JsonObject, RequiredKeys: keys}
+	return func(jsonStr string) error { return validateJson(jsonStr, constraints) }
+}
+
+// IsJsonMaxDepth validates that objects and arrays in the provided JSON string are nested at most depth levels.
+func IsJsonMaxDepth(jsonStr string, depth int) types.Validate {
+	constraints := JsonConstraints{MaxDepth: depth}
+	return func() error { return validateJson(jsonStr, constraints) }
+}
+
+// VIsJsonMaxDepth validates that objects and arrays in a JSON string are nested at most depth levels.
+func VIsJsonMaxDepth(depth int) types.ValTest[string] {
+	constraints := JsonConstraints{MaxDepth: depth}
+	return func(jsonStr string) error { return validateJson(jsonStr, constraints) }
+}
+
+// IsJsonMaxSize validates that the provided JSON string is at most size bytes long.
+func IsJsonMaxSize(jsonStr string, size int64) types.Validate {
+	constraints := JsonConstraints{MaxSize: size}
+	return func() error { return validateJson(jsonStr, constraints) }
+}
+
+// VIsJsonMaxSize validates that a JSON string is at most size bytes long.
+func VIsJsonMaxSize(size int64) types.ValTest[string] {
+	constraints := JsonConstraints{MaxSize: size}
+	return func(jsonStr string) error { return validateJson(jsonStr, constraints) }
+}
+
+// IsJsonNoDuplicateKeys validates that no object in the provided JSON string repeats a key.
+func IsJsonNoDuplicateKeys(jsonStr string) types.Validate {
+	constraints := JsonConstraints{NoDuplicateKeys: true}
+	return func() error { return validateJson(jsonStr, constraints) }
+}
+
+// VIsJsonNoDuplicateKeys validates that no object in a JSON string repeats a key.
+func VIsJsonNoDuplicateKeys(jsonStr string) error {
+	return validateJson(jsonStr, JsonConstraints{NoDuplicateKeys: true})
+}
+
+// IsJsonNoTrailingData validates that the provided JSON string contains a single value.
+func IsJsonNoTrailingData(jsonStr string) types.Validate {
+	constraints := JsonConstraints{}
+	return func() error { return validateJson(jsonStr, constraints) }
+}
+
+// VIsJsonNoTrailingData validates that a JSON string contains a single value.
+func VIsJsonNoTrailingData(jsonStr string) error {
+	return validateJson(jsonStr, JsonConstraints{})
+}
+
+// jsonFrame is an object or array which is currently being scanned.
+type jsonFrame struct {
+	isObject  bool
+	expectKey bool
+	keys      map[string]struct{}
+}
+
+// jsonScanner walks the tokens of a single JSON value and checks them against the constraints.
+type jsonScanner struct {
+	dec         *json.Decoder
+	constraints JsonConstraints
+	stack       []*jsonFrame
+	topKeys     map[string]struct{}
+}
+
+func newJsonScanner(r io.Reader, constraints JsonConstraints) *jsonScanner {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return &jsonScanner{dec: dec, constraints: constraints}
+}
+
+func (s *jsonScanner) scan() error {
+	tok, err := s.dec.Token()
+	if err != nil {
+		return errs.InvalidJsonError
+	}
+	if s.constraints.Kind != JsonAnyKind && kindOf(tok) != s.constraints.Kind {
+		return errs.InvalidJsonKindError
+	}
+	if err := s.value(tok); err != nil {
+		return err
+	}
+
+	for len(s.stack) > 0 {
+		if tok, err = s.dec.Token(); err != nil {
+			return errs.InvalidJsonError
+		}
+		if err := s.next(tok); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range s.constraints.RequiredKeys {
+		if _, ok := s.topKeys[key]; !ok {
+			return errs.MissingJsonKeyError
+		}
+	}
+
+	if !s.constraints.AllowTrailingData {
+		if _, err := s.dec.Token(); !errors.Is(err, io.EOF) {
+			return errs.JsonTrailingDataError
+		}
+	}
+	return nil
+}
+
+// next handles a token read while inside an object or array.
+func (s *jsonScanner) next(tok json.Token) error {
+	top := s.stack[len(s.stack)-1]
+	if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+		s.stack = s.stack[:len(s.stack)-1]
+		return nil
+	}
+	if !top.isObject || !top.expectKey {
+		if top.isObject {
+			top.expectKey = true
+		}
+		return s.value(tok)
+	}
+
+	key, _ := tok.(string)
+	top.expectKey = false
+	if top.keys == nil {
+		return nil
+	}
+	if _, ok := top.keys[key]; ok && s.constraints.NoDuplicateKeys {
+		return errs.DuplicateJsonKeyError
+	}
+	top.keys[key] = struct{}{}
+	return nil
+}
+
+// value handles the first token of a value, opening a new frame for objects and arrays.
+func (s *jsonScanner) value(tok json.Token) error {
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	frame := &jsonFrame{isObject: delim == '{', expectKey: delim == '{'}
+	if frame.isObject && (s.constraints.NoDuplicateKeys || len(s.stack) == 0 && len(s.constraints.RequiredKeys) > 0) {
+		frame.keys = make(map[string]struct{})
+		if len(s.stack) == 0 {
+			s.topKeys = frame.keys
+		}
+	}
+	s.stack = append(s.stack, frame)
+	if s.constraints.MaxDepth > 0 && len(s.stack) > s.constraints.MaxDepth {
+		return errs.JsonDepthError
+	}
+	return nil
+}
+
+// kindOf returns the kind of the value starting with tok.
+func kindOf(tok json.Token) JsonKind {
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			return JsonObject
+		}
+		return JsonArray
+	case string:
+		return JsonString
+	case json.Number:
+		return JsonNumber
+	case bool:
+		return JsonBool
+	default:
+		return JsonNull
+	}
+}
@@ -0,0 +1,278 @@
+package options
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVIsValidJsonWith(t *testing.T) {
+	tests := map[string]struct {
+		json        string
+		constraints JsonConstraints
+		expectedErr error
+	}{
+		"no constraints valid json": {
+			json: `{"a":[1,2,{"b":null}]}`,
+		},
+		"no constraints invalid json": {
+			json:        `{"a":[1,2}`,
+			expectedErr: errs.InvalidJsonError,
+		},
+		"no constraints truncated json": {
+			json:        `{"a":[1,2`,
+			expectedErr: errs.InvalidJsonError,
+		},
+		"no constraints empty string": {
+			json:        ``,
+			expectedErr: errs.InvalidJsonError,
+		},
+		"kind object success": {
+			json:        `{"a":1}`,
+			constraints: JsonConstraints{Kind: JsonObject},
+		},
+		"kind object fail": {
+			json:        `[1]`,
+			constraints: JsonConstraints{Kind: JsonObject},
+			expectedErr: errs.InvalidJsonKindError,
+		},
+		"kind array success": {
+			json:        `[1]`,
+			constraints: JsonConstraints{Kind: JsonArray},
+		},
+		"kind string success": {
+			json:        `"str"`,
+			constraints: JsonConstraints{Kind: JsonString},
+		},
+		"kind number success": {
+			json:        `1.5e10`,
+			constraints: JsonConstraints{Kind: JsonNumber},
+		},
+		"kind bool success": {
+			json:        `true`,
+			constraints: JsonConstraints{Kind: JsonBool},
+		},
+		"kind null success": {
+			json:        `null`,
+			constraints: JsonConstraints{Kind: JsonNull},
+		},
+		"required keys success": {
+			json:        `{"a":1,"b":{"c":2}}`,
+			constraints: JsonConstraints{RequiredKeys: []string{"a", "b"}},
+		},
+		"required keys nested key does not count": {
+			json:        `{"a":1,"b":{"c":2}}`,
+			constraints: JsonConstraints{RequiredKeys: []string{"a", "c"}},
+			expectedErr: errs.MissingJsonKeyError,
+		},
+		"required keys key used as value does not count": {
+			json:        `{"a":"b"}`,
+			constraints: JsonConstraints{RequiredKeys: []string{"b"}},
+			expectedErr: errs.MissingJsonKeyError,
+		},
+		"max depth at boundary": {
+			json:        `{"a":[{"b":1}]}`,
+			constraints: JsonConstraints{MaxDepth: 3},
+		},
+		"max depth exceeded": {
+			json:        `{"a":[{"b":[]}]}`,
+			constraints: JsonConstraints{MaxDepth: 3},
+			expectedErr: errs.JsonDepthError,
+		},
+		"max depth scalar": {
+			json:        `1`,
+			constraints: JsonConstraints{MaxDepth: 1},
+		},
+		"max size at boundary": {
+			json:        `[1,2]`,
+			constraints: JsonConstraints{MaxSize: 5},
+		},
+		"max size exceeded": {
+			json:        `[1,2,3]`,
+			constraints: JsonConstraints{MaxSize: 5},
+			expectedErr: errs.JsonSizeError,
+		},
+		"no duplicate keys success": {
+			json:        `{"a":{"a":1},"b":[{"a":1},{"a":2}]}`,
+			constraints: JsonConstraints{NoDuplicateKeys: true},
+		},
+		"no duplicate keys top level": {
+			json:        `{"a":1,"a":2}`,
+			constraints: JsonConstraints{NoDuplicateKeys: true},
+			expectedErr: errs.DuplicateJsonKeyError,
+		},
+		"no duplicate keys nested": {
+			json:        `[{"a":1,"b":{"c":1,"c":2}}]`,
+			constraints: JsonConstraints{NoDuplicateKeys: true},
+			expectedErr: errs.DuplicateJsonKeyError,
+		},
+		"trailing whitespace": {
+			json: "{}  \n",
+		},
+		"trailing second value": {
+			json:        `{} {}`,
+			expectedErr: errs.JsonTrailingDataError,
+		},
+		"trailing garbage": {
+			json:        `[1] abc`,
+			expectedErr: errs.JsonTrailingDataError,
+		},
+		"trailing garbage after keys": {
+			json:        `{"a":1}garbage`,
+			constraints: JsonConstraints{Kind: JsonObject, RequiredKeys: []string{"a"}},
+			expectedErr: errs.JsonTrailingDataError,
+		},
+		"trailing closing brace": {
+			json:        `{"a":1}}`,
+			expectedErr: errs.JsonTrailingDataError,
+		},
+		"trailing data allowed": {
+			json:        `{"a":1}garbage`,
+			constraints: JsonConstraints{RequiredKeys: []string{"a"}, AllowTrailingData: true},
+		},
+		"trailing data allowed within size": {
+			json:        `[1]` + strings.Repeat("x", 10),
+			constraints: JsonConstraints{MaxSize: 5, AllowTrailingData: true},
+		},
+		"all constraints success": {
+			json: `{"id":1,"tags":["a","b"]}`,
+			constraints: JsonConstraints{
+				Kind:            JsonObject,
+				RequiredKeys:    []string{"id", "tags"},
+				MaxDepth:        2,
+				MaxSize:         100,
+				NoDuplicateKeys: true,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, VIsValidJsonWith(tc.constraints)(tc.json))
+			assert.Equal(t, tc.expectedErr, IsValidJsonWith(tc.json, tc.constraints)())
+		})
+	}
+}
+
+func TestValidateJsonReader_MaxSize(t *testing.T) {
+	tests := map[string]struct {
+		json          string
+		maxSize       int64
+		allowTrailing bool
+		expectedErr   error
+	}{
+		"within size": {
+			json:    `{"a":"b"}`,
+			maxSize: 9,
+		},
+		"truncated by size": {
+			json:        `{"a":"` + strings.Repeat("b", 100) + `"}`,
+			maxSize:     10,
+			expectedErr: errs.JsonSizeError,
+		},
+		"trailing whitespace counts towards size": {
+			json:        `{}` + strings.Repeat(" ", 10),
+			maxSize:     5,
+			expectedErr: errs.JsonSizeError,
+		},
+		"buffered data after document does not count": {
+			json:          `{}` + strings.Repeat(" ", 10000),
+			maxSize:       5,
+			allowTrailing: true,
+		},
+		"trailing data beyond size": {
+			json:          `[1,2,3]` + strings.Repeat("x", 10000),
+			maxSize:       5,
+			allowTrailing: true,
+			expectedErr:   errs.JsonSizeError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			constraints := JsonConstraints{MaxSize: tc.maxSize, AllowTrailingData: tc.allowTrailing}
+			assert.Equal(t, tc.expectedErr, ValidateJsonReader(strings.NewReader(tc.json), constraints))
+		})
+	}
+}
+
+func TestJsonSingleChecks(t *testing.T) {
+	tests := map[string]struct {
+		json        string
+		validateErr func(string) error
+		expectedErr error
+	}{
+		"IsJsonKind success": {
+			json:        `[]`,
+			validateErr: func(s string) error { return IsJsonKind(s, JsonArray)() },
+		},
+		"VIsJsonKind fail": {
+			json:        `{}`,
+			validateErr: VIsJsonKind(JsonArray),
+			expectedErr: errs.InvalidJsonKindError,
+		},
+		"HasJsonKeys success": {
+			json:        `{"a":1}`,
+			validateErr: func(s string) error { return HasJsonKeys(s, "a")() },
+		},
+		"HasJsonKeys trailing data": {
+			json:        `{"a":1}garbage`,
+			validateErr: func(s string) error { return HasJsonKeys(s, "a")() },
+			expectedErr: errs.JsonTrailingDataError,
+		},
+		"HasJsonKeys not an object": {
+			json:        `["a"]`,
+			validateErr: func(s string) error { return HasJsonKeys(s, "a")() },
+			expectedErr: errs.InvalidJsonKindError,
+		},
+		"VHasJsonKeys fail": {
+			json:        `{"a":1}`,
+			validateErr: VHasJsonKeys("a", "b"),
+			expectedErr: errs.MissingJsonKeyError,
+		},
+		"IsJsonMaxDepth success": {
+			json:        `[[]]`,
+			validateErr: func(s string) error { return IsJsonMaxDepth(s, 2)() },
+		},
+		"VIsJsonMaxDepth fail": {
+			json:        `[[[]]]`,
+			validateErr: VIsJsonMaxDepth(2),
+			expectedErr: errs.JsonDepthError,
+		},
+		"IsJsonMaxSize success": {
+			json:        `[]`,
+			validateErr: func(s string) error { return IsJsonMaxSize(s, 2)() },
+		},
+		"VIsJsonMaxSize fail": {
+			json:        `[1]`,
+			validateErr: VIsJsonMaxSize(2),
+			expectedErr: errs.JsonSizeError,
+		},
+		"IsJsonNoDuplicateKeys success": {
+			json:        `{"a":1,"b":1}`,
+			validateErr: func(s string) error { return IsJsonNoDuplicateKeys(s)() },
+		},
+		"VIsJsonNoDuplicateKeys fail": {
+			json:        `{"a":1,"a":1}`,
+			validateErr: VIsJsonNoDuplicateKeys,
+			expectedErr: errs.DuplicateJsonKeyError,
+		},
+		"IsJsonNoTrailingData success": {
+			json:        `"a"`,
+			validateErr: func(s string) error { return IsJsonNoTrailingData(s)() },
+		},
+		"VIsJsonNoTrailingData fail": {
+			json:        `"a" "b"`,
+			validateErr: VIsJsonNoTrailingData,
+			expectedErr: errs.JsonTrailingDataError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.validateErr(tc.json))
+		})
+	}
+}
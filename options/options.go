@@ -2,6 +2,7 @@ package options
 
 import (
 	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/internal/scope"
 	types "github.com/Jh123x/go-validate/ttypes"
 )
 
@@ -18,59 +19,214 @@ func WithRequire(t types.Test, err error) types.Validate {
 // IsNotEmpty validates that the provided value is not the empty/default value.
 func IsNotEmpty[T comparable](val T) types.Validate {
 	var defaultVal T
-	return WithRequire(func() bool { return val != defaultVal }, errs.IsNotEmptyErr)
+	return func() error {
+		if val == defaultVal {
+			return isNotEmptyErr
+		}
+		return nil
+	}
 }
 
 // IsEmpty validates that the provided value is equals to the empty/default value.
 func IsEmpty[T comparable](val T) types.Validate {
-	return IsNotEmpty(val).Not(errs.IsEmptyError)
+	var defaultVal T
+	return func() error {
+		if val != defaultVal {
+			return isEmptyErr
+		}
+		return nil
+	}
 }
 
 // IsLength validates the the provided value is between, inclusive, the start and end values.
 func IsLength[T any](arr []T, start, end int) types.Validate {
-	return WithRequire(func() bool { return len(arr) >= start && len(arr) <= end }, errs.InvalidLengthError)
+	return func() error {
+		if len(arr) >= start && len(arr) <= end {
+			return nil
+		}
+		return boundsError(errs.InvalidLengthError, start, end)
+	}
+}
+
+// IsInRange validates that the provided value is between, inclusive, the min and max values.
+func IsInRange[T types.Ordered](val, min, max T) types.Validate {
+	return func() error {
+		if val >= min && val <= max {
+			return nil
+		}
+		return boundsError(errs.OutOfRangeError, min, max)
+	}
 }
 
 // Contains validates that the provided array contains the provided element.
 func Contains[T comparable](arr []T, elem T) types.Validate {
-	return WithRequire(func() bool {
+	return func() error {
 		for _, v := range arr {
 			if v == elem {
-				return true
+				return nil
 			}
 		}
-		return false
-	}, errs.ContainsError)
+		return containsErr
+	}
 }
 
 // Or validates that at least one of the provided options is valid.
 // If none of the options are valid, then Or returns a errs.OrError.
+// An option returning only warnings and infos is valid.
 func Or(options ...types.Validate) types.Validate {
-	return func() error {
-		for _, option := range options {
-			if option == nil {
-				continue
-			}
-			if err := option(); err == nil {
-				return nil
-			}
+	return scope.Option(or(options))
+}
+
+// or is the node of Or.
+type or []types.Validate
+
+func (or) Rule() scope.Rule { return scope.Rule{Name: "Or"} }
+
+func (o or) Evaluate(s *scope.Scope) error {
+	for _, option := range o {
+		if option == nil {
+			continue
+		}
+		if err := s.Eval(option); errs.OnlyErrors(err) == nil {
+			return nil
 		}
-		return errs.OrError
 	}
+	return orErr
 }
 
 // And validates that all of the provided options are valid.
 // If any of the options are invalid, then And returns the first error.
+// Warnings and infos do not stop And, and are returned along with the first error.
 func And(options ...types.Validate) types.Validate {
-	return func() error {
-		for _, option := range options {
-			if option == nil {
-				continue
-			}
-			if err := option(); err != nil {
-				return err
-			}
+	return scope.Option(and(options))
+}
+
+// and is the node of And.
+type and []types.Validate
+
+func (and) Rule() scope.Rule { return scope.Rule{Name: "And"} }
+
+func (a and) Evaluate(s *scope.Scope) error {
+	var findings errs.Errors
+	for _, option := range a {
+		if option == nil {
+			continue
+		}
+		err := s.Eval(option)
+		if err == nil {
+			continue
+		}
+		if errs.SeverityOf(err) == errs.SeverityError {
+			return joinFindings(findings, err)
 		}
+		findings = append(findings, err)
+	}
+	return joinFindings(findings, nil)
+}
+
+// Named gives the option a name, such as "age-adult".
+// The name is shown in traces, and the errors of the option are wrapped in an errs.NamedError.
+// In a validation with memoization, the option is evaluated once for each name.
+func Named(name string, option types.Validate) types.Validate {
+	return Described(name, "", option)
+}
+
+// Described gives the option a name and a description, as Named does.
+func Described(name, description string, option types.Validate) types.Validate {
+	return scope.Option(named{rule: scope.Rule{Name: name, Description: description, Key: nameKey(name)}, option: option})
+}
+
+// named is the node of Named and Described, which is transparent as it reports the option as its rule.
+type named struct {
+	rule   scope.Rule
+	option types.Validate
+}
+
+func (named) Rule() scope.Rule { return scope.Rule{} }
+
+func (n named) Evaluate(s *scope.Scope) error {
+	if n.option == nil {
+		return nil
+	}
+	return errs.WithName(n.rule.Name, n.rule.Description, s.EvalRule(n.rule, n.option))
+}
+
+// Memo evaluates the option once in a validation with memoization, such as with the WithMemo
+// method of the validators, returning the same error for every later evaluation.
+func Memo(option types.Validate) types.Validate {
+	return scope.Option(memo{key: new(memoKey), option: option})
+}
+
+// memo is the node of Memo, which is transparent as it does not change the outcome of the option.
+type memo struct {
+	key    *memoKey
+	option types.Validate
+}
+
+func (memo) Rule() scope.Rule { return scope.Rule{} }
+
+func (m memo) Evaluate(s *scope.Scope) error {
+	if m.option == nil {
+		return nil
+	}
+	return s.EvalRule(scope.Rule{Key: m.key}, m.option)
+}
+
+// WithSeverity gives the errors of the option the severity.
+func WithSeverity(severity errs.Severity, option types.Validate) types.Validate {
+	return scope.Option(withSeverity{name: "WithSeverity", severity: severity, option: option})
+}
+
+// Warn turns the errors of the option into warnings, which do not reject the value.
+func Warn(option types.Validate) types.Validate {
+	return scope.Option(withSeverity{name: "Warn", severity: errs.SeverityWarning, option: option})
+}
+
+// Info turns the errors of the option into infos, which do not reject the value.
+func Info(option types.Validate) types.Validate {
+	return scope.Option(withSeverity{name: "Info", severity: errs.SeverityInfo, option: option})
+}
+
+// withSeverity is the node of WithSeverity, Warn and Info, named after the function which made it.
+type withSeverity struct {
+	name     string
+	severity errs.Severity
+	option   types.Validate
+}
+
+func (w withSeverity) Rule() scope.Rule { return scope.Rule{Name: w.name} }
+
+func (w withSeverity) Evaluate(s *scope.Scope) error {
+	if w.option == nil {
 		return nil
 	}
+	return errs.WithSeverity(w.severity, s.Eval(w.option))
+}
+
+// ExactlyOne validates that exactly one of the provided options is valid.
+// If none or more than one of the options are valid, then ExactlyOne returns a errs.ExactlyOneError.
+// An option returning only warnings and infos is valid.
+func ExactlyOne(options ...types.Validate) types.Validate {
+	return scope.Option(exactlyOne(options))
+}
+
+// exactlyOne is the node of ExactlyOne.
+type exactlyOne []types.Validate
+
+func (exactlyOne) Rule() scope.Rule { return scope.Rule{Name: "ExactlyOne"} }
+
+func (e exactlyOne) Evaluate(s *scope.Scope) error {
+	passed := 0
+	for _, option := range e {
+		if option == nil {
+			continue
+		}
+		if err := s.Eval(option); errs.OnlyErrors(err) == nil {
+			passed++
+		}
+	}
+	if passed != 1 {
+		return exactlyOneErr
+	}
+	return nil
 }
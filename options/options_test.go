@@ -2,9 +2,11 @@ package options
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/internal/scope"
 	"github.com/Jh123x/go-validate/ttypes"
 
 	"github.com/stretchr/testify/assert"
@@ -98,13 +100,13 @@ func TestIsLength(t *testing.T) {
 			arr:         testArr,
 			start:       len(testArr) + 1,
 			end:         len(testArr) + 2,
-			expectedErr: errs.InvalidLengthError,
+			expectedErr: errs.InvalidLengthError.WithParams(map[string]any{"min": len(testArr) + 1, "max": len(testArr) + 2}),
 		},
 		"array is too long": {
 			arr:         testArr,
 			start:       len(testArr) - 2,
 			end:         len(testArr) - 1,
-			expectedErr: errs.InvalidLengthError,
+			expectedErr: errs.InvalidLengthError.WithParams(map[string]any{"min": len(testArr) - 2, "max": len(testArr) - 1}),
 		},
 		"array is correct length": {
 			arr:         testArr,
@@ -171,6 +173,21 @@ func TestOr(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		"warnings are valid": {
+			options: []ttypes.Validate{
+				func() error { return errs.IsNotEmptyErr },
+				Warn(func() error { return errs.IsEmptyError }),
+			},
+			expectedErr: nil,
+		},
+		"warnings with an error are invalid": {
+			options: []ttypes.Validate{
+				func() error {
+					return errs.Errors{errs.WithSeverity(errs.SeverityWarning, errs.IsEmptyError), errs.IsNotEmptyErr}
+				},
+			},
+			expectedErr: errs.OrError,
+		},
 	}
 
 	for testName, testCase := range tests {
@@ -218,6 +235,26 @@ func TestAnd(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		"warnings do not stop": {
+			options: []ttypes.Validate{
+				Warn(IsEmpty("a")),
+				func() error { return nil },
+			},
+			expectedErr: errs.WithSeverity(errs.SeverityWarning, errs.IsEmptyError),
+		},
+		"warnings are returned with the first error": {
+			options: []ttypes.Validate{
+				Warn(IsEmpty("a")),
+				Info(IsNotEmpty("")),
+				func() error { return errs.InvalidLengthError },
+				func() error { return errs.OrError },
+			},
+			expectedErr: errs.Errors{
+				errs.WithSeverity(errs.SeverityWarning, errs.IsEmptyError),
+				errs.WithSeverity(errs.SeverityInfo, errs.IsNotEmptyErr),
+				errs.InvalidLengthError,
+			},
+		},
 	}
 
 	for testName, testCase := range tests {
@@ -394,7 +431,7 @@ func TestVIsLength(t *testing.T) {
 			val:         []rune("test"),
 			minLen:      5,
 			maxLen:      10,
-			expectedErr: errs.InvalidLengthError,
+			expectedErr: errs.InvalidLengthError.WithParams(map[string]any{"min": 5, "max": 10}),
 		},
 	}
 
@@ -460,6 +497,14 @@ func TestVOr(t *testing.T) {
 			},
 			expectedErr: errs.OrError,
 		},
+		"warnings are valid": {
+			val: []string{"test", "test2"},
+			orOptions: []ttypes.ValTest[[]string]{
+				VIsEmpty[string],        // Fail
+				VWarn(VIsEmpty[string]), // Warn
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for name, tc := range tests {
@@ -499,6 +544,29 @@ func TestVAnd(t *testing.T) {
 			},
 			expectedErr: errs.IsEmptyError,
 		},
+		"warnings do not stop": {
+			val: []string{"test", "test2"},
+			andOptions: []ttypes.ValTest[[]string]{
+				nil,
+				VWarn(VIsEmpty[string]),   // Warn
+				VIsLength[string](10, 20), // Fail
+			},
+			expectedErr: errs.Errors{
+				errs.WithSeverity(errs.SeverityWarning, errs.IsEmptyError),
+				errs.InvalidLengthError.WithParams(map[string]any{"min": 10, "max": 20}),
+			},
+		},
+		"only warnings": {
+			val: []string{"test", "test2"},
+			andOptions: []ttypes.ValTest[[]string]{
+				VWarn(VIsEmpty[string]),        // Warn
+				VInfo(VIsLength[string](5, 6)), // Info
+			},
+			expectedErr: errs.Errors{
+				errs.WithSeverity(errs.SeverityWarning, errs.IsEmptyError),
+				errs.WithSeverity(errs.SeverityInfo, errs.InvalidLengthError.WithParams(map[string]any{"min": 5, "max": 6})),
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -507,3 +575,401 @@ func TestVAnd(t *testing.T) {
 		})
 	}
 }
+
+// TestExactlyOne tests if the ExactlyOne function works as expected.
+func TestExactlyOne(t *testing.T) {
+	tests := map[string]struct {
+		options     []ttypes.Validate
+		expectedErr error
+	}{
+		"one option is valid": {
+			options: []ttypes.Validate{
+				func() error { return errs.OrError },
+				func() error { return nil },
+			},
+			expectedErr: nil,
+		},
+		"more than one option is valid": {
+			options: []ttypes.Validate{
+				func() error { return nil },
+				func() error { return nil },
+			},
+			expectedErr: errs.ExactlyOneError,
+		},
+		"no options are valid": {
+			options: []ttypes.Validate{
+				func() error { return errs.IsNotEmptyErr },
+			},
+			expectedErr: errs.ExactlyOneError,
+		},
+		"nil options will be skipped": {
+			options: []ttypes.Validate{
+				nil,
+				func() error { return nil },
+			},
+			expectedErr: nil,
+		},
+		"info is valid": {
+			options: []ttypes.Validate{
+				Info(func() error { return errs.IsEmptyError }),
+				func() error { return errs.IsNotEmptyErr },
+			},
+			expectedErr: nil,
+		},
+		"warning and valid option are more than one": {
+			options: []ttypes.Validate{
+				Warn(func() error { return errs.IsEmptyError }),
+				func() error { return nil },
+			},
+			expectedErr: errs.ExactlyOneError,
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			validateFn := ExactlyOne(testCase.options...)
+			assert.Equal(t, testCase.expectedErr, validateFn())
+		})
+	}
+}
+
+// TestIsInRange tests if the IsInRange function works as expected.
+func TestIsInRange(t *testing.T) {
+	tests := map[string]struct {
+		val         float64
+		min         float64
+		max         float64
+		expectedErr error
+	}{
+		"within range": {
+			val: 2,
+			min: 1,
+			max: 3,
+		},
+		"at lower boundary": {
+			val: 1,
+			min: 1,
+			max: 3,
+		},
+		"at upper boundary": {
+			val: 3,
+			min: 1,
+			max: 3,
+		},
+		"below range": {
+			val:         0.5,
+			min:         1,
+			max:         3,
+			expectedErr: errs.OutOfRangeError.WithParams(map[string]any{"min": 1.0, "max": 3.0}),
+		},
+		"above range": {
+			val:         3.5,
+			min:         1,
+			max:         3,
+			expectedErr: errs.OutOfRangeError.WithParams(map[string]any{"min": 1.0, "max": 3.0}),
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedErr, IsInRange(testCase.val, testCase.min, testCase.max)())
+			assert.Equal(t, testCase.expectedErr, VIsInRange(testCase.min, testCase.max)(testCase.val))
+		})
+	}
+}
+
+// TestWithSeverity tests that WithSeverity sets the severity of the errors of the option.
+func TestWithSeverity(t *testing.T) {
+	tests := map[string]struct {
+		option           ttypes.Validate
+		expectedErr      error
+		expectedSeverity errs.Severity
+	}{
+		"valid option": {
+			option:           Warn(IsEmpty("")),
+			expectedSeverity: errs.SeverityInfo,
+		},
+		"nil option": {
+			option:           Warn(nil),
+			expectedSeverity: errs.SeverityInfo,
+		},
+		"warning": {
+			option:           Warn(IsEmpty("a")),
+			expectedErr:      errs.IsEmptyError,
+			expectedSeverity: errs.SeverityWarning,
+		},
+		"info": {
+			option:           Info(IsEmpty("a")),
+			expectedErr:      errs.IsEmptyError,
+			expectedSeverity: errs.SeverityInfo,
+		},
+		"error": {
+			option:           WithSeverity(errs.SeverityError, Warn(IsEmpty("a"))),
+			expectedErr:      errs.IsEmptyError,
+			expectedSeverity: errs.SeverityError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.option()
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedSeverity, errs.SeverityOf(err))
+		})
+	}
+}
+
+// TestVWithSeverity tests that VWithSeverity sets the severity of the errors of the option.
+func TestVWithSeverity(t *testing.T) {
+	tests := map[string]struct {
+		option           ttypes.ValTest[int]
+		val              int
+		expectedErr      error
+		expectedSeverity errs.Severity
+	}{
+		"valid option": {
+			option:           VWarn(VIsDefault[int]()),
+			expectedSeverity: errs.SeverityInfo,
+		},
+		"nil option": {
+			option:           VWarn[int](nil),
+			val:              1,
+			expectedSeverity: errs.SeverityInfo,
+		},
+		"warning": {
+			option:           VWarn(VIsDefault[int]()),
+			val:              1,
+			expectedErr:      errs.IsDefaultErr,
+			expectedSeverity: errs.SeverityWarning,
+		},
+		"info": {
+			option:           VInfo(VIsDefault[int]()),
+			val:              1,
+			expectedErr:      errs.IsDefaultErr,
+			expectedSeverity: errs.SeverityInfo,
+		},
+		"error": {
+			option:           VWithSeverity(errs.SeverityError, VWarn(VIsDefault[int]())),
+			val:              1,
+			expectedErr:      errs.IsDefaultErr,
+			expectedSeverity: errs.SeverityError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.option(tc.val)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedSeverity, errs.SeverityOf(err))
+		})
+	}
+}
+
+// TestNamed tests that Named and Described give the errors of the option a name.
+func TestNamed(t *testing.T) {
+	tests := map[string]struct {
+		option       ttypes.Validate
+		expectedErr  error
+		expectedName string
+	}{
+		"valid option": {
+			option: Named("empty", IsEmpty("")),
+		},
+		"nil option": {
+			option: Named("empty", nil),
+		},
+		"invalid option": {
+			option:       Named("empty", IsEmpty("a")),
+			expectedErr:  errs.IsEmptyError,
+			expectedName: "empty",
+		},
+		"described": {
+			option:       Described("empty", "must be empty", IsEmpty("a")),
+			expectedErr:  errs.IsEmptyError,
+			expectedName: "empty",
+		},
+		"nested": {
+			option:       Named("outer", Named("inner", IsEmpty("a"))),
+			expectedErr:  errs.IsEmptyError,
+			expectedName: "outer",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.option()
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedName, errs.NameOf(err))
+		})
+	}
+}
+
+// TestVNamed tests that VNamed and VDescribed give the errors of the option a name.
+func TestVNamed(t *testing.T) {
+	tests := map[string]struct {
+		option       ttypes.ValTest[int]
+		val          int
+		expectedErr  error
+		expectedName string
+	}{
+		"valid option": {
+			option: VNamed("age-adult", VIsInRange(18, 150)),
+			val:    20,
+		},
+		"nil option": {
+			option: VNamed[int]("age-adult", nil),
+		},
+		"invalid option": {
+			option:       VNamed("age-adult", VIsInRange(18, 150)),
+			val:          1,
+			expectedErr:  errs.OutOfRangeError,
+			expectedName: "age-adult",
+		},
+		"described": {
+			option:       VDescribed("age-adult", "must be 18 or over", VIsInRange(18, 150)),
+			val:          1,
+			expectedErr:  errs.OutOfRangeError,
+			expectedName: "age-adult",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.option(tc.val)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedName, errs.NameOf(err))
+		})
+	}
+}
+
+// TestMemo tests that Memo and VMemo evaluate the option once in a validation with memoization.
+func TestMemo(t *testing.T) {
+	var calls int
+	expensive := func() error {
+		calls++
+		return errs.IsEmptyError
+	}
+	vExpensive := func(val int) error { return expensive() }
+	memo, vMemo := Memo(expensive), VMemo(vExpensive)
+
+	tests := map[string]struct {
+		eval          func(s *scope.Scope) error
+		expectedErr   error
+		expectedCalls int
+	}{
+		"memo": {
+			eval:          func(s *scope.Scope) error { return s.Eval(Or(And(memo, IsEmpty("")), And(memo, IsEmpty("a")))) },
+			expectedErr:   errs.OrError,
+			expectedCalls: 1,
+		},
+		"different memos": {
+			eval:          func(s *scope.Scope) error { return s.Eval(Or(memo, Memo(expensive))) },
+			expectedErr:   errs.OrError,
+			expectedCalls: 2,
+		},
+		"nil memo": {
+			eval: func(s *scope.Scope) error {
+				_ = s.Eval(Memo(nil))
+				return scope.EvalValue(s, 1, VMemo[int](nil))
+			},
+		},
+		"value memo": {
+			eval: func(s *scope.Scope) error {
+				return scope.EvalValue(s, 1, VOr(VAnd(vMemo, VIsDefault[int]()), VAnd(vMemo, VIsNotDefault[int]()), VNamed("other", vMemo)))
+			},
+			expectedErr:   errs.OrError,
+			expectedCalls: 1,
+		},
+		"named": {
+			eval: func(s *scope.Scope) error {
+				return s.Eval(Or(Named("expensive", expensive), Named("expensive", expensive), Named("other", expensive)))
+			},
+			expectedErr:   errs.OrError,
+			expectedCalls: 2,
+		},
+		"value named": {
+			eval: func(s *scope.Scope) error {
+				named := VNamed("expensive", vExpensive)
+				return scope.EvalValue(s, 1, VOr(named, named, VField("a", func(v int) int { return v + 1 }, named)))
+			},
+			expectedErr:   errs.OrError,
+			expectedCalls: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls = 0
+			assert.Equal(t, tc.expectedErr, tc.eval(nil))
+			withoutMemo := calls
+
+			calls = 0
+			assert.Equal(t, tc.expectedErr, tc.eval(scope.WithMemo(nil)))
+			assert.Equal(t, tc.expectedCalls, calls)
+			assert.GreaterOrEqual(t, withoutMemo, calls)
+		})
+	}
+}
+
+// TestMap tests that Map validates the converted value, pointing to the stage which failed.
+func TestMap(t *testing.T) {
+	toCelsius := func(f int) (float64, error) { return float64(f-32) * 5 / 9, nil }
+	convertErr := errs.ConvertError.WithParams(map[string]any{"error": `strconv.Atoi: parsing "abc": invalid syntax`})
+	rangeErr := errs.OutOfRangeError.WithParams(map[string]any{"min": 1, "max": 100})
+	celsiusErr := errs.OutOfRangeError.WithParams(map[string]any{"min": 0.0, "max": 30.0})
+	tests := map[string]struct {
+		option        ttypes.ValTest[string]
+		value         string
+		expectedErr   error
+		expectedStage string
+	}{
+		"converted and valid": {
+			option: Map(strconv.Atoi, VIsInRange(1, 100)),
+			value:  "42",
+		},
+		"converted without rules": {
+			option: Map(strconv.Atoi),
+			value:  "-1",
+		},
+		"nil convert": {
+			option: Map[string, int](nil, VIsInRange(1, 100)),
+			value:  "abc",
+		},
+		"cannot convert": {
+			option:        Map(strconv.Atoi, VIsInRange(1, 100)),
+			value:         "abc",
+			expectedErr:   errs.StageError{Stage: "int", Err: convertErr},
+			expectedStage: "int",
+		},
+		"converted and invalid": {
+			option:        Map(strconv.Atoi, VIsInRange(1, 100)),
+			value:         "101",
+			expectedErr:   errs.StageError{Stage: "int", Err: rangeErr},
+			expectedStage: "int",
+		},
+		"chained and valid": {
+			option: Map(strconv.Atoi, VIsInRange(1, 100), Map(toCelsius, VIsInRange(0.0, 30.0))),
+			value:  "68",
+		},
+		"chained and invalid": {
+			option:        Map(strconv.Atoi, VIsInRange(1, 100), Map(toCelsius, VIsInRange(0.0, 30.0))),
+			value:         "95",
+			expectedErr:   errs.StageError{Stage: "int", Err: errs.StageError{Stage: "float64", Err: celsiusErr}},
+			expectedStage: "float64",
+		},
+		"field": {
+			option:        VField("age", func(s string) string { return s }, Map(strconv.Atoi, VIsInRange(1, 100))),
+			value:         "0",
+			expectedErr:   errs.PathError{Pointer: "/age", Err: errs.StageError{Stage: "int", Err: rangeErr}},
+			expectedStage: "int",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.option(tc.value)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedStage, errs.StageOf(err))
+		})
+	}
+}
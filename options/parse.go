@@ -0,0 +1,76 @@
+package options
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/Jh123x/go-validate/errs"
+	types "github.com/Jh123x/go-validate/ttypes"
+)
+
+// Email is an email address parsed by ParseEmail.
+type Email struct {
+	Local  string
+	Domain string
+}
+
+// String returns the email address.
+func (e Email) String() string {
+	return e.Local + "@" + e.Domain
+}
+
+// ParseURI parses the string as IsValidURI does and validates the URL with the rules.
+// It returns errs.InvalidURIError and a nil URL if the string is not a valid URL.
+// Otherwise it returns the URL along with the error of the rules, which are evaluated as VAnd does.
+func ParseURI(uriStr string, rules ...types.ValTest[*url.URL]) (*url.URL, error) {
+	uri, err := url.ParseRequestURI(uriStr)
+	if err != nil {
+		return nil, invalidURIErr
+	}
+	return uri, VAnd(rules...)(uri)
+}
+
+// ParseJSON decodes the JSON string into a T and validates it with the rules.
+// It returns errs.InvalidJsonError if the string is not a valid JSON, and errs.InvalidTypeError at
+// the location of the value if a value does not fit T, along with the zero T.
+// Otherwise it returns the decoded value along with the error of the rules, which are evaluated as VAnd does.
+func ParseJSON[T any](jsonStr string, rules ...types.ValTest[T]) (T, error) {
+	var val T
+	if err := json.Unmarshal([]byte(jsonStr), &val); err != nil {
+		var zero T
+		return zero, jsonDecodeError(err)
+	}
+	return val, VAnd(rules...)(val)
+}
+
+// ParseEmail parses the string as an email address and validates the address with the rules.
+// Unlike IsValidEmail, the whole string must be the address.
+// It returns errs.InvalidEmailError and the zero Email if the string is not a valid email.
+// Otherwise it returns the address along with the error of the rules, which are evaluated as VAnd does.
+func ParseEmail(email string, rules ...types.ValTest[Email]) (Email, error) {
+	parts := wholeEmailRegex.FindStringSubmatch(email)
+	if parts == nil {
+		return Email{}, invalidEmailErr
+	}
+	addr := Email{Local: parts[1], Domain: parts[2]}
+	return addr, VAnd(rules...)(addr)
+}
+
+// jsonDecodeError returns the validation error of the error returned by json.Unmarshal.
+func jsonDecodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return invalidJsonErr
+	}
+	found := error(errs.InvalidTypeError.WithParams(map[string]any{"type": typeErr.Type.String()}))
+	if typeErr.Field == "" {
+		return found
+	}
+	fields := strings.Split(typeErr.Field, ".")
+	for i := len(fields) - 1; i >= 0; i-- {
+		found = errs.WithPath(fields[i], found)
+	}
+	return found
+}
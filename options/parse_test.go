@@ -0,0 +1,182 @@
+package options
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURI(t *testing.T) {
+	isHTTPS := func(uri *url.URL) error {
+		if uri.Scheme != "https" {
+			return errs.PatternError
+		}
+		return nil
+	}
+	tests := map[string]struct {
+		uri          string
+		rules        []ttypes.ValTest[*url.URL]
+		expectedHost string
+		expectedErr  error
+	}{
+		"valid url": {
+			uri:          "https://github.com/Jh123x/go-validate",
+			expectedHost: "github.com",
+		},
+		"valid url passing rules": {
+			uri:          "https://github.com/Jh123x/go-validate",
+			rules:        []ttypes.ValTest[*url.URL]{nil, isHTTPS},
+			expectedHost: "github.com",
+		},
+		"valid url failing rules": {
+			uri:          "http://google.com",
+			rules:        []ttypes.ValTest[*url.URL]{isHTTPS},
+			expectedHost: "google.com",
+			expectedErr:  errs.PatternError,
+		},
+		"invalid url": {
+			uri:         "www.google.com",
+			rules:       []ttypes.ValTest[*url.URL]{isHTTPS},
+			expectedErr: errs.InvalidURIError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			uri, err := ParseURI(tc.uri, tc.rules...)
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedHost == "" {
+				assert.Nil(t, uri)
+				return
+			}
+			assert.Equal(t, tc.expectedHost, uri.Host)
+		})
+	}
+}
+
+type parseUser struct {
+	Name    string `json:"name"`
+	Age     int    `json:"age"`
+	Address struct {
+		Zip int `json:"zip"`
+	} `json:"address"`
+}
+
+func TestParseJSON(t *testing.T) {
+	isAdult := VField("age", func(u parseUser) int { return u.Age }, VIsInRange(18, 150))
+	adult := parseUser{Name: "jh123x", Age: 20}
+	child := parseUser{Name: "jh123x", Age: 10}
+	zipped := parseUser{Name: "jh123x", Age: 20}
+	zipped.Address.Zip = 123
+	typeErr := errs.InvalidTypeError.WithParams(map[string]any{"type": "int"})
+
+	tests := map[string]struct {
+		json        string
+		rules       []ttypes.ValTest[parseUser]
+		expected    parseUser
+		expectedErr error
+	}{
+		"valid json": {
+			json:     `{"name":"jh123x","age":20}`,
+			expected: adult,
+		},
+		"valid json passing rules": {
+			json:     `{"name":"jh123x","age":20,"address":{"zip":123}}`,
+			rules:    []ttypes.ValTest[parseUser]{isAdult},
+			expected: zipped,
+		},
+		"valid json failing rules": {
+			json:        `{"name":"jh123x","age":10}`,
+			rules:       []ttypes.ValTest[parseUser]{isAdult},
+			expected:    child,
+			expectedErr: errs.WithPath("age", errs.OutOfRangeError.WithParams(map[string]any{"min": 18, "max": 150})),
+		},
+		"invalid json": {
+			json:        `{"name":"jh123x"`,
+			rules:       []ttypes.ValTest[parseUser]{isAdult},
+			expectedErr: errs.InvalidJsonError,
+		},
+		"wrong type": {
+			json:        `{"name":"jh123x","age":"20"}`,
+			expectedErr: errs.WithPath("age", typeErr),
+		},
+		"wrong nested type": {
+			json:        `{"name":"jh123x","age":20,"address":{"zip":"123"}}`,
+			expectedErr: errs.WithPath("address", errs.WithPath("zip", typeErr)),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := ParseJSON(tc.json, tc.rules...)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, val)
+		})
+	}
+}
+
+func TestParseJSON_TopLevelType(t *testing.T) {
+	val, err := ParseJSON[int](`"20"`)
+	assert.Equal(t, 0, val)
+	assert.Equal(t, errs.InvalidTypeError.WithParams(map[string]any{"type": "int"}), err)
+	assert.True(t, errors.Is(err, errs.InvalidTypeError))
+}
+
+func TestParseEmail(t *testing.T) {
+	isCompany := func(email Email) error {
+		if email.Domain != "company.com" {
+			return errs.PatternError
+		}
+		return nil
+	}
+	tests := map[string]struct {
+		email       string
+		rules       []ttypes.ValTest[Email]
+		expected    Email
+		expectedErr error
+	}{
+		"valid email": {
+			email:    "test@test.com",
+			expected: Email{Local: "test", Domain: "test.com"},
+		},
+		"valid email passing rules": {
+			email:    "first.last@company.com",
+			rules:    []ttypes.ValTest[Email]{isCompany},
+			expected: Email{Local: "first.last", Domain: "company.com"},
+		},
+		"valid email failing rules": {
+			email:       "test@test.com",
+			rules:       []ttypes.ValTest[Email]{isCompany},
+			expected:    Email{Local: "test", Domain: "test.com"},
+			expectedErr: errs.PatternError,
+		},
+		"domain literal": {
+			email:    "test@[127.0.0.1]",
+			expected: Email{Local: "test", Domain: "[127.0.0.1]"},
+		},
+		"invalid email": {
+			email:       "test.com",
+			expectedErr: errs.InvalidEmailError,
+		},
+		"email within other text": {
+			email:       "mail test@test.com now",
+			expectedErr: errs.InvalidEmailError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			email, err := ParseEmail(tc.email, tc.rules...)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expected, email)
+		})
+	}
+}
+
+func TestEmail_String(t *testing.T) {
+	assert.Equal(t, "test@test.com", Email{Local: "test", Domain: "test.com"}.String())
+}
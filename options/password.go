@@ -0,0 +1,192 @@
+package options
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Jh123x/go-validate/errs"
+	types "github.com/Jh123x/go-validate/ttypes"
+)
+
+// PasswordClasses is a set of the classes of the characters of a password.
+type PasswordClasses uint8
+
+const (
+	PasswordLower PasswordClasses = 1 << iota
+	PasswordUpper
+	PasswordDigit
+	PasswordSymbol
+)
+
+// minPersonalLength is the number of characters from which a personal value is looked for in a password.
+const minPersonalLength = 3
+
+// PasswordPolicy describes the requirements of a password.
+// The zero value of each field disables the corresponding requirement.
+type PasswordPolicy struct {
+	MinLength  int             // Minimum number of characters.
+	MaxLength  int             // Maximum number of characters.
+	Classes    PasswordClasses // Classes of characters the password must contain, such as PasswordLower|PasswordDigit.
+	MaxRepeats int             // Maximum number of times a character can be repeated in a row.
+	NoCommon   bool            // Rejects the passwords of the embedded list of common passwords.
+	MinEntropy float64         // Minimum entropy in bits, as estimated by PasswordEntropy.
+}
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var (
+	commonPasswordsOnce sync.Once
+	commonPasswords     map[string]struct{}
+)
+
+// IsValidPassword validates that the provided password meets the requirements of the policy.
+// The password must not contain the personal values, such as the username and the email address of the user,
+// or the part of an email address before its '@', ignoring case. Values shorter than 3 characters are ignored.
+// Every unmet requirement is returned, as an errs.Errors if there are several.
+func IsValidPassword(password string, policy PasswordPolicy, personal ...string) types.Validate {
+	return func() error {
+		return validatePassword(password, policy, personal)
+	}
+}
+
+// VIsValidPassword validates that a password meets the requirements of the policy, as IsValidPassword does.
+func VIsValidPassword(policy PasswordPolicy) types.ValTest[string] {
+	return func(password string) error {
+		return validatePassword(password, policy, nil)
+	}
+}
+
+// PasswordEntropy estimates the entropy of the password in bits, as the number of its characters
+// times the base 2 logarithm of the number of characters in the classes it uses.
+// Characters outside of the classes, such as letters without case, count as a class of 100 characters.
+func PasswordEntropy(password string) float64 {
+	var classes PasswordClasses
+	other := false
+	for _, r := range password {
+		class := passwordClass(r)
+		classes |= class
+		other = other || class == 0
+	}
+
+	pool := 0
+	for _, c := range passwordClassSizes {
+		if classes&c.class != 0 {
+			pool += c.size
+		}
+	}
+	if other {
+		pool += 100
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(utf8.RuneCountInString(password)) * math.Log2(float64(pool))
+}
+
+// passwordClassSizes are the names of the classes and their number of characters, in the order they are reported.
+var passwordClassSizes = []struct {
+	class PasswordClasses
+	name  string
+	size  int
+}{
+	{class: PasswordLower, name: "lower", size: 26},
+	{class: PasswordUpper, name: "upper", size: 26},
+	{class: PasswordDigit, name: "digit", size: 10},
+	{class: PasswordSymbol, name: "symbol", size: 33},
+}
+
+func validatePassword(password string, policy PasswordPolicy, personal []string) error {
+	var found errs.Errors
+	length := utf8.RuneCountInString(password)
+	if policy.MinLength > 0 && length < policy.MinLength {
+		found = append(found, errs.PasswordTooShortError.WithParams(map[string]any{"min": policy.MinLength}))
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		found = append(found, errs.PasswordTooLongError.WithParams(map[string]any{"max": policy.MaxLength}))
+	}
+
+	var classes PasswordClasses
+	var repeats, maxRepeats int
+	var last rune
+	for i, r := range password {
+		classes |= passwordClass(r)
+		if i > 0 && r == last {
+			repeats++
+		} else {
+			repeats = 1
+		}
+		if repeats > maxRepeats {
+			maxRepeats = repeats
+		}
+		last = r
+	}
+	for _, c := range passwordClassSizes {
+		if policy.Classes&c.class != 0 && classes&c.class == 0 {
+			found = append(found, errs.PasswordClassError.WithParams(map[string]any{"class": c.name}))
+		}
+	}
+	if policy.MaxRepeats > 0 && maxRepeats > policy.MaxRepeats {
+		found = append(found, errs.PasswordRepeatsError.WithParams(map[string]any{"max": policy.MaxRepeats}))
+	}
+
+	lower := strings.ToLower(password)
+	if containsPersonal(lower, personal) {
+		found = append(found, errs.PasswordPersonalError)
+	}
+	if policy.NoCommon && isCommonPassword(lower) {
+		found = append(found, errs.PasswordCommonError)
+	}
+	if policy.MinEntropy > 0 && PasswordEntropy(password) < policy.MinEntropy {
+		found = append(found, errs.PasswordEntropyError.WithParams(map[string]any{"min": policy.MinEntropy}))
+	}
+	return joinFindings(found, nil)
+}
+
+// passwordClass returns the class of the character, or 0 if it is in none of them.
+func passwordClass(r rune) PasswordClasses {
+	switch {
+	case unicode.IsLower(r):
+		return PasswordLower
+	case unicode.IsUpper(r):
+		return PasswordUpper
+	case unicode.IsDigit(r):
+		return PasswordDigit
+	case unicode.IsPunct(r) || unicode.IsSymbol(r) || r == ' ':
+		return PasswordSymbol
+	default:
+		return 0
+	}
+}
+
+// containsPersonal returns whether the lower case password contains one of the personal values,
+// or the part of an email address before its '@'.
+func containsPersonal(lower string, personal []string) bool {
+	for _, value := range personal {
+		value = strings.ToLower(value)
+		if local, _, ok := strings.Cut(value, "@"); ok && utf8.RuneCountInString(local) >= minPersonalLength && strings.Contains(lower, local) {
+			return true
+		}
+		if utf8.RuneCountInString(value) >= minPersonalLength && strings.Contains(lower, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommonPassword returns whether the lower case password is in the embedded list of common passwords.
+func isCommonPassword(lower string) bool {
+	commonPasswordsOnce.Do(func() {
+		list := strings.Fields(commonPasswordList)
+		commonPasswords = make(map[string]struct{}, len(list))
+		for _, password := range list {
+			commonPasswords[password] = struct{}{}
+		}
+	})
+	_, ok := commonPasswords[lower]
+	return ok
+}
@@ -0,0 +1,153 @@
+package options
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidPassword(t *testing.T) {
+	strict := PasswordPolicy{
+		MinLength:  12,
+		MaxLength:  64,
+		Classes:    PasswordLower | PasswordUpper | PasswordDigit | PasswordSymbol,
+		MaxRepeats: 2,
+		NoCommon:   true,
+		MinEntropy: 60,
+	}
+	tooShort := errs.PasswordTooShortError.WithParams(map[string]any{"min": 12})
+	missing := func(class string) error {
+		return errs.PasswordClassError.WithParams(map[string]any{"class": class})
+	}
+	tests := map[string]struct {
+		password    string
+		policy      PasswordPolicy
+		personal    []string
+		expectedErr error
+	}{
+		"zero policy": {
+			password: "a",
+		},
+		"meets every requirement": {
+			password: "Correct-Horse-9-Battery",
+			policy:   strict,
+			personal: []string{"jh123x", "jh123x@example.com"},
+		},
+		"too short": {
+			password:    "Sh0rt-Pass!",
+			policy:      PasswordPolicy{MinLength: 12},
+			expectedErr: tooShort,
+		},
+		"length counts characters": {
+			password: "пароль-пароль",
+			policy:   PasswordPolicy{MinLength: 12, MaxLength: 13},
+		},
+		"too long": {
+			password:    strings.Repeat("ab", 33),
+			policy:      PasswordPolicy{MaxLength: 64},
+			expectedErr: errs.PasswordTooLongError.WithParams(map[string]any{"max": 64}),
+		},
+		"missing classes": {
+			password:    "lowercase only",
+			policy:      PasswordPolicy{Classes: PasswordLower | PasswordUpper | PasswordDigit | PasswordSymbol},
+			expectedErr: errs.Errors{missing("upper"), missing("digit")},
+		},
+		"unicode classes": {
+			password: "Été№1",
+			policy:   PasswordPolicy{Classes: PasswordLower | PasswordUpper | PasswordDigit | PasswordSymbol},
+		},
+		"repeats": {
+			password:    "abcccd",
+			policy:      PasswordPolicy{MaxRepeats: 2},
+			expectedErr: errs.PasswordRepeatsError.WithParams(map[string]any{"max": 2}),
+		},
+		"repeats within the limit": {
+			password: "aabbcc",
+			policy:   PasswordPolicy{MaxRepeats: 2},
+		},
+		"contains username": {
+			password:    "My-JH123X-Password",
+			personal:    []string{"jh123x"},
+			expectedErr: errs.PasswordPersonalError,
+		},
+		"contains email local part": {
+			password:    "alice.smith2024",
+			personal:    []string{"", "Alice.Smith@example.com"},
+			expectedErr: errs.PasswordPersonalError,
+		},
+		"contains email": {
+			password:    "xx@example.comxx",
+			personal:    []string{"xx@example.com"},
+			expectedErr: errs.PasswordPersonalError,
+		},
+		"short personal values are ignored": {
+			password: "al-is-ok-al",
+			personal: []string{"al", "al@x.io"},
+		},
+		"common": {
+			password:    "P@ssw0rd",
+			policy:      PasswordPolicy{NoCommon: true},
+			expectedErr: errs.PasswordCommonError,
+		},
+		"not common": {
+			password: "P@ssw0rd-but-longer",
+			policy:   PasswordPolicy{NoCommon: true},
+		},
+		"low entropy": {
+			password:    "abcdefgh",
+			policy:      PasswordPolicy{MinEntropy: 40},
+			expectedErr: errs.PasswordEntropyError.WithParams(map[string]any{"min": 40.0}),
+		},
+		"every unmet requirement": {
+			password: "Qwerty123",
+			policy:   strict,
+			personal: []string{"qwerty"},
+			expectedErr: errs.Errors{
+				tooShort,
+				missing("symbol"),
+				errs.PasswordPersonalError,
+				errs.PasswordCommonError,
+				errs.PasswordEntropyError.WithParams(map[string]any{"min": 60.0}),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, IsValidPassword(tc.password, tc.policy, tc.personal...)())
+			if len(tc.personal) == 0 {
+				assert.Equal(t, tc.expectedErr, VIsValidPassword(tc.policy)(tc.password))
+			}
+		})
+	}
+}
+
+func TestPasswordEntropy(t *testing.T) {
+	tests := map[string]struct {
+		password string
+		expected float64
+	}{
+		"empty":        {password: "", expected: 0},
+		"lower":        {password: "abcd", expected: 4 * math.Log2(26)},
+		"lower digits": {password: "abc1", expected: 4 * math.Log2(36)},
+		"all classes":  {password: "aA1!", expected: 4 * math.Log2(95)},
+		"other":        {password: "密码", expected: 2 * math.Log2(100)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, tc.expected, PasswordEntropy(tc.password), 1e-9)
+		})
+	}
+}
+
+func TestCommonPasswords(t *testing.T) {
+	for _, password := range strings.Fields(commonPasswordList) {
+		assert.Equal(t, strings.ToLower(password), password)
+		assert.True(t, isCommonPassword(password))
+	}
+	assert.False(t, isCommonPassword("correct-horse-battery-staple"))
+}
@@ -0,0 +1,100 @@
+package options
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Jh123x/go-validate/internal/scope"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+// VNil validates that the pointer is nil.
+func VNil[T any](val *T) error {
+	if val != nil {
+		return isNilErr
+	}
+	return nil
+}
+
+// VNotNil validates that the pointer is not nil.
+func VNotNil[T any](val *T) error {
+	if val == nil {
+		return isNotNilErr
+	}
+	return nil
+}
+
+// VOptional skips the rules when the pointer is nil, and validates the value it points to with the rules otherwise.
+// The rules are evaluated as VAnd does.
+func VOptional[T any](rules ...ttypes.ValTest[T]) ttypes.ValTest[*T] {
+	return VOptionalNull(deref[T], rules...)
+}
+
+// VRequired returns errs.RequiredError when the pointer is nil, and validates the value it points to with the rules otherwise.
+// The rules are evaluated as VAnd does.
+func VRequired[T any](rules ...ttypes.ValTest[T]) ttypes.ValTest[*T] {
+	return VRequiredNull(deref[T], rules...)
+}
+
+// VOptionalNull is VOptional for values which can be null, such as sql.NullString, whose value and validity are returned by get,
+// such as NullString.
+func VOptionalNull[N, T any](get func(N) (T, bool), rules ...ttypes.ValTest[T]) ttypes.ValTest[N] {
+	return scope.ValueOption[N](nullable[N, T]{name: "VOptional", get: get, rule: VAnd(rules...)})
+}
+
+// VRequiredNull is VRequired for values which can be null, such as sql.NullString, whose value and validity are returned by get,
+// such as NullString.
+func VRequiredNull[N, T any](get func(N) (T, bool), rules ...ttypes.ValTest[T]) ttypes.ValTest[N] {
+	return scope.ValueOption[N](nullable[N, T]{name: "VRequired", get: get, rule: VAnd(rules...), nullErr: requiredErr})
+}
+
+// nullable is the node of VOptionalNull and VRequiredNull, which returns nullErr for null values.
+type nullable[N, T any] struct {
+	name    string
+	get     func(N) (T, bool)
+	rule    ttypes.ValTest[T]
+	nullErr error
+}
+
+func (n nullable[N, T]) Rule() scope.Rule { return scope.Rule{Name: n.name} }
+
+func (n nullable[N, T]) Evaluate(s *scope.Scope, val N) error {
+	inner, ok := n.get(val)
+	if !ok {
+		return n.nullErr
+	}
+	return scope.EvalValue(s, inner, n.rule)
+}
+
+// NullString returns the string of n and whether it is valid, for VOptionalNull and VRequiredNull.
+func NullString(n sql.NullString) (string, bool) { return n.String, n.Valid }
+
+// NullInt64 returns the int64 of n and whether it is valid, for VOptionalNull and VRequiredNull.
+func NullInt64(n sql.NullInt64) (int64, bool) { return n.Int64, n.Valid }
+
+// NullInt32 returns the int32 of n and whether it is valid, for VOptionalNull and VRequiredNull.
+func NullInt32(n sql.NullInt32) (int32, bool) { return n.Int32, n.Valid }
+
+// NullInt16 returns the int16 of n and whether it is valid, for VOptionalNull and VRequiredNull.
+func NullInt16(n sql.NullInt16) (int16, bool) { return n.Int16, n.Valid }
+
+// NullByte returns the byte of n and whether it is valid, for VOptionalNull and VRequiredNull.
+func NullByte(n sql.NullByte) (byte, bool) { return n.Byte, n.Valid }
+
+// NullFloat64 returns the float64 of n and whether it is valid, for VOptionalNull and VRequiredNull.
+func NullFloat64(n sql.NullFloat64) (float64, bool) { return n.Float64, n.Valid }
+
+// NullBool returns the bool of n and whether it is valid, for VOptionalNull and VRequiredNull.
+func NullBool(n sql.NullBool) (bool, bool) { return n.Bool, n.Valid }
+
+// NullTime returns the time of n and whether it is valid, for VOptionalNull and VRequiredNull.
+func NullTime(n sql.NullTime) (time.Time, bool) { return n.Time, n.Valid }
+
+// deref returns the value val points to, and whether val is not nil.
+func deref[T any](val *T) (T, bool) {
+	if val == nil {
+		var zero T
+		return zero, false
+	}
+	return *val, true
+}
@@ -0,0 +1,98 @@
+package options
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVNil(t *testing.T) {
+	email := "test@test.com"
+	assert.Nil(t, VNil[string](nil))
+	assert.Equal(t, errs.IsNilError, VNil(&email))
+	assert.Nil(t, VNotNil(&email))
+	assert.Equal(t, errs.IsNotNilError, VNotNil[string](nil))
+}
+
+func TestVOptional(t *testing.T) {
+	valid, invalid, empty := "test@test.com", "test.com", ""
+	isEmail := func(s string) error { return VIsValidEmail(s) }
+	tests := map[string]struct {
+		option      ttypes.ValTest[*string]
+		value       *string
+		expectedErr error
+	}{
+		"optional nil": {
+			option: VOptional(isEmail),
+		},
+		"optional valid": {
+			option: VOptional(isEmail),
+			value:  &valid,
+		},
+		"optional invalid": {
+			option:      VOptional(isEmail),
+			value:       &invalid,
+			expectedErr: errs.InvalidEmailError,
+		},
+		"optional without rules": {
+			option: VOptional[string](),
+			value:  &empty,
+		},
+		"required nil": {
+			option:      VRequired(isEmail),
+			expectedErr: errs.RequiredError,
+		},
+		"required valid": {
+			option: VRequired(isEmail),
+			value:  &valid,
+		},
+		"required invalid": {
+			option:      VRequired(isEmail),
+			value:       &invalid,
+			expectedErr: errs.InvalidEmailError,
+		},
+		"required empty": {
+			option:      VRequired(VIsNotDefault[string]()),
+			value:       &empty,
+			expectedErr: errs.IsNotDefaultErr,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.option(tc.value))
+		})
+	}
+}
+
+func TestVOptionalNull(t *testing.T) {
+	now := time.Now()
+	rangeErr := errs.OutOfRangeError.WithParams(map[string]any{"min": int64(1), "max": int64(10)})
+	tests := map[string]struct {
+		err         error
+		expectedErr error
+	}{
+		"string null":       {err: VOptionalNull(NullString, VIsStringLength(1, 3))(sql.NullString{})},
+		"string valid":      {err: VOptionalNull(NullString, VIsStringLength(1, 3))(sql.NullString{String: "abc", Valid: true})},
+		"string required":   {err: VRequiredNull(NullString, VIsStringLength(1, 3))(sql.NullString{String: "abc"}), expectedErr: errs.RequiredError},
+		"int64 invalid":     {err: VOptionalNull(NullInt64, VIsInRange[int64](1, 10))(sql.NullInt64{Int64: 11, Valid: true}), expectedErr: rangeErr},
+		"int64 required":    {err: VRequiredNull(NullInt64, VIsInRange[int64](1, 10))(sql.NullInt64{Int64: 5, Valid: true})},
+		"int32":             {err: VRequiredNull(NullInt32, VIsNotDefault[int32]())(sql.NullInt32{Valid: true}), expectedErr: errs.IsNotDefaultErr},
+		"int16":             {err: VRequiredNull(NullInt16, VIsNotDefault[int16]())(sql.NullInt16{Int16: 1, Valid: true})},
+		"byte":              {err: VRequiredNull(NullByte, VIsNotDefault[byte]())(sql.NullByte{}), expectedErr: errs.RequiredError},
+		"float64":           {err: VOptionalNull(NullFloat64, VIsInRange(0.0, 1.0))(sql.NullFloat64{Float64: 0.5, Valid: true})},
+		"bool":              {err: VRequiredNull(NullBool, VIsDefault[bool]())(sql.NullBool{Bool: true, Valid: true}), expectedErr: errs.IsDefaultErr},
+		"time":              {err: VRequiredNull(NullTime, VIsNotDefault[time.Time]())(sql.NullTime{Time: now, Valid: true})},
+		"time null allowed": {err: VOptionalNull(NullTime, VIsNotDefault[time.Time]())(sql.NullTime{})},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.err)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package options
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Jh123x/go-validate/errs"
+	types "github.com/Jh123x/go-validate/ttypes"
+)
+
+// setIndexSize is the number of values from which a set looks values up in a map rather than scanning them.
+const setIndexSize = 16
+
+// OneOf validates that the provided value is one of the allowed values.
+// If it is not, then OneOf returns errs.OneOfError holding the allowed values.
+func OneOf[T comparable](val T, allowed ...T) types.Validate {
+	return func() error {
+		for _, v := range allowed {
+			if v == val {
+				return nil
+			}
+		}
+		return oneOfError(allowed)
+	}
+}
+
+// NoneOf validates that the provided value is none of the disallowed values.
+// If it is one of them, then NoneOf returns errs.NoneOfError.
+func NoneOf[T comparable](val T, disallowed ...T) types.Validate {
+	return func() error {
+		for _, v := range disallowed {
+			if v == val {
+				return noneOfErr
+			}
+		}
+		return nil
+	}
+}
+
+// OneOfFold is OneOf for strings compared ignoring case, as strings.EqualFold does.
+func OneOfFold(val string, allowed ...string) types.Validate {
+	return func() error {
+		for _, v := range allowed {
+			if strings.EqualFold(v, val) {
+				return nil
+			}
+		}
+		return oneOfError(allowed)
+	}
+}
+
+// NoneOfFold is NoneOf for strings compared ignoring case, as strings.EqualFold does.
+func NoneOfFold(val string, disallowed ...string) types.Validate {
+	return func() error {
+		for _, v := range disallowed {
+			if strings.EqualFold(v, val) {
+				return noneOfErr
+			}
+		}
+		return nil
+	}
+}
+
+// VOneOf validates that the value is one of the allowed values, which are looked up in a map when there are many of them.
+func VOneOf[T comparable](allowed ...T) types.ValTest[T] {
+	values := newSet(allowed, nil)
+	err := oneOfError(values.values)
+	return func(val T) error {
+		if values.contains(val) {
+			return nil
+		}
+		return err
+	}
+}
+
+// VNoneOf validates that the value is none of the disallowed values, which are looked up in a map when there are many of them.
+func VNoneOf[T comparable](disallowed ...T) types.ValTest[T] {
+	values := newSet(disallowed, nil)
+	return func(val T) error {
+		if values.contains(val) {
+			return noneOfErr
+		}
+		return nil
+	}
+}
+
+// VOneOfFold is VOneOf for strings compared ignoring case, as strings.EqualFold does.
+func VOneOfFold(allowed ...string) types.ValTest[string] {
+	values := newSet(allowed, foldKey)
+	err := oneOfError(values.values)
+	return func(val string) error {
+		if values.contains(val) {
+			return nil
+		}
+		return err
+	}
+}
+
+// VNoneOfFold is VNoneOf for strings compared ignoring case, as strings.EqualFold does.
+func VNoneOfFold(disallowed ...string) types.ValTest[string] {
+	values := newSet(disallowed, foldKey)
+	return func(val string) error {
+		if values.contains(val) {
+			return noneOfErr
+		}
+		return nil
+	}
+}
+
+// Enum is the set of the values of a typed enum, such as the statuses of an order.
+//
+//	type Status string
+//
+//	var Statuses = options.NewEnum[Status]("active", "suspended")
+type Enum[T comparable] struct {
+	values set[T]
+	err    error
+}
+
+// NewEnum returns the enum of the values.
+func NewEnum[T comparable](values ...T) Enum[T] {
+	set := newSet(values, nil)
+	return Enum[T]{values: set, err: oneOfError(set.values)}
+}
+
+// Values returns the values of the enum, in the order they were given.
+func (e Enum[T]) Values() []T {
+	return append([]T(nil), e.values.values...)
+}
+
+// Contains returns whether val is a value of the enum.
+func (e Enum[T]) Contains(val T) bool {
+	return e.values.contains(val)
+}
+
+// Validate validates that val is a value of the enum, returning errs.OneOfError holding the values of the enum otherwise.
+// It can be used as a value option, such as with VField.
+func (e Enum[T]) Validate(val T) error {
+	if e.values.contains(val) {
+		return nil
+	}
+	if e.err == nil {
+		return oneOfError[T](nil)
+	}
+	return e.err
+}
+
+// IsValid validates that the provided value is a value of the enum, as Validate does.
+func (e Enum[T]) IsValid(val T) types.Validate {
+	return func() error { return e.Validate(val) }
+}
+
+// set is a set of values, which are scanned when there are few of them and looked up in a map otherwise.
+// If key is not nil, the values are compared by their key, which are always looked up in a map.
+type set[T comparable] struct {
+	values []T
+	index  map[T]struct{}
+	key    func(T) T
+}
+
+func newSet[T comparable](values []T, key func(T) T) set[T] {
+	s := set[T]{values: append([]T(nil), values...), key: key}
+	if key == nil && len(values) < setIndexSize {
+		return s
+	}
+	s.index = make(map[T]struct{}, len(values))
+	for _, v := range values {
+		s.index[s.keyOf(v)] = struct{}{}
+	}
+	return s
+}
+
+func (s set[T]) keyOf(val T) T {
+	if s.key == nil {
+		return val
+	}
+	return s.key(val)
+}
+
+func (s set[T]) contains(val T) bool {
+	if s.index != nil {
+		_, ok := s.index[s.keyOf(val)]
+		return ok
+	}
+	for _, v := range s.values {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+// oneOfError returns errs.OneOfError holding the allowed values.
+func oneOfError[T any](allowed []T) error {
+	return errs.OneOfError.WithParams(map[string]any{"allowed": allowed})
+}
+
+// foldKey returns s with each rune replaced by the smallest rune it is equal to ignoring case,
+// so that the strings equal under strings.EqualFold have the same key.
+func foldKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+		return min
+	}, s)
+}
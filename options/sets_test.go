@@ -0,0 +1,115 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOneOf(t *testing.T) {
+	allowedErr := errs.OneOfError.WithParams(map[string]any{"allowed": []string{"active", "suspended"}})
+	tests := map[string]struct {
+		option      ttypes.Validate
+		expectedErr error
+	}{
+		"one of":              {option: OneOf("active", "active", "suspended")},
+		"not one of":          {option: OneOf("deleted", "active", "suspended"), expectedErr: allowedErr},
+		"not one of nothing":  {option: OneOf[int](1), expectedErr: errs.OneOfError.WithParams(map[string]any{"allowed": []int(nil)})},
+		"none of":             {option: NoneOf("user", "admin", "root")},
+		"not none of":         {option: NoneOf("root", "admin", "root"), expectedErr: errs.NoneOfError},
+		"one of fold":         {option: OneOfFold("ACTIVE", "active", "suspended")},
+		"not one of fold":     {option: OneOfFold("deleted", "active", "suspended"), expectedErr: allowedErr},
+		"none of fold":        {option: NoneOfFold("user", "admin", "root")},
+		"not none of fold":    {option: NoneOfFold("Root", "admin", "root"), expectedErr: errs.NoneOfError},
+		"one of with warning": {option: Warn(OneOf(3, 1, 2)), expectedErr: errs.WithSeverity(errs.SeverityWarning, errs.OneOfError.WithParams(map[string]any{"allowed": []int{1, 2}}))},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.option())
+		})
+	}
+}
+
+func TestVOneOf(t *testing.T) {
+	many := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		many = append(many, fmt.Sprintf("Value%d", i))
+	}
+	manyErr := errs.OneOfError.WithParams(map[string]any{"allowed": many})
+	smallErr := errs.OneOfError.WithParams(map[string]any{"allowed": []string{"a", "b"}})
+	tests := map[string]struct {
+		option      ttypes.ValTest[string]
+		value       string
+		expectedErr error
+	}{
+		"small one of":             {option: VOneOf("a", "b"), value: "b"},
+		"small not one of":         {option: VOneOf("a", "b"), value: "B", expectedErr: smallErr},
+		"large one of":             {option: VOneOf(many...), value: "Value42"},
+		"large not one of":         {option: VOneOf(many...), value: "value42", expectedErr: manyErr},
+		"small none of":            {option: VNoneOf("a", "b"), value: "c"},
+		"small not none of":        {option: VNoneOf("a", "b"), value: "a", expectedErr: errs.NoneOfError},
+		"large none of":            {option: VNoneOf(many...), value: "Value100"},
+		"large not none of":        {option: VNoneOf(many...), value: "Value99", expectedErr: errs.NoneOfError},
+		"small one of fold":        {option: VOneOfFold("a", "b"), value: "B"},
+		"small not one of fold":    {option: VOneOfFold("a", "b"), value: "c", expectedErr: smallErr},
+		"large one of fold":        {option: VOneOfFold(many...), value: "VALUE42"},
+		"large not one of fold":    {option: VOneOfFold(many...), value: "VALUE100", expectedErr: manyErr},
+		"none of fold":             {option: VNoneOfFold("admin", "root"), value: "user"},
+		"not none of fold":         {option: VNoneOfFold("admin", "root"), value: "ADMIN", expectedErr: errs.NoneOfError},
+		"fold of special cases":    {option: VOneOfFold("straße", "k"), value: "STRASSE", expectedErr: errs.OneOfError.WithParams(map[string]any{"allowed": []string{"straße", "k"}})},
+		"fold of kelvin sign":      {option: VOneOfFold("straße", "k"), value: "\u212a"},
+		"fold of long s and sigma": {option: VNoneOfFold("\u017f\u03c3"), value: "S\u03a3", expectedErr: errs.NoneOfError},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.option(tc.value))
+		})
+	}
+}
+
+func TestVOneOf_CopiesValues(t *testing.T) {
+	allowed := []int{1, 2}
+	option := VOneOf(allowed...)
+	allowed[0] = 3
+	assert.Nil(t, option(1))
+	assert.Equal(t, errs.OneOfError.WithParams(map[string]any{"allowed": []int{1, 2}}), option(3))
+}
+
+func TestFoldKey(t *testing.T) {
+	words := []string{"Go", "GO", "go", "gO", "\u01c4", "\u01c5", "\u01c6", "K", "k", "\u212a", "\u017f", "s"}
+	for _, a := range words {
+		for _, b := range words {
+			assert.Equal(t, strings.EqualFold(a, b), foldKey(a) == foldKey(b), "%q and %q", a, b)
+		}
+	}
+}
+
+type testStatus string
+
+func TestEnum(t *testing.T) {
+	statuses := NewEnum[testStatus]("active", "suspended")
+	enumErr := errs.OneOfError.WithParams(map[string]any{"allowed": []testStatus{"active", "suspended"}})
+
+	assert.Equal(t, []testStatus{"active", "suspended"}, statuses.Values())
+	statuses.Values()[0] = "deleted"
+	assert.True(t, statuses.Contains("active"))
+	assert.False(t, statuses.Contains("deleted"))
+	assert.Nil(t, statuses.Validate("suspended"))
+	assert.Equal(t, enumErr, statuses.Validate("deleted"))
+	assert.Nil(t, statuses.IsValid("active")())
+	assert.Equal(t, enumErr, statuses.IsValid("Active")())
+
+	type account struct{ Status testStatus }
+	validateAccount := VField("status", func(a account) testStatus { return a.Status }, statuses.Validate)
+	assert.Equal(t, errs.PathError{Pointer: "/status", Err: enumErr}, validateAccount(account{}))
+
+	var empty Enum[int]
+	assert.Empty(t, empty.Values())
+	assert.Equal(t, errs.OneOfError.WithParams(map[string]any{"allowed": []int(nil)}), empty.Validate(0))
+}
@@ -0,0 +1,132 @@
+package options
+
+import (
+	"strconv"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+// VUnique validates that the values of the slice are unique.
+// Each duplicate is reported at its index, such as "/3", by an errs.UniqueError holding the index of its first occurrence.
+func VUnique[T comparable]() ttypes.ValTest[[]T] {
+	return func(val []T) error {
+		return unique(val, func(v T) T { return v })
+	}
+}
+
+// VUniqueBy is VUnique for values compared by their key, such as the ID of a struct.
+func VUniqueBy[T any, K comparable](key func(T) K) ttypes.ValTest[[]T] {
+	return func(val []T) error {
+		return unique(val, key)
+	}
+}
+
+// VSorted validates that the values of the slice are in ascending order.
+// Each value smaller than the one before it is reported at its index by an errs.SortedError holding the index before it.
+func VSorted[T ttypes.Ordered]() ttypes.ValTest[[]T] {
+	return func(val []T) error {
+		return sorted(val, func(a, b T) bool { return a < b })
+	}
+}
+
+// VSortedFunc is VSorted for values ordered by cmp, which returns a negative number when a is before b,
+// a positive number when a is after b and zero otherwise.
+func VSortedFunc[T any](cmp func(a, b T) int) ttypes.ValTest[[]T] {
+	return func(val []T) error {
+		return sorted(val, func(a, b T) bool { return cmp(a, b) < 0 })
+	}
+}
+
+// VSubsetOf validates that each value of the slice is one of the values of the superset.
+// Each other value is reported at its index by an errs.SubsetOfError.
+func VSubsetOf[T comparable](superset ...T) ttypes.ValTest[[]T] {
+	values := newSet(superset, nil)
+	return func(val []T) error {
+		var found errs.Errors
+		for i, v := range val {
+			if !values.contains(v) {
+				found = append(found, errs.WithPath(strconv.Itoa(i), subsetOfErr))
+			}
+		}
+		return joinFindings(found, nil)
+	}
+}
+
+// VSupersetOf validates that the slice contains each of the values of the subset.
+// If it does not, then VSupersetOf returns errs.SupersetOfError holding the missing values.
+func VSupersetOf[T comparable](subset ...T) ttypes.ValTest[[]T] {
+	required := append([]T(nil), subset...)
+	return func(val []T) error {
+		values := set[T]{values: val}
+		if len(val) >= setIndexSize {
+			values = newSet(val, nil)
+		}
+		var missing []T
+		for _, v := range required {
+			if !values.contains(v) {
+				missing = append(missing, v)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		return errs.SupersetOfError.WithParams(map[string]any{"missing": missing})
+	}
+}
+
+// VDisjoint validates that none of the values of the slice is one of the other values.
+// Each of them is reported at its index by an errs.DisjointError.
+func VDisjoint[T comparable](others ...T) ttypes.ValTest[[]T] {
+	values := newSet(others, nil)
+	return func(val []T) error {
+		var found errs.Errors
+		for i, v := range val {
+			if values.contains(v) {
+				found = append(found, errs.WithPath(strconv.Itoa(i), disjointErr))
+			}
+		}
+		return joinFindings(found, nil)
+	}
+}
+
+// VNoNil validates that none of the pointers of the slice is nil.
+// Each nil pointer is reported at its index by an errs.IsNotNilError.
+func VNoNil[T any](val []*T) error {
+	var found errs.Errors
+	for i, v := range val {
+		if v == nil {
+			found = append(found, errs.WithPath(strconv.Itoa(i), isNotNilErr))
+		}
+	}
+	return joinFindings(found, nil)
+}
+
+// unique reports each value of val whose key is the key of a value before it.
+func unique[T any, K comparable](val []T, key func(T) K) error {
+	if len(val) < 2 {
+		return nil
+	}
+	var found errs.Errors
+	first := make(map[K]int, len(val))
+	for i, v := range val {
+		k := key(v)
+		if j, ok := first[k]; ok {
+			found = append(found, errs.WithPath(strconv.Itoa(i), errs.UniqueError.WithParams(map[string]any{"index": j})))
+			continue
+		}
+		first[k] = i
+	}
+	return joinFindings(found, nil)
+}
+
+// sorted reports each value of val which is less than the value before it.
+func sorted[T any](val []T, less func(a, b T) bool) error {
+	var found errs.Errors
+	for i := 1; i < len(val); i++ {
+		if less(val[i], val[i-1]) {
+			found = append(found, errs.WithPath(strconv.Itoa(i), errs.SortedError.WithParams(map[string]any{"index": i - 1})))
+		}
+	}
+	return joinFindings(found, nil)
+}
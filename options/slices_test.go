@@ -0,0 +1,116 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVUnique(t *testing.T) {
+	type item struct {
+		ID   int
+		Name string
+	}
+	duplicate := func(i, first int) error {
+		return errs.PathError{Pointer: fmt.Sprintf("/%d", i), Err: errs.UniqueError.WithParams(map[string]any{"index": first})}
+	}
+	byID := VUniqueBy(func(i item) int { return i.ID })
+	tests := map[string]struct {
+		err         error
+		expectedErr error
+	}{
+		"nil":                {err: VUnique[int]()(nil)},
+		"single":             {err: VUnique[int]()([]int{1})},
+		"unique":             {err: VUnique[string]()([]string{"a", "b", "c"})},
+		"one duplicate":      {err: VUnique[string]()([]string{"a", "b", "a"}), expectedErr: duplicate(2, 0)},
+		"many duplicates":    {err: VUnique[int]()([]int{1, 2, 1, 2, 1}), expectedErr: errs.Errors{duplicate(2, 0), duplicate(3, 1), duplicate(4, 0)}},
+		"unique by key":      {err: byID([]item{{ID: 1, Name: "a"}, {ID: 2, Name: "a"}})},
+		"duplicate by key":   {err: byID([]item{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 1, Name: "c"}}), expectedErr: duplicate(2, 0)},
+		"duplicate ignoring": {err: VUniqueBy(strings.ToLower)([]string{"Go", "go"}), expectedErr: duplicate(1, 0)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.err)
+		})
+	}
+}
+
+func TestVSorted(t *testing.T) {
+	outOfOrder := func(i int) error {
+		return errs.PathError{Pointer: fmt.Sprintf("/%d", i), Err: errs.SortedError.WithParams(map[string]any{"index": i - 1})}
+	}
+	byLength := VSortedFunc(func(a, b string) int { return len(a) - len(b) })
+	tests := map[string]struct {
+		err         error
+		expectedErr error
+	}{
+		"empty":             {err: VSorted[int]()([]int{})},
+		"sorted":            {err: VSorted[int]()([]int{1, 2, 2, 3})},
+		"one out of order":  {err: VSorted[string]()([]string{"a", "c", "b"}), expectedErr: outOfOrder(2)},
+		"many out of order": {err: VSorted[float64]()([]float64{3, 2, 1}), expectedErr: errs.Errors{outOfOrder(1), outOfOrder(2)}},
+		"sorted by func":    {err: byLength([]string{"b", "a", "ab", "abc"})},
+		"unsorted by func":  {err: byLength([]string{"abc", "a"}), expectedErr: outOfOrder(1)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.err)
+		})
+	}
+}
+
+func TestVSets(t *testing.T) {
+	many := make([]int, 0, 50)
+	for i := 0; i < 50; i++ {
+		many = append(many, i)
+	}
+	at := func(i int, err error) error {
+		return errs.PathError{Pointer: fmt.Sprintf("/%d", i), Err: err}
+	}
+	missing := func(values ...int) error {
+		return errs.SupersetOfError.WithParams(map[string]any{"missing": values})
+	}
+	tests := map[string]struct {
+		err         error
+		expectedErr error
+	}{
+		"subset":              {err: VSubsetOf(1, 2, 3)([]int{3, 1})},
+		"empty subset":        {err: VSubsetOf(1, 2, 3)(nil)},
+		"not subset":          {err: VSubsetOf(1, 2, 3)([]int{1, 4, 2, 5}), expectedErr: errs.Errors{at(1, errs.SubsetOfError), at(3, errs.SubsetOfError)}},
+		"large subset":        {err: VSubsetOf(many...)([]int{49, 0})},
+		"large not subset":    {err: VSubsetOf(many...)([]int{50}), expectedErr: at(0, errs.SubsetOfError)},
+		"superset":            {err: VSupersetOf(1, 2)([]int{3, 2, 1})},
+		"empty superset":      {err: VSupersetOf[int]()(nil)},
+		"not superset":        {err: VSupersetOf(1, 2, 3)([]int{2}), expectedErr: missing(1, 3)},
+		"large superset":      {err: VSupersetOf(49, 0)(many)},
+		"large not superset":  {err: VSupersetOf(49, 50)(many), expectedErr: missing(50)},
+		"disjoint":            {err: VDisjoint(1, 2)([]int{3, 4})},
+		"not disjoint":        {err: VDisjoint(1, 2)([]int{2, 3, 1}), expectedErr: errs.Errors{at(0, errs.DisjointError), at(2, errs.DisjointError)}},
+		"large not disjoint":  {err: VDisjoint(many...)([]int{-1, 7}), expectedErr: at(1, errs.DisjointError)},
+		"superset in a field": {err: VField("roles", func(r []int) []int { return r }, VSupersetOf(1))([]int{2}), expectedErr: errs.PathError{Pointer: "/roles", Err: missing(1)}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, tc.err)
+		})
+	}
+}
+
+func TestVNoNil(t *testing.T) {
+	a, b := 1, 2
+	assert.Nil(t, VNoNil[int](nil))
+	assert.Nil(t, VNoNil([]*int{&a, &b}))
+	assert.Equal(t, errs.PathError{Pointer: "/1", Err: errs.IsNotNilError}, VNoNil([]*int{&a, nil}))
+	assert.Equal(t, errs.Errors{
+		errs.PathError{Pointer: "/0", Err: errs.IsNotNilError},
+		errs.PathError{Pointer: "/2", Err: errs.IsNotNilError},
+	}, VNoNil([]*int{nil, &b, nil}))
+
+	tags := VField("tags", func(t []*int) []*int { return t }, VNoNil[int])
+	assert.Equal(t, errs.PathError{Pointer: "/tags/0", Err: errs.IsNotNilError}, tags([]*int{nil}))
+}
@@ -3,6 +3,8 @@ package options
 import (
 	"encoding/json"
 	"net/url"
+	"regexp"
+	"unicode/utf8"
 
 	"github.com/Jh123x/go-validate/errs"
 	types "github.com/Jh123x/go-validate/ttypes"
@@ -10,44 +12,96 @@ import (
 
 // IsValidURI validates that the provided string is a valid URL.
 func IsValidURI(uriStr string) types.Validate {
-	return WithRequire(func() bool {
-		_, err := url.ParseRequestURI(uriStr)
-		return err == nil
-	}, errs.InvalidURIError)
+	return func() error {
+		return isValidURI(uriStr)
+	}
 }
 
 // IsValidJson validates that the provided string is a valid JSON.
 func IsValidJson(jsonStr string) types.Validate {
-	return WithRequire(func() bool {
-		return json.Valid([]byte(jsonStr))
-	}, errs.InvalidJsonError)
+	return func() error {
+		return isValidJson(jsonStr)
+	}
 }
 
 // IsValidEmail validates the provided string is a valid email address.
 func IsValidEmail(email string) types.Validate {
-	return WithRequire(func() bool {
-		return emailRegex.MatchString(email)
-	}, errs.InvalidEmailError)
+	return func() error {
+		return isValidEmail(email)
+	}
 }
 
 func VIsValidURI(uriStr string) error {
+	return isValidURI(uriStr)
+}
+
+func VIsValidJson(jsonStr string) error {
+	return isValidJson(jsonStr)
+}
+
+func VIsValidEmail(email string) error {
+	return isValidEmail(email)
+}
+
+func isValidURI(uriStr string) error {
 	_, err := url.ParseRequestURI(uriStr)
 	if err != nil {
-		return errs.InvalidURIError
+		return invalidURIErr
 	}
 	return nil
 }
 
-func VIsValidJson(jsonStr string) error {
+func isValidJson(jsonStr string) error {
 	if !json.Valid([]byte(jsonStr)) {
-		return errs.InvalidJsonError
+		return invalidJsonErr
 	}
 	return nil
 }
 
-func VIsValidEmail(email string) error {
+func isValidEmail(email string) error {
 	if !emailRegex.MatchString(email) {
-		return errs.InvalidEmailError
+		return invalidEmailErr
 	}
 	return nil
 }
+
+// IsStringLength validates that the number of characters in the provided string is between, inclusive, the start and end values.
+func IsStringLength(str string, start, end int) types.Validate {
+	return func() error {
+		if length := utf8.RuneCountInString(str); length >= start && length <= end {
+			return nil
+		}
+		return boundsError(errs.InvalidLengthError, start, end)
+	}
+}
+
+// Matches validates that the provided string matches the regular expression.
+func Matches(str string, re *regexp.Regexp) types.Validate {
+	return func() error {
+		if re.MatchString(str) {
+			return nil
+		}
+		return patternError(re)
+	}
+}
+
+func VIsStringLength(minLen, maxLen int) types.ValTest[string] {
+	err := boundsError(errs.InvalidLengthError, minLen, maxLen)
+	return func(str string) error {
+		length := utf8.RuneCountInString(str)
+		if length >= minLen && length <= maxLen {
+			return nil
+		}
+		return err
+	}
+}
+
+func VMatches(re *regexp.Regexp) types.ValTest[string] {
+	err := patternError(re)
+	return func(str string) error {
+		if !re.MatchString(str) {
+			return err
+		}
+		return nil
+	}
+}
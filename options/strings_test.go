@@ -1,6 +1,7 @@
 package options
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/Jh123x/go-validate/errs"
@@ -202,3 +203,65 @@ func TestVIsValidEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestIsStringLength(t *testing.T) {
+	tests := map[string]struct {
+		str         string
+		start       int
+		end         int
+		expectedErr error
+	}{
+		"within range": {
+			str:   "abc",
+			start: 1,
+			end:   3,
+		},
+		"counts characters instead of bytes": {
+			str:   "日本語",
+			start: 3,
+			end:   3,
+		},
+		"too short": {
+			str:         "",
+			start:       1,
+			end:         3,
+			expectedErr: errs.InvalidLengthError.WithParams(map[string]any{"min": 1, "max": 3}),
+		},
+		"too long": {
+			str:         "abcd",
+			start:       1,
+			end:         3,
+			expectedErr: errs.InvalidLengthError.WithParams(map[string]any{"min": 1, "max": 3}),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, IsStringLength(tc.str, tc.start, tc.end)())
+			assert.Equal(t, tc.expectedErr, VIsStringLength(tc.start, tc.end)(tc.str))
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	re := regexp.MustCompile(`^[a-z]+$`)
+	tests := map[string]struct {
+		str         string
+		expectedErr error
+	}{
+		"matches": {
+			str: "abc",
+		},
+		"does not match": {
+			str:         "abc1",
+			expectedErr: errs.PatternError.WithParams(map[string]any{"pattern": "^[a-z]+$"}),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, Matches(tc.str, re)())
+			assert.Equal(t, tc.expectedErr, VMatches(re)(tc.str))
+		})
+	}
+}
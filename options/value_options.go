@@ -1,7 +1,10 @@
 package options
 
 import (
+	"reflect"
+
 	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/internal/scope"
 	"github.com/Jh123x/go-validate/ttypes"
 )
 
@@ -16,9 +19,9 @@ func VWithRequire[T any](t ttypes.VTest[T], err error) ttypes.ValTest[T] {
 
 func VIsNotDefault[T comparable]() ttypes.ValTest[T] {
 	var defaultVal T
-	return func(t T) error {
-		if defaultVal == t {
-			return errs.IsNotDefaultErr
+	return func(val T) error {
+		if defaultVal == val {
+			return isNotDefaultErr
 		}
 		return nil
 	}
@@ -28,7 +31,7 @@ func VIsDefault[T comparable]() ttypes.ValTest[T] {
 	var defaultVal T
 	return func(val T) error {
 		if defaultVal != val {
-			return errs.IsDefaultErr
+			return isDefaultErr
 		}
 		return nil
 	}
@@ -38,23 +41,33 @@ func VIsEmpty[T any](val []T) error {
 	if len(val) == 0 {
 		return nil
 	}
-	return errs.IsEmptyError
+	return isEmptyErr
 }
 
 func VIsNotEmpty[T any](val []T) error {
 	if len(val) != 0 {
 		return nil
 	}
-	return errs.IsNotEmptyErr
-
+	return isNotEmptyErr
 }
 
 func VIsLength[T any](minLen, maxLen int) ttypes.ValTest[[]T] {
+	err := boundsError(errs.InvalidLengthError, minLen, maxLen)
 	return func(val []T) error {
 		if len(val) >= minLen && len(val) <= maxLen {
 			return nil
 		}
-		return errs.InvalidLengthError
+		return err
+	}
+}
+
+func VIsInRange[T ttypes.Ordered](min, max T) ttypes.ValTest[T] {
+	err := boundsError(errs.OutOfRangeError, min, max)
+	return func(val T) error {
+		if val >= min && val <= max {
+			return nil
+		}
+		return err
 	}
 }
 
@@ -65,34 +78,254 @@ func VContains[T comparable](elem T) ttypes.ValTest[[]T] {
 				return nil
 			}
 		}
-		return errs.ContainsError
+		return containsErr
 	}
 }
 
 func VOr[T any](options ...ttypes.ValTest[T]) ttypes.ValTest[T] {
-	return func(val T) error {
-		for _, option := range options {
-			if option == nil {
-				continue
-			}
-			if err := option(val); err == nil {
-				return nil
-			}
+	return scope.ValueOption[T](vOr[T](options))
+}
+
+// vOr is the node of VOr.
+type vOr[T any] []ttypes.ValTest[T]
+
+func (vOr[T]) Rule() scope.Rule { return scope.Rule{Name: "VOr"} }
+
+func (o vOr[T]) Evaluate(s *scope.Scope, val T) error {
+	for _, option := range o {
+		if option == nil {
+			continue
+		}
+		if err := scope.EvalValue(s, val, option); errs.OnlyErrors(err) == nil {
+			return nil
 		}
-		return errs.OrError
 	}
+	return orErr
 }
 
 func VAnd[T any](options ...ttypes.ValTest[T]) ttypes.ValTest[T] {
-	return func(val T) error {
-		for _, option := range options {
-			if option == nil {
-				continue
-			}
-			if err := option(val); err != nil {
-				return err
-			}
+	return scope.ValueOption[T](vAnd[T](options))
+}
+
+// vAnd is the node of VAnd.
+type vAnd[T any] []ttypes.ValTest[T]
+
+func (vAnd[T]) Rule() scope.Rule { return scope.Rule{Name: "VAnd"} }
+
+func (a vAnd[T]) Evaluate(s *scope.Scope, val T) error {
+	var findings errs.Errors
+	for _, option := range a {
+		if option == nil {
+			continue
+		}
+		err := scope.EvalValue(s, val, option)
+		if err == nil {
+			continue
+		}
+		if errs.SeverityOf(err) == errs.SeverityError {
+			return joinFindings(findings, err)
 		}
+		findings = append(findings, err)
+	}
+	return joinFindings(findings, nil)
+}
+
+func VExactlyOne[T any](options ...ttypes.ValTest[T]) ttypes.ValTest[T] {
+	return scope.ValueOption[T](vExactlyOne[T](options))
+}
+
+// vExactlyOne is the node of VExactlyOne.
+type vExactlyOne[T any] []ttypes.ValTest[T]
+
+func (vExactlyOne[T]) Rule() scope.Rule { return scope.Rule{Name: "VExactlyOne"} }
+
+func (e vExactlyOne[T]) Evaluate(s *scope.Scope, val T) error {
+	passed := 0
+	for _, option := range e {
+		if option == nil {
+			continue
+		}
+		if err := scope.EvalValue(s, val, option); errs.OnlyErrors(err) == nil {
+			passed++
+		}
+	}
+	if passed != 1 {
+		return exactlyOneErr
+	}
+	return nil
+}
+
+func VNot[T any](option ttypes.ValTest[T], err error) ttypes.ValTest[T] {
+	return scope.ValueOption[T](vNot[T]{option: option, err: err})
+}
+
+// vNot is the node of VNot.
+type vNot[T any] struct {
+	option ttypes.ValTest[T]
+	err    error
+}
+
+func (vNot[T]) Rule() scope.Rule { return scope.Rule{Name: "VNot"} }
+
+func (n vNot[T]) Evaluate(s *scope.Scope, val T) error {
+	if n.option == nil || errs.OnlyErrors(scope.EvalValue(s, val, n.option)) != nil {
+		return nil
+	}
+	return n.err
+}
+
+// VNamed gives the option a name, such as "age-adult".
+// The name is shown in traces, and the errors of the option are wrapped in an errs.NamedError.
+// In a validation with memoization, the option is evaluated once for each name and value.
+func VNamed[T any](name string, option ttypes.ValTest[T]) ttypes.ValTest[T] {
+	return VDescribed(name, "", option)
+}
+
+// VDescribed gives the option a name and a description, as VNamed does.
+func VDescribed[T any](name, description string, option ttypes.ValTest[T]) ttypes.ValTest[T] {
+	return scope.ValueOption[T](vNamed[T]{rule: scope.Rule{Name: name, Description: description, Key: nameKey(name)}, option: option})
+}
+
+// vNamed is the node of VNamed and VDescribed, which is transparent as it reports the option as its rule.
+type vNamed[T any] struct {
+	rule   scope.Rule
+	option ttypes.ValTest[T]
+}
+
+func (vNamed[T]) Rule() scope.Rule { return scope.Rule{} }
+
+func (n vNamed[T]) Evaluate(s *scope.Scope, val T) error {
+	if n.option == nil {
+		return nil
+	}
+	return errs.WithName(n.rule.Name, n.rule.Description, scope.EvalRuleValue(s, n.rule, val, n.option))
+}
+
+// VMemo evaluates the option once for each value in a validation with memoization, such as with
+// the WithMemo method of the validators, returning the same error for every later evaluation.
+// Values which are not comparable, such as slices, are always evaluated.
+func VMemo[T any](option ttypes.ValTest[T]) ttypes.ValTest[T] {
+	return scope.ValueOption[T](vMemo[T]{key: new(memoKey), option: option})
+}
+
+// vMemo is the node of VMemo, which is transparent as it does not change the outcome of the option.
+type vMemo[T any] struct {
+	key    *memoKey
+	option ttypes.ValTest[T]
+}
+
+func (vMemo[T]) Rule() scope.Rule { return scope.Rule{} }
+
+func (m vMemo[T]) Evaluate(s *scope.Scope, val T) error {
+	if m.option == nil {
+		return nil
+	}
+	return scope.EvalRuleValue(s, scope.Rule{Key: m.key}, val, m.option)
+}
+
+// VWithSeverity gives the errors of the option the severity.
+func VWithSeverity[T any](severity errs.Severity, option ttypes.ValTest[T]) ttypes.ValTest[T] {
+	return scope.ValueOption[T](vWithSeverity[T]{name: "VWithSeverity", severity: severity, option: option})
+}
+
+// VWarn turns the errors of the option into warnings, which do not reject the value.
+func VWarn[T any](option ttypes.ValTest[T]) ttypes.ValTest[T] {
+	return scope.ValueOption[T](vWithSeverity[T]{name: "VWarn", severity: errs.SeverityWarning, option: option})
+}
+
+// VInfo turns the errors of the option into infos, which do not reject the value.
+func VInfo[T any](option ttypes.ValTest[T]) ttypes.ValTest[T] {
+	return scope.ValueOption[T](vWithSeverity[T]{name: "VInfo", severity: errs.SeverityInfo, option: option})
+}
+
+// vWithSeverity is the node of VWithSeverity, VWarn and VInfo, named after the function which made it.
+type vWithSeverity[T any] struct {
+	name     string
+	severity errs.Severity
+	option   ttypes.ValTest[T]
+}
+
+func (w vWithSeverity[T]) Rule() scope.Rule { return scope.Rule{Name: w.name} }
+
+func (w vWithSeverity[T]) Evaluate(s *scope.Scope, val T) error {
+	if w.option == nil {
+		return nil
+	}
+	return errs.WithSeverity(w.severity, scope.EvalValue(s, val, w.option))
+}
+
+// VField validates the field returned by get, prefixing the location of its errors with name.
+func VField[T, F any](name string, get func(T) F, option ttypes.ValTest[F]) ttypes.ValTest[T] {
+	return scope.ValueOption[T](field[T, F]{name: name, pointer: "/" + errs.EscapePointerToken(name), get: get, option: option})
+}
+
+// field is the node of VField, which is transparent as it reports the field with its own input.
+type field[T, F any] struct {
+	name    string
+	pointer string
+	get     func(T) F
+	option  ttypes.ValTest[F]
+}
+
+func (field[T, F]) Rule() scope.Rule { return scope.Rule{} }
+
+func (f field[T, F]) Evaluate(s *scope.Scope, val T) error {
+	if f.option == nil {
+		return nil
+	}
+	return errs.WithPath(f.name, scope.EvalRuleValue(s, scope.Rule{Name: f.pointer}, f.get(val), f.option))
+}
+
+// Map converts the value with convert, such as strconv.Atoi, and validates the converted value with the rules,
+// which are evaluated as VAnd does.
+// The errors are wrapped in an errs.StageError named after the type of the converted value, such as "int".
+// If the value cannot be converted, the error of convert is returned as errs.ConvertError.
+func Map[A, B any](convert func(A) (B, error), rules ...ttypes.ValTest[B]) ttypes.ValTest[A] {
+	return scope.ValueOption[A](mapped[A, B]{stage: reflect.TypeOf((*B)(nil)).Elem().String(), convert: convert, rule: VAnd(rules...)})
+}
+
+// mapped is the node of Map.
+type mapped[A, B any] struct {
+	stage   string
+	convert func(A) (B, error)
+	rule    ttypes.ValTest[B]
+}
+
+func (mapped[A, B]) Rule() scope.Rule { return scope.Rule{Name: "Map"} }
+
+func (m mapped[A, B]) Evaluate(s *scope.Scope, val A) error {
+	if m.convert == nil {
+		return nil
+	}
+	converted, err := m.convert(val)
+	if err != nil {
+		return errs.WithStage(m.stage, convertError(err))
+	}
+	return errs.WithStage(m.stage, scope.EvalValue(s, converted, m.rule))
+}
+
+// VAll runs every option and returns all of their errors as errs.Errors.
+func VAll[T any](options ...ttypes.ValTest[T]) ttypes.ValTest[T] {
+	return scope.ValueOption[T](vAll[T](options))
+}
+
+// vAll is the node of VAll.
+type vAll[T any] []ttypes.ValTest[T]
+
+func (vAll[T]) Rule() scope.Rule { return scope.Rule{Name: "VAll"} }
+
+func (a vAll[T]) Evaluate(s *scope.Scope, val T) error {
+	var found errs.Errors
+	for _, option := range a {
+		if option == nil {
+			continue
+		}
+		if err := scope.EvalValue(s, val, option); err != nil {
+			found = append(found, err)
+		}
+	}
+	if len(found) == 0 {
 		return nil
 	}
+	return found
 }
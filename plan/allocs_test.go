@@ -0,0 +1,107 @@
+//go:build !race
+
+package plan
+
+import (
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/Jh123x/go-validate/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// The allocation tests are not built with the race detector, whose instrumentation allocates.
+
+// TestPlan_Allocs tests that the built-in value options do not allocate when validating a value.
+func TestPlan_Allocs(t *testing.T) {
+	notDefault := options.VIsNotDefault[int]()
+	isDefault := options.VIsDefault[int]()
+	inRange := options.VIsInRange(0, 10)
+	tests := map[string]struct {
+		plan    *Plan[int]
+		val     int
+		invalid bool
+	}{
+		"VIsNotDefault": {plan: New(notDefault), val: 1},
+		"VIsDefault":    {plan: New(isDefault), val: 1, invalid: true},
+		"VIsInRange":    {plan: New(inRange), val: 11, invalid: true},
+		"VAnd":          {plan: New(options.VAnd(notDefault, inRange)), val: 1},
+		"VOr":           {plan: New(options.VOr(isDefault, inRange)), val: 11, invalid: true},
+		"VExactlyOne":   {plan: New(options.VExactlyOne(isDefault, inRange)), val: 1},
+		"VNot":          {plan: New(options.VNot(isDefault, errs.NotError)), val: 1},
+		"VAll":          {plan: New(options.VAll(notDefault, inRange)), val: 1},
+		"VWarn":         {plan: New(options.VWarn(notDefault)), val: 1},
+		"VNamed":        {plan: New(options.VNamed("range", inRange)), val: 1},
+		"VWithRequire":  {plan: New(options.VWithRequire(func(v int) bool { return v > 0 }, errs.NotError)), val: 1},
+		"VField":        {plan: New(options.VField("abs", func(v int) int { return -v }, inRange)), val: -1},
+		"Explicit":      {plan: New[int](func(v int) error { return nil }), val: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				if err := tc.plan.Validate(tc.val); (err != nil) != tc.invalid {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			})
+			assert.Zero(t, allocs)
+		})
+	}
+}
+
+// TestPlan_AllocsSlicesAndStrings tests that the built-in options of slices and strings do not allocate.
+func TestPlan_AllocsSlicesAndStrings(t *testing.T) {
+	tags := []string{"member"}
+	slicePlan := New(options.VIsNotEmpty[string], options.VIsLength[string](1, 5), options.VContains("member"))
+	stringPlan := New(
+		options.VIsNotDefault[string](),
+		options.VIsStringLength(1, 20),
+		options.VMatches(regexp.MustCompile(`^[a-z]+@[a-z.]+$`)),
+		options.VIsValidEmail,
+	)
+	validators := map[string]ttypes.Validate{
+		"slices":       slicePlan.ToOption(tags),
+		"empty slices": New(options.VIsEmpty[string]).ToOption(nil),
+		"strings":      stringPlan.ToOption("jh@example.com"),
+	}
+
+	for name, validate := range validators {
+		t.Run(name, func(t *testing.T) {
+			assert.Nil(t, validate())
+			assert.Zero(t, testing.AllocsPerRun(100, func() { _ = validate() }))
+		})
+	}
+}
+
+// TestPlan_AllocsWhileObserved tests that validating does not allocate while a validation with hooks
+// and an explained validation are in flight on other goroutines.
+func TestPlan_AllocsWhileObserved(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	blocking := func(int) error {
+		entered <- struct{}{}
+		<-release
+		return nil
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = validator.NewTyped[int](validator.Lazy).WithOptions(blocking).WithHooks(hooks.Regions()).Validate(1)
+	}()
+	go func() {
+		defer wg.Done()
+		_ = New(blocking).Explain(1)
+	}()
+	<-entered
+	<-entered
+	defer wg.Wait()
+	defer close(release)
+
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = userPlan.Validate(validUser) }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _ = userPlan.ValidateAll(validUser) }))
+}
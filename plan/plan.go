@@ -0,0 +1,73 @@
+// Package plan validates values with rules built once, instead of building the options of a
+// validator from each value. Validating a value with the built-in value options does not allocate.
+package plan
+
+import (
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/trace"
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/Jh123x/go-validate/validator"
+)
+
+// Plan is a list of rules for values of type T, which is built once and is safe for concurrent use.
+// It evaluates the rules with a validator.Typed, in the Lazy mode, or the CollectAll mode for ValidateAll.
+type Plan[T any] struct {
+	lazy *validator.Typed[T]
+	all  *validator.Typed[T]
+}
+
+// New returns the plan evaluating the rules in order.
+// Nil rules are skipped.
+func New[T any](rules ...ttypes.ValTest[T]) *Plan[T] {
+	options := make([]ttypes.ValTest[T], 0, len(rules))
+	for _, rule := range rules {
+		if rule != nil {
+			options = append(options, rule)
+		}
+	}
+	return &Plan[T]{
+		lazy: validator.NewTyped[T](validator.Lazy).WithOptions(options...),
+		all:  validator.NewTyped[T](validator.CollectAll).WithOptions(options...),
+	}
+}
+
+// Validate evaluates the rules on val until the first error, which it returns.
+// Warnings and infos are not returned, and can be retrieved with Check.
+func (p *Plan[T]) Validate(val T) error {
+	if p == nil {
+		return nil
+	}
+	return p.lazy.Validate(val)
+}
+
+// ValidateAll evaluates every rule on val, returning all of the errors as errs.Errors.
+func (p *Plan[T]) ValidateAll(val T) error {
+	if p == nil {
+		return nil
+	}
+	return p.all.Validate(val)
+}
+
+// Check evaluates the rules on val until the first error, returning it along with the warnings and infos found.
+func (p *Plan[T]) Check(val T) errs.Result {
+	if p == nil {
+		return errs.Result{}
+	}
+	return p.lazy.Check(val)
+}
+
+// Explain validates val, returning the trace of the rules evaluated.
+func (p *Plan[T]) Explain(val T) *trace.Node {
+	var lazy *validator.Typed[T]
+	if p != nil {
+		lazy = p.lazy
+	}
+	node := lazy.Explain(val)
+	node.Name = "Plan"
+	return node
+}
+
+// ToOption returns the option validating val with the plan.
+func (p *Plan[T]) ToOption(val T) ttypes.Validate {
+	return func() error { return p.Validate(val) }
+}
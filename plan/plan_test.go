@@ -0,0 +1,109 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	Name  string
+	Email string
+	Age   int
+	Tags  []string
+}
+
+var userPlan = New(
+	options.VField("name", func(u user) string { return u.Name }, options.VIsStringLength(1, 10)),
+	options.VField("email", func(u user) string { return u.Email }, options.VIsValidEmail),
+	options.VField("age", func(u user) int { return u.Age }, options.VIsInRange(18, 150)),
+	options.VField("tags", func(u user) []string { return u.Tags }, options.VAnd(options.VIsNotEmpty[string], options.VContains("member"))),
+	nil,
+)
+
+var validUser = user{Name: "jh", Email: "jh@example.com", Age: 20, Tags: []string{"member"}}
+
+func TestPlan_Validate(t *testing.T) {
+	tests := map[string]struct {
+		val         user
+		expectedErr error
+		expectedAll error
+	}{
+		"valid": {
+			val: validUser,
+		},
+		"first error": {
+			val:         user{Name: "jh", Email: "jh", Tags: []string{"member"}},
+			expectedErr: errs.PathError{Pointer: "/email", Err: errs.InvalidEmailError},
+			expectedAll: errs.Errors{
+				errs.PathError{Pointer: "/email", Err: errs.InvalidEmailError},
+				errs.PathError{Pointer: "/age", Err: errs.OutOfRangeError.WithParams(map[string]any{"min": 18, "max": 150})},
+			},
+		},
+		"single error": {
+			val:         user{Name: "jh", Email: "jh@example.com", Age: 20},
+			expectedErr: errs.PathError{Pointer: "/tags", Err: errs.IsNotEmptyErr},
+			expectedAll: errs.Errors{errs.PathError{Pointer: "/tags", Err: errs.IsNotEmptyErr}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, userPlan.Validate(tc.val))
+			assert.Equal(t, tc.expectedAll, userPlan.ValidateAll(tc.val))
+			assert.Equal(t, tc.expectedErr, userPlan.ToOption(tc.val)())
+		})
+	}
+}
+
+func TestPlan_Check(t *testing.T) {
+	warning := errs.WithSeverity(errs.SeverityWarning, errs.IsDefaultErr)
+	plan := New(options.VWarn(options.VIsDefault[int]()), options.VIsInRange(0, 5), options.VIsNotDefault[int]())
+
+	assert.Nil(t, plan.Validate(1))
+	assert.Nil(t, plan.ValidateAll(1))
+	assert.Equal(t, errs.Result{Warnings: errs.Errors{warning}}, plan.Check(1))
+
+	rangeErr := errs.OutOfRangeError.WithParams(map[string]any{"min": 0, "max": 5})
+	assert.Equal(t, rangeErr, plan.Validate(6))
+	assert.Equal(t, errs.Result{Errors: errs.Errors{rangeErr}, Warnings: errs.Errors{warning}}, plan.Check(6))
+}
+
+func TestPlan_Explain(t *testing.T) {
+	node := userPlan.Explain(user{Name: "jh"})
+	assert.Equal(t, "Plan", node.Name)
+	assert.False(t, node.Passed)
+	assert.Len(t, node.Children, 2)
+	assert.Equal(t, "/name", node.Children[0].Name)
+	assert.Equal(t, "/email", node.Children[1].Name)
+	assert.True(t, strings.HasPrefix(node.String(), "FAIL Plan input="))
+}
+
+func TestNilPlan(t *testing.T) {
+	var plan *Plan[int]
+	assert.Nil(t, plan.Validate(1))
+	assert.Nil(t, plan.ValidateAll(1))
+	assert.Equal(t, errs.Result{}, plan.Check(1))
+	assert.True(t, plan.Explain(1).Passed)
+	assert.Nil(t, plan.ToOption(1)())
+	assert.Nil(t, New[int]().Validate(1))
+}
+
+func BenchmarkPlan_Validate(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = userPlan.Validate(validUser)
+	}
+}
+
+func BenchmarkPlan_ValidateParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = userPlan.Validate(validUser)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+// Package problem renders validation errors as problem details (RFC 9457).
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Jh123x/go-validate/errs"
+)
+
+// ContentType is the media type of problem details.
+const ContentType = "application/problem+json"
+
+// DefaultType is the problem type used when no other type is set.
+const DefaultType = "about:blank"
+
+// Details is a problem details document, with the validation errors in the "errors" extension member.
+type Details struct {
+	Type     string  `json:"type"`
+	Title    string  `json:"title,omitempty"`
+	Status   int     `json:"status,omitempty"`
+	Detail   string  `json:"detail,omitempty"`
+	Instance string  `json:"instance,omitempty"`
+	Errors   []Error `json:"errors,omitempty"`
+}
+
+// Error is a validation error in the "errors" extension member.
+// Pointer is a JSON Pointer into the request content such as "/name", as in errs.PathError, and is empty for the content itself.
+// Errors of query parameters and headers set Parameter or Header instead.
+// Severity is only set for warnings and infos, Name only for errors of named rules,
+// and Stage only for errors of stages converting the value, set to the stage which failed.
+type Error struct {
+	Pointer   string         `json:"pointer,omitempty"`
+	Parameter string         `json:"parameter,omitempty"`
+	Header    string         `json:"header,omitempty"`
+	Severity  string         `json:"severity,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Stage     string         `json:"stage,omitempty"`
+	Code      string         `json:"code,omitempty"`
+	Rule      string         `json:"rule,omitempty"`
+	Message   string         `json:"message"`
+	Params    map[string]any `json:"params,omitempty"`
+}
+
+// Describer is implemented by errors which describe themselves as a problem Error,
+// such as errors found outside the request content.
+type Describer interface {
+	ProblemError() Error
+}
+
+// Option sets a member of the problem details.
+type Option func(*Details)
+
+// WithType sets the URI identifying the problem type.
+func WithType(uri string) Option {
+	return func(d *Details) { d.Type = uri }
+}
+
+// WithTitle sets the summary of the problem type, which is the status text by default.
+func WithTitle(title string) Option {
+	return func(d *Details) { d.Title = title }
+}
+
+// WithDetail sets the explanation of this occurrence of the problem.
+func WithDetail(detail string) Option {
+	return func(d *Details) { d.Detail = detail }
+}
+
+// WithInstance sets the URI identifying this occurrence of the problem.
+func WithInstance(uri string) Option {
+	return func(d *Details) { d.Instance = uri }
+}
+
+// New returns the problem details with the given status listing every error in err.
+// Aggregated errors (errs.Errors) are flattened, errs.PathError sets the pointer and
+// errs.ValidateError sets the code, rule, message and params.
+func New(status int, err error, opts ...Option) *Details {
+	details := &Details{Type: DefaultType, Title: http.StatusText(status), Status: status}
+	for _, item := range errs.Flatten(err) {
+		details.Errors = append(details.Errors, FromError(item))
+	}
+	for _, opt := range opts {
+		opt(details)
+	}
+	return details
+}
+
+// FromError returns the problem Error describing err, with the members of errs.Describe.
+func FromError(err error) Error {
+	var describer Describer
+	if errors.As(err, &describer) {
+		return describer.ProblemError()
+	}
+
+	d := errs.Describe(err)
+	return Error{
+		Pointer:  d.Pointer,
+		Severity: d.Severity,
+		Name:     d.Name,
+		Stage:    d.Stage,
+		Code:     d.Code,
+		Rule:     d.Rule,
+		Message:  d.Message,
+		Params:   d.Params,
+	}
+}
+
+// Write writes the problem details as a response with their status.
+func Write(w http.ResponseWriter, details *Details) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(details.Status)
+	_ = json.NewEncoder(w).Encode(details)
+}
@@ -0,0 +1,140 @@
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type headerError struct{}
+
+func (headerError) Error() string { return "missing header" }
+
+func (headerError) ProblemError() Error {
+	return Error{Header: "X-Trace-Id", Message: "missing header"}
+}
+
+func TestNew(t *testing.T) {
+	rangeErr := errs.OutOfRangeError.WithParams(map[string]any{"min": 18, "max": 150})
+	tests := map[string]struct {
+		err      error
+		opts     []Option
+		expected *Details
+	}{
+		"nil error": {
+			err:      nil,
+			expected: &Details{Type: DefaultType, Title: "Bad Request", Status: http.StatusBadRequest},
+		},
+		"validate error": {
+			err: errs.IsNotEmptyErr,
+			expected: &Details{
+				Type:   DefaultType,
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Errors: []Error{{Code: errs.CodeIsNotEmpty, Rule: "IsNotEmpty", Message: "value is empty"}},
+			},
+		},
+		"aggregated errors": {
+			err: errs.Errors{
+				errs.PathError{Pointer: "/age", Err: rangeErr},
+				errs.Errors{errs.PathError{Pointer: "/name", Err: fmt.Errorf("custom error")}},
+				headerError{},
+			},
+			expected: &Details{
+				Type:   DefaultType,
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Errors: []Error{
+					{Pointer: "/age", Code: errs.CodeOutOfRange, Rule: "IsInRange", Message: "value is out of range", Params: map[string]any{"min": 18, "max": 150}},
+					{Pointer: "/name", Message: "custom error"},
+					{Header: "X-Trace-Id", Message: "missing header"},
+				},
+			},
+		},
+		"warnings": {
+			err: errs.Errors{
+				errs.WithPath("nickname", errs.WithSeverity(errs.SeverityWarning, errs.IsEmptyError)),
+				errs.WithSeverity(errs.SeverityInfo, fmt.Errorf("custom info")),
+			},
+			expected: &Details{
+				Type:   DefaultType,
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Errors: []Error{
+					{Pointer: "/nickname", Severity: "warning", Code: errs.CodeIsEmpty, Rule: "IsEmpty", Message: "value is not empty"},
+					{Severity: "info", Message: "custom info"},
+				},
+			},
+		},
+		"named": {
+			err: errs.WithPath("age", errs.WithName("age-adult", "", fmt.Errorf("must be an adult"))),
+			expected: &Details{
+				Type:   DefaultType,
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Errors: []Error{{Pointer: "/age", Name: "age-adult", Message: "must be an adult"}},
+			},
+		},
+		"staged": {
+			err: errs.WithPath("age", errs.WithStage("int", errs.WithStage("celsius", fmt.Errorf("too cold")))),
+			expected: &Details{
+				Type:   DefaultType,
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Errors: []Error{{Pointer: "/age", Stage: "celsius", Message: "too cold"}},
+			},
+		},
+		"options": {
+			err: fmt.Errorf("wrapped: %w", errs.PathError{Pointer: "/a", Err: errs.IsEmptyError}),
+			opts: []Option{
+				WithType("https://example.com/problems/validation"),
+				WithTitle("Validation failed"),
+				WithDetail("the request is invalid"),
+				WithInstance("/requests/1"),
+			},
+			expected: &Details{
+				Type:     "https://example.com/problems/validation",
+				Title:    "Validation failed",
+				Status:   http.StatusBadRequest,
+				Detail:   "the request is invalid",
+				Instance: "/requests/1",
+				Errors:   []Error{{Pointer: "/a", Code: errs.CodeIsEmpty, Rule: "IsEmpty", Message: "value is not empty"}},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, New(http.StatusBadRequest, tc.err, tc.opts...))
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Write(rec, New(http.StatusUnprocessableEntity, errs.Errors{
+		errs.PathError{Pointer: "/age", Err: errs.OutOfRangeError.WithParams(map[string]any{"min": 18})},
+	}))
+
+	res := rec.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusUnprocessableEntity, res.StatusCode)
+	assert.Equal(t, ContentType, res.Header.Get("Content-Type"))
+
+	var body map[string]any
+	require.Nil(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(t, map[string]any{
+		"type":   "about:blank",
+		"title":  "Unprocessable Entity",
+		"status": 422.0,
+		"errors": []any{
+			map[string]any{"pointer": "/age", "code": "out_of_range", "rule": "IsInRange", "message": "value is out of range", "params": map[string]any{"min": 18.0}},
+		},
+	}, body)
+}
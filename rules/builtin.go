@@ -0,0 +1,341 @@
+package rules
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/schema"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+var (
+	minLengthParam = ParamSpec{Name: "min", Type: IntParam, Description: "the minimum length"}
+	maxLengthParam = ParamSpec{Name: "max", Type: IntParam, Description: "the maximum length"}
+	minParam       = ParamSpec{Name: "min", Type: NumberParam, Description: "the minimum value"}
+	maxParam       = ParamSpec{Name: "max", Type: NumberParam, Description: "the maximum value"}
+)
+
+// registerBuiltins registers the built-in rules.
+// Every rule except "required" accepts a nil value, so that absent fields are optional.
+func registerBuiltins(r *Registry) *Registry {
+	return r.
+		MustRegister(Rule{
+			Name:        "required",
+			Description: "The value must be present and not empty.",
+			Factory:     required,
+			Schema:      requiredSchema,
+		}).
+		MustRegister(Rule{
+			Name:        "not_empty",
+			Description: "The string, list or map must not be empty.",
+			Factory:     notEmpty,
+			Schema:      notEmptySchema,
+		}).
+		MustRegister(Rule{
+			Name:        "length",
+			Description: "The length of the string, list or map must be within the bounds.",
+			Params:      []ParamSpec{minLengthParam, maxLengthParam},
+			Factory:     length,
+			Schema:      lengthSchema,
+		}).
+		MustRegister(Rule{
+			Name:        "range",
+			Description: "The number must be within the bounds.",
+			Params:      []ParamSpec{minParam, maxParam},
+			Factory:     numberRange,
+			Schema:      rangeSchema,
+		}).
+		MustRegister(Rule{
+			Name:        "allowed",
+			Description: "The value must be one of the values.",
+			Params:      []ParamSpec{{Name: "values", Type: ListParam, Required: true, Description: "the allowed values"}},
+			Factory:     allowed,
+			Schema:      allowedSchema,
+		}).
+		MustRegister(Rule{
+			Name:        "contains",
+			Description: "The string must contain the value as a substring, or the list must contain the value.",
+			Params:      []ParamSpec{{Name: "value", Type: AnyParam, Required: true, Description: "the value to look for"}},
+			Factory:     contains,
+			Schema:      containsSchema,
+		}).
+		MustRegister(Rule{
+			Name:        "pattern",
+			Description: "The string must match the regular expression.",
+			Params:      []ParamSpec{{Name: "pattern", Type: StringParam, Required: true, Description: "the regular expression"}},
+			Factory:     pattern,
+			Schema:      patternSchema,
+		}).
+		MustRegister(Rule{
+			Name:        "email",
+			Description: "The string must be an email address.",
+			Factory:     stringRule(options.VIsValidEmail),
+			Schema:      func(Params) *schema.Builder { return schema.String().Format("email") },
+		}).
+		MustRegister(Rule{
+			Name:        "uri",
+			Description: "The string must be a URI.",
+			Factory:     stringRule(options.VIsValidURI),
+			Schema:      func(Params) *schema.Builder { return schema.String().Format("uri") },
+		}).
+		MustRegister(Rule{
+			Name:        "json",
+			Description: "The string must be valid JSON.",
+			Factory:     stringRule(options.VIsValidJson),
+			Schema:      func(Params) *schema.Builder { return schema.String().ContentMediaType("application/json") },
+		})
+}
+
+func required(Params) (ttypes.ValTest[any], error) {
+	return func(val any) error {
+		if val == nil {
+			return errs.IsNotEmptyErr
+		}
+		if size, ok := sizeOf(val); ok && size == 0 {
+			return errs.IsNotEmptyErr
+		}
+		return nil
+	}, nil
+}
+
+func notEmpty(Params) (ttypes.ValTest[any], error) {
+	return optional(func(val any) error {
+		size, ok := sizeOf(val)
+		if !ok {
+			return errs.InvalidTypeError
+		}
+		if size == 0 {
+			return errs.IsNotEmptyErr
+		}
+		return nil
+	}), nil
+}
+
+func length(params Params) (ttypes.ValTest[any], error) {
+	minLen, maxLen, err := intBounds(params)
+	if err != nil {
+		return nil, err
+	}
+	lengthErr := boundsError(errs.InvalidLengthError, params, minLen, maxLen)
+	return optional(func(val any) error {
+		size, ok := sizeOf(val)
+		if !ok {
+			return errs.InvalidTypeError
+		}
+		if size < minLen || size > maxLen {
+			return lengthErr
+		}
+		return nil
+	}), nil
+}
+
+func numberRange(params Params) (ttypes.ValTest[any], error) {
+	minVal, maxVal := math.Inf(-1), math.Inf(1)
+	var err error
+	if params.Has("min") {
+		if minVal, err = params.Float("min"); err != nil {
+			return nil, err
+		}
+	}
+	if params.Has("max") {
+		if maxVal, err = params.Float("max"); err != nil {
+			return nil, err
+		}
+	}
+	if err = params.bounds(minVal, maxVal); err != nil {
+		return nil, err
+	}
+	rangeErr := boundsError(errs.OutOfRangeError, params, minVal, maxVal)
+	return optional(func(val any) error {
+		num, ok := toFloat(val)
+		if !ok {
+			return errs.InvalidTypeError
+		}
+		if num < minVal || num > maxVal {
+			return rangeErr
+		}
+		return nil
+	}), nil
+}
+
+func allowed(params Params) (ttypes.ValTest[any], error) {
+	values, err := params.List("values")
+	if err != nil {
+		return nil, err
+	}
+	return optional(func(val any) error {
+		for _, allowedVal := range values {
+			if equal(val, allowedVal) {
+				return nil
+			}
+		}
+		return errs.ContainsError
+	}), nil
+}
+
+func contains(params Params) (ttypes.ValTest[any], error) {
+	elem := params["value"]
+	return optional(func(val any) error {
+		if str, ok := val.(string); ok {
+			substr, ok := elem.(string)
+			if !ok || !strings.Contains(str, substr) {
+				return errs.ContainsError
+			}
+			return nil
+		}
+		list, ok := val.([]any)
+		if !ok {
+			return errs.InvalidTypeError
+		}
+		for _, item := range list {
+			if equal(item, elem) {
+				return nil
+			}
+		}
+		return errs.ContainsError
+	}), nil
+}
+
+func pattern(params Params) (ttypes.ValTest[any], error) {
+	str, err := params.String("pattern")
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(str)
+	if err != nil {
+		return nil, params.invalid("pattern", "a valid regular expression")
+	}
+	return stringRule(options.VMatches(re))(params)
+}
+
+// requiredSchema describes a value which is not null and not empty. The field is required by Validator.Schema,
+// as the rule rejects absent values.
+func requiredSchema(Params) *schema.Builder {
+	return schema.Not(schema.Null()).MinLength(1).MinItems(1).MinProperties(1)
+}
+
+func notEmptySchema(Params) *schema.Builder {
+	return schema.AnyOf(schema.String().MinLength(1), schema.Array(nil).MinItems(1), schema.Object().MinProperties(1))
+}
+
+func lengthSchema(params Params) *schema.Builder {
+	str, arr, obj := schema.String(), schema.Array(nil), schema.Object()
+	if minLen, err := params.Int("min"); err == nil {
+		str, arr, obj = str.MinLength(minLen), arr.MinItems(minLen), obj.MinProperties(minLen)
+	}
+	if maxLen, err := params.Int("max"); err == nil {
+		str, arr, obj = str.MaxLength(maxLen), arr.MaxItems(maxLen), obj.MaxProperties(maxLen)
+	}
+	return schema.AnyOf(str, arr, obj)
+}
+
+func rangeSchema(params Params) *schema.Builder {
+	num := schema.Number()
+	if minVal, err := params.Float("min"); err == nil {
+		num = num.Minimum(minVal)
+	}
+	if maxVal, err := params.Float("max"); err == nil {
+		num = num.Maximum(maxVal)
+	}
+	return num
+}
+
+func allowedSchema(params Params) *schema.Builder {
+	values, _ := params.List("values")
+	return schema.Any().Enum(values...)
+}
+
+func containsSchema(params Params) *schema.Builder {
+	elem := params["value"]
+	list := schema.Array(nil).Contains(schema.Any().Const(elem))
+	if substr, ok := elem.(string); ok {
+		return schema.AnyOf(schema.String().Pattern(regexp.QuoteMeta(substr)), list)
+	}
+	return list
+}
+
+func patternSchema(params Params) *schema.Builder {
+	str, _ := params.String("pattern")
+	return schema.String().Pattern(str)
+}
+
+// stringRule returns a factory for a rule without parameters which only accepts strings.
+func stringRule(test ttypes.ValTest[string]) Factory {
+	return func(Params) (ttypes.ValTest[any], error) {
+		return optional(func(val any) error {
+			str, ok := val.(string)
+			if !ok {
+				return errs.InvalidTypeError
+			}
+			return test(str)
+		}), nil
+	}
+}
+
+// optional skips the test for nil values.
+func optional(test ttypes.ValTest[any]) ttypes.ValTest[any] {
+	return func(val any) error {
+		if val == nil {
+			return nil
+		}
+		return test(val)
+	}
+}
+
+// boundsError returns err holding the bounds whose "min" and "max" parameters are set.
+func boundsError[T any](err errs.ValidateError, params Params, minVal, maxVal T) error {
+	bounds := make(map[string]any, 2)
+	if params.Has("min") {
+		bounds["min"] = minVal
+	}
+	if params.Has("max") {
+		bounds["max"] = maxVal
+	}
+	return err.WithParams(bounds)
+}
+
+// intBounds returns the "min" and "max" parameters, which default to no bound, and must not be reversed.
+func intBounds(params Params) (int, int, error) {
+	minVal, maxVal := 0, math.MaxInt
+	var err error
+	if params.Has("min") {
+		if minVal, err = params.Int("min"); err != nil {
+			return 0, 0, err
+		}
+	}
+	if params.Has("max") {
+		if maxVal, err = params.Int("max"); err != nil {
+			return 0, 0, err
+		}
+	}
+	if err = params.bounds(float64(minVal), float64(maxVal)); err != nil {
+		return 0, 0, err
+	}
+	return minVal, maxVal, nil
+}
+
+// sizeOf returns the number of characters of a string, or the length of a slice, array or map.
+func sizeOf(val any) (int, bool) {
+	if str, ok := val.(string); ok {
+		return utf8.RuneCountInString(str), true
+	}
+	switch v := reflect.ValueOf(val); v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// equal compares two decoded values, treating numbers of different types as equal if their values are.
+func equal(a, b any) bool {
+	if numA, ok := toFloat(a); ok {
+		numB, ok := toFloat(b)
+		return ok && numA == numB
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,101 @@
+package rules
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltins(t *testing.T) {
+	tests := map[string]struct {
+		rule        string
+		params      Params
+		value       any
+		expectedErr error
+	}{
+		"required success":          {rule: "required", value: "a"},
+		"required number success":   {rule: "required", value: 0},
+		"required nil":              {rule: "required", value: nil, expectedErr: errs.IsNotEmptyErr},
+		"required empty string":     {rule: "required", value: "", expectedErr: errs.IsNotEmptyErr},
+		"required empty list":       {rule: "required", value: []any{}, expectedErr: errs.IsNotEmptyErr},
+		"not empty success":         {rule: "not_empty", value: "a"},
+		"not empty nil is skipped":  {rule: "not_empty", value: nil},
+		"not empty fail":            {rule: "not_empty", value: []any{}, expectedErr: errs.IsNotEmptyErr},
+		"not empty wrong type":      {rule: "not_empty", value: 1, expectedErr: errs.InvalidTypeError},
+		"contains substring":        {rule: "contains", params: Params{"value": "@"}, value: "a@b"},
+		"contains substring fail":   {rule: "contains", params: Params{"value": "@"}, value: "ab", expectedErr: errs.ContainsError},
+		"contains non string value": {rule: "contains", params: Params{"value": 1}, value: "1", expectedErr: errs.ContainsError},
+		"contains element":          {rule: "contains", params: Params{"value": 1}, value: []any{1.0, 2.0}},
+		"contains element fail":     {rule: "contains", params: Params{"value": 3}, value: []any{1, 2}, expectedErr: errs.ContainsError},
+		"contains wrong type":       {rule: "contains", params: Params{"value": 3}, value: 3, expectedErr: errs.InvalidTypeError},
+		"length success":            {rule: "length", params: Params{"min": 1, "max": 3}, value: "abc"},
+		"length list success":       {rule: "length", params: Params{"max": 1}, value: []any{1}},
+		"length map success":        {rule: "length", params: Params{"min": 1}, value: map[string]any{"a": 1}},
+		"length too long":           {rule: "length", params: Params{"max": 2}, value: "abc", expectedErr: errs.InvalidLengthError.WithParams(map[string]any{"max": 2})},
+		"length too short":          {rule: "length", params: Params{"min": 4}, value: "abc", expectedErr: errs.InvalidLengthError.WithParams(map[string]any{"min": 4})},
+		"length wrong type":         {rule: "length", params: Params{"min": 1}, value: 1, expectedErr: errs.InvalidTypeError},
+		"length nil is skipped":     {rule: "length", params: Params{"min": 1}, value: nil},
+		"range success":             {rule: "range", params: Params{"min": 18, "max": 65}, value: 20},
+		"range float success":       {rule: "range", params: Params{"max": 1.5}, value: 1.5},
+		"range too small":           {rule: "range", params: Params{"min": 18}, value: 17, expectedErr: errs.OutOfRangeError.WithParams(map[string]any{"min": 18.0})},
+		"range too large":           {rule: "range", params: Params{"max": 1.5}, value: 2, expectedErr: errs.OutOfRangeError.WithParams(map[string]any{"max": 1.5})},
+		"range wrong type":          {rule: "range", params: Params{"min": 1}, value: "1", expectedErr: errs.InvalidTypeError},
+		"allowed success":           {rule: "allowed", params: Params{"values": []any{"SG", "MY"}}, value: "MY"},
+		"allowed number success":    {rule: "allowed", params: Params{"values": []any{1, 2}}, value: 2.0},
+		"allowed fail":              {rule: "allowed", params: Params{"values": []any{"SG", "MY"}}, value: "US", expectedErr: errs.ContainsError},
+		"pattern success":           {rule: "pattern", params: Params{"pattern": "^[0-9]+$"}, value: "123"},
+		"pattern fail":              {rule: "pattern", params: Params{"pattern": "^[0-9]+$"}, value: "12a", expectedErr: errs.PatternError.WithParams(map[string]any{"pattern": "^[0-9]+$"})},
+		"pattern wrong type":        {rule: "pattern", params: Params{"pattern": "^[0-9]+$"}, value: 123, expectedErr: errs.InvalidTypeError},
+		"email success":             {rule: "email", value: "a@b.c"},
+		"email fail":                {rule: "email", value: "a", expectedErr: errs.InvalidEmailError},
+		"uri success":               {rule: "uri", value: "https://github.com"},
+		"uri fail":                  {rule: "uri", value: "github", expectedErr: errs.InvalidURIError},
+		"json success":              {rule: "json", value: `{"a":1}`},
+		"json fail":                 {rule: "json", value: `{"a":1`, expectedErr: errs.InvalidJsonError},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			test, err := Default.Build(tc.rule, tc.params)
+			require.Nil(t, err)
+			assert.Equal(t, tc.expectedErr, test(tc.value))
+		})
+	}
+}
+
+func TestBuiltins_InvalidParams(t *testing.T) {
+	tests := map[string]struct {
+		rule   string
+		params Params
+	}{
+		"length min not an integer": {rule: "length", params: Params{"min": 1.5}},
+		"length max not a number":   {rule: "length", params: Params{"max": "2"}},
+		"range min not a number":    {rule: "range", params: Params{"min": "1"}},
+		"range max not a number":    {rule: "range", params: Params{"max": "1"}},
+		"length min above max":      {rule: "length", params: Params{"min": 5, "max": 1}},
+		"length max out of range":   {rule: "length", params: Params{"max": 1e300}},
+		"length min out of range":   {rule: "length", params: Params{"min": -1e300}},
+		"length max infinite":       {rule: "length", params: Params{"max": math.Inf(1)}},
+		"range min above max":       {rule: "range", params: Params{"min": 2.5, "max": 1}},
+		"allowed missing values":    {rule: "allowed", params: Params{}},
+		"pattern missing pattern":   {rule: "pattern", params: Params{}},
+		"pattern invalid regex":     {rule: "pattern", params: Params{"pattern": "("}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			test, err := Default.Build(tc.rule, tc.params)
+			assert.Nil(t, test)
+			assert.ErrorIs(t, err, ErrInvalidParams)
+		})
+	}
+}
+
+func TestRegistry_UnknownRule(t *testing.T) {
+	test, err := NewRegistry().Build("email", nil)
+	assert.Nil(t, test)
+	assert.ErrorIs(t, err, ErrUnknownRule)
+}
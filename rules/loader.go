@@ -0,0 +1,238 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/internal/scope"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/schema"
+	"github.com/Jh123x/go-validate/trace"
+	"github.com/Jh123x/go-validate/ttypes"
+	"gopkg.in/yaml.v3"
+)
+
+// Definition describes the rules of each field.
+type Definition struct {
+	Fields map[string][]RuleDefinition `json:"fields" yaml:"fields"`
+}
+
+// RuleDefinition describes a rule by its registered name and parameters.
+// If Message is set, it replaces the error returned by the rule.
+// If Severity is set to "warning" or "info", the errors of the rule do not reject the value.
+type RuleDefinition struct {
+	Rule     string        `json:"rule" yaml:"rule"`
+	Params   Params        `json:"params,omitempty" yaml:"params,omitempty"`
+	Message  string        `json:"message,omitempty" yaml:"message,omitempty"`
+	Severity errs.Severity `json:"severity,omitempty" yaml:"severity,omitempty"`
+}
+
+// Validator validates the fields of a map against the rules loaded from a definition.
+type Validator struct {
+	fields []field
+}
+
+type field struct {
+	name     string
+	pointer  string
+	test     ttypes.ValTest[any]
+	schemas  []func() *schema.Builder
+	required bool
+}
+
+// LoadJSON loads a definition written in JSON.
+// If registry is nil, the Default registry is used.
+func LoadJSON(data []byte, registry *Registry) (*Validator, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var def Definition
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("invalid rule definition: %w", err)
+	}
+	return Load(def, registry)
+}
+
+// LoadYAML loads a definition written in YAML.
+// If registry is nil, the Default registry is used.
+func LoadYAML(data []byte, registry *Registry) (*Validator, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var def Definition
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("invalid rule definition: %w", err)
+	}
+	return Load(def, registry)
+}
+
+// Load builds a validator from a definition.
+// If registry is nil, the Default registry is used.
+func Load(def Definition, registry *Registry) (*Validator, error) {
+	if registry == nil {
+		registry = Default
+	}
+
+	names := make([]string, 0, len(def.Fields))
+	for name := range def.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	v := &Validator{fields: make([]field, 0, len(names))}
+	for _, name := range names {
+		f := field{name: name, pointer: "/" + errs.EscapePointerToken(name)}
+		tests := make([]ttypes.ValTest[any], 0, len(def.Fields[name]))
+		for i, ruleDef := range def.Fields[name] {
+			test, err := registry.Build(ruleDef.Rule, ruleDef.Params)
+			if err != nil {
+				return nil, fmt.Errorf("field %q rule %d: %w", name, i, err)
+			}
+			if ruleDef.Severity == errs.SeverityError {
+				f.describe(registry, ruleDef, test)
+			}
+			if ruleDef.Message != "" {
+				test = withMessage(test, ruleDef.Rule, ruleDef.Message)
+			}
+			if ruleDef.Severity != errs.SeverityError {
+				test = options.VWithSeverity(ruleDef.Severity, test)
+			}
+			tests = append(tests, test)
+		}
+		f.test = options.VAnd(tests...)
+		v.fields = append(v.fields, f)
+	}
+	return v, nil
+}
+
+// describe adds the schema of the rule to the field, which is required if the rule rejects absent values.
+func (f *field) describe(registry *Registry, ruleDef RuleDefinition, test ttypes.ValTest[any]) {
+	if rule, ok := registry.Lookup(ruleDef.Rule); ok && rule.Schema != nil {
+		params := ruleDef.Params
+		f.schemas = append(f.schemas, func() *schema.Builder { return rule.Schema(params) })
+	}
+	if errs.OnlyErrors(test(nil)) != nil {
+		f.required = true
+	}
+}
+
+// withMessage replaces the error of test with one holding msg, keeping the code of the original error.
+func withMessage(test ttypes.ValTest[any], rule, msg string) ttypes.ValTest[any] {
+	return scope.ValueOption[any](message{rule: rule, msg: msg, test: test})
+}
+
+// message is the node of withMessage, which reports test in its place.
+type message struct {
+	rule string
+	msg  string
+	test ttypes.ValTest[any]
+}
+
+func (message) Rule() scope.Rule { return scope.Rule{} }
+
+func (m message) Evaluate(s *scope.Scope, val any) error {
+	err := scope.EvalValue(s, val, m.test)
+	if err == nil {
+		return nil
+	}
+	var validateErr errs.ValidateError
+	if errors.As(err, &validateErr) {
+		return errs.NewValidateErrorWithCode(validateErr.Code(), m.rule, m.msg)
+	}
+	return errs.NewValidateError(m.rule, m.msg)
+}
+
+// Validate validates the fields of values, returning the first error found.
+// Errors are wrapped in an errs.PathError pointing at the field.
+// Warnings and infos are not returned, and can be retrieved with Check.
+func (v *Validator) Validate(values map[string]any) error {
+	return v.validate(nil, values)
+}
+
+// validate validates the fields of values in the scope.
+func (v *Validator) validate(s *scope.Scope, values map[string]any) error {
+	if v == nil {
+		return nil
+	}
+	for _, f := range v.fields {
+		if err := errs.OnlyErrors(f.call(s, values)); err != nil {
+			return errs.WithPath(f.name, err)
+		}
+	}
+	return nil
+}
+
+// Check validates the fields of values until the first error, returning it along with the warnings and infos found.
+func (v *Validator) Check(values map[string]any) errs.Result {
+	var result errs.Result
+	if v == nil {
+		return result
+	}
+	for _, f := range v.fields {
+		result = result.Merge(errs.NewResult(errs.WithPath(f.name, f.call(nil, values))))
+		if !result.Valid() {
+			break
+		}
+	}
+	return result
+}
+
+// Explain validates the fields of values, returning the trace of the rules evaluated.
+func (v *Validator) Explain(values map[string]any) *trace.Node {
+	return trace.ExplainValue("rules.Validator", values, scope.ValueOption[map[string]any](node(v.validate)))
+}
+
+// node evaluates a validator in the scope of its trace, whose fields are reported in its place.
+type node func(s *scope.Scope, values map[string]any) error
+
+func (node) Rule() scope.Rule { return scope.Rule{} }
+
+func (n node) Evaluate(s *scope.Scope, values map[string]any) error { return n(s, values) }
+
+// Schema returns the JSON Schema of the values which the validator accepts, as an object with a property for each field,
+// described by the schemas of its rules. A field is required if one of its rules rejects absent values, and may be null otherwise.
+// The rules without a schema, and the ones whose errors are warnings or infos, are left out.
+func (v *Validator) Schema() *schema.Builder {
+	object := schema.Object()
+	if v == nil {
+		return object
+	}
+	for _, f := range v.fields {
+		schemas := make([]*schema.Builder, 0, len(f.schemas))
+		for _, describe := range f.schemas {
+			schemas = append(schemas, describe())
+		}
+		var property *schema.Builder
+		switch len(schemas) {
+		case 0:
+			property = schema.Any()
+		case 1:
+			property = schemas[0]
+		default:
+			property = schema.AllOf(schemas...)
+		}
+		if f.required {
+			object.RequiredProperty(f.name, property)
+			continue
+		}
+		object.Property(f.name, property.Nullable())
+	}
+	return object
+}
+
+// call validates the field of values in the scope.
+func (f field) call(s *scope.Scope, values map[string]any) error {
+	return scope.EvalRuleValue(s, scope.Rule{Name: f.pointer}, values[f.name], f.test)
+}
+
+// ValTest returns the validator as a ttypes.ValTest.
+func (v *Validator) ValTest() ttypes.ValTest[map[string]any] {
+	return v.Validate
+}
+
+// ToOption returns a Validate which validates values.
+func (v *Validator) ToOption(values map[string]any) ttypes.Validate {
+	return func() error { return v.Validate(values) }
+}
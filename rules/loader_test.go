@@ -0,0 +1,305 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/Jh123x/go-validate/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlDefinition = `
+fields:
+  name:
+    - rule: required
+    - rule: length
+      params: {min: 1, max: 5}
+  country:
+    - rule: allowed
+      params:
+        values: [SG, MY]
+      message: country is not supported
+  age:
+    - rule: range
+      params: {min: 18}
+`
+
+const jsonDefinition = `{
+	"fields": {
+		"name": [{"rule": "required"}, {"rule": "length", "params": {"min": 1, "max": 5}}],
+		"country": [{"rule": "allowed", "params": {"values": ["SG", "MY"]}, "message": "country is not supported"}],
+		"age": [{"rule": "range", "params": {"min": 18}}]
+	}
+}`
+
+func TestLoad(t *testing.T) {
+	loaders := map[string]func() (*Validator, error){
+		"yaml": func() (*Validator, error) { return LoadYAML([]byte(yamlDefinition), nil) },
+		"json": func() (*Validator, error) { return LoadJSON([]byte(jsonDefinition), Default) },
+	}
+	tests := map[string]struct {
+		values      map[string]any
+		expectedErr error
+	}{
+		"valid": {
+			values: map[string]any{"name": "jh", "country": "SG", "age": 20},
+		},
+		"optional fields can be absent": {
+			values: map[string]any{"name": "jh"},
+		},
+		"missing required field": {
+			values:      map[string]any{"country": "SG"},
+			expectedErr: errs.PathError{Pointer: "/name", Err: errs.IsNotEmptyErr},
+		},
+		"invalid length": {
+			values:      map[string]any{"name": "jh123x"},
+			expectedErr: errs.PathError{Pointer: "/name", Err: errs.InvalidLengthError.WithParams(map[string]any{"min": 1, "max": 5})},
+		},
+		"custom message": {
+			values:      map[string]any{"name": "jh", "country": "US"},
+			expectedErr: errs.PathError{Pointer: "/country", Err: errs.NewValidateErrorWithCode(errs.CodeContains, "allowed", "country is not supported")},
+		},
+		"fields are validated in name order": {
+			values:      map[string]any{"name": "", "age": 1},
+			expectedErr: errs.PathError{Pointer: "/age", Err: errs.OutOfRangeError.WithParams(map[string]any{"min": 18.0})},
+		},
+	}
+
+	for loaderName, load := range loaders {
+		v, err := load()
+		require.Nil(t, err)
+		for name, tc := range tests {
+			t.Run(fmt.Sprintf("%s %s", loaderName, name), func(t *testing.T) {
+				assert.Equal(t, tc.expectedErr, v.Validate(tc.values))
+			})
+		}
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := map[string]struct {
+		load        func() (*Validator, error)
+		expectedErr error
+	}{
+		"unknown rule": {
+			load: func() (*Validator, error) {
+				return LoadJSON([]byte(`{"fields":{"a":[{"rule":"unknown"}]}}`), nil)
+			},
+			expectedErr: ErrUnknownRule,
+		},
+		"invalid params": {
+			load: func() (*Validator, error) {
+				return LoadYAML([]byte("fields:\n  a:\n    - rule: length\n      params: {min: a}\n"), nil)
+			},
+			expectedErr: ErrInvalidParams,
+		},
+		"reversed bounds": {
+			load: func() (*Validator, error) {
+				return LoadJSON([]byte(`{"fields":{"a":[{"rule":"length","params":{"min":5,"max":1}}]}}`), nil)
+			},
+			expectedErr: ErrInvalidParams,
+		},
+		"custom registry without the rule": {
+			load: func() (*Validator, error) {
+				return LoadJSON([]byte(`{"fields":{"a":[{"rule":"email"}]}}`), NewRegistry())
+			},
+			expectedErr: ErrUnknownRule,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := tc.load()
+			assert.Nil(t, v)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestLoad_MalformedDefinition(t *testing.T) {
+	tests := map[string]func() (*Validator, error){
+		"invalid json":       func() (*Validator, error) { return LoadJSON([]byte(`{`), nil) },
+		"unknown json field": func() (*Validator, error) { return LoadJSON([]byte(`{"field":{}}`), nil) },
+		"invalid yaml":       func() (*Validator, error) { return LoadYAML([]byte("fields: ["), nil) },
+		"unknown yaml field": func() (*Validator, error) { return LoadYAML([]byte("field: {}"), nil) },
+		"invalid severity": func() (*Validator, error) {
+			return LoadJSON([]byte(`{"fields":{"a":[{"rule":"email","severity":"fatal"}]}}`), nil)
+		},
+	}
+
+	for name, load := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, err := load()
+			assert.Nil(t, v)
+			assert.ErrorContains(t, err, "invalid rule definition")
+		})
+	}
+}
+
+func TestValidator_Options(t *testing.T) {
+	registry := NewRegistry().MustRegister(Rule{
+		Name: "even",
+		Factory: func(Params) (ttypes.ValTest[any], error) {
+			return func(val any) error {
+				if num, ok := val.(int); ok && num%2 == 0 {
+					return nil
+				}
+				return errs.InvalidTypeError
+			}, nil
+		},
+	})
+	v, err := Load(Definition{Fields: map[string][]RuleDefinition{"a": {{Rule: "even"}}}}, registry)
+	require.Nil(t, err)
+
+	assert.Nil(t, v.ValTest()(map[string]any{"a": 2}))
+	err = validator.NewLazyValidator().WithOptions(v.ToOption(map[string]any{"a": 1})).Validate()
+	assert.Equal(t, errs.PathError{Pointer: "/a", Err: errs.InvalidTypeError}, err)
+}
+
+func TestValidator_Nil(t *testing.T) {
+	var v *Validator
+	assert.Nil(t, v.Validate(map[string]any{}))
+	assert.Equal(t, errs.Result{}, v.Check(map[string]any{}))
+}
+
+func TestLoad_MessageOfCustomError(t *testing.T) {
+	registry := NewRegistry().MustRegister(Rule{
+		Name: "fail",
+		Factory: func(Params) (ttypes.ValTest[any], error) {
+			return func(any) error { return fmt.Errorf("failed") }, nil
+		},
+	})
+	v, err := Load(Definition{Fields: map[string][]RuleDefinition{"a": {{Rule: "fail", Message: "a failed"}}}}, registry)
+	require.Nil(t, err)
+	assert.Equal(t, errs.PathError{Pointer: "/a", Err: errs.NewValidateError("fail", "a failed")}, v.Validate(nil))
+}
+
+func TestLoad_Severity(t *testing.T) {
+	loaders := map[string]func() (*Validator, error){
+		"yaml": func() (*Validator, error) {
+			return LoadYAML([]byte("fields:\n  nickname:\n    - rule: length\n      params: {max: 3}\n      severity: warning\n  name:\n    - rule: required\n"), nil)
+		},
+		"json": func() (*Validator, error) {
+			return LoadJSON([]byte(`{"fields":{"nickname":[{"rule":"length","params":{"max":3},"severity":"warning"}],"name":[{"rule":"required"}]}}`), nil)
+		},
+	}
+	warning := errs.PathError{
+		Pointer: "/nickname",
+		Err:     errs.WithSeverity(errs.SeverityWarning, errs.InvalidLengthError.WithParams(map[string]any{"max": 3})),
+	}
+
+	for name, load := range loaders {
+		t.Run(name, func(t *testing.T) {
+			v, err := load()
+			require.Nil(t, err)
+
+			values := map[string]any{"name": "jh", "nickname": "jh123x"}
+			assert.Nil(t, v.Validate(values))
+			assert.Equal(t, errs.Result{Warnings: errs.Errors{warning}}, v.Check(values))
+
+			values = map[string]any{"nickname": "jh123x"}
+			assert.Equal(t, errs.PathError{Pointer: "/name", Err: errs.IsNotEmptyErr}, v.Validate(values))
+			assert.Equal(t, errs.Result{
+				Errors: errs.Errors{errs.PathError{Pointer: "/name", Err: errs.IsNotEmptyErr}},
+			}, v.Check(values))
+		})
+	}
+}
+
+func TestValidator_Explain(t *testing.T) {
+	v, err := LoadYAML([]byte(yamlDefinition), nil)
+	require.Nil(t, err)
+
+	node := v.Explain(map[string]any{"name": "jh", "country": "US"})
+	assert.Equal(t, "rules.Validator", node.Name)
+	assert.False(t, node.Passed)
+	names := make([]string, 0, len(node.Children))
+	for _, child := range node.Children {
+		names = append(names, child.Name)
+	}
+	assert.Equal(t, []string{"/age", "/country"}, names)
+	assert.Equal(t, "US", node.Children[1].Input)
+	assert.False(t, node.Children[1].Passed)
+	assert.Equal(t, "allowed", node.Children[1].Children[0].Children[0].Name)
+}
+
+func TestValidator_Schema(t *testing.T) {
+	v, err := LoadYAML([]byte(yamlDefinition+`
+  nickname:
+    - rule: length
+      params: {max: 3}
+      severity: warning
+  tags:
+    - rule: not_empty
+    - rule: contains
+      params: {value: go}
+  email:
+    - rule: email
+  code:
+    - rule: pattern
+      params: {pattern: "^[A-Z]+$"}
+`), nil)
+	require.Nil(t, err)
+
+	doc, err := json.Marshal(v.Schema())
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"age": {"type": ["number", "null"], "minimum": 18},
+			"code": {"type": ["string", "null"], "pattern": "^[A-Z]+$"},
+			"country": {"anyOf": [{"enum": ["SG", "MY"]}, {"type": "null"}]},
+			"email": {"type": ["string", "null"], "format": "email"},
+			"name": {"allOf": [
+				{"not": {"type": "null"}, "minLength": 1, "minItems": 1, "minProperties": 1},
+				{"anyOf": [
+					{"type": "string", "minLength": 1, "maxLength": 5},
+					{"type": "array", "minItems": 1, "maxItems": 5},
+					{"type": "object", "minProperties": 1, "maxProperties": 5}
+				]}
+			]},
+			"nickname": {"anyOf": [{}, {"type": "null"}]},
+			"tags": {"anyOf": [{"allOf": [
+				{"anyOf": [{"type": "string", "minLength": 1}, {"type": "array", "minItems": 1}, {"type": "object", "minProperties": 1}]},
+				{"anyOf": [{"type": "string", "pattern": "go"}, {"type": "array", "contains": {"const": "go"}}]}
+			]}, {"type": "null"}]}
+		}
+	}`, string(doc))
+
+	s, err := v.Schema().Compile()
+	require.Nil(t, err)
+	documents := []string{
+		`{"name":"jh","country":"SG","age":20,"tags":["go"],"email":"a@b.c","code":"AB","nickname":"jh123x"}`,
+		`{"name":"jh","country":null,"age":null,"tags":null}`,
+		`{}`,
+		`{"name":null}`,
+		`{"name":""}`,
+		`{"name":"jh123x"}`,
+		`{"name":["a"]}`,
+		`{"name":"jh","country":"US"}`,
+		`{"name":"jh","age":17}`,
+		`{"name":"jh","age":"20"}`,
+		`{"name":"jh","tags":[]}`,
+		`{"name":"jh","tags":["rust"]}`,
+		`{"name":"jh","tags":"golang"}`,
+		`{"name":"jh","tags":"rust"}`,
+		`{"name":"jh","email":"a"}`,
+		`{"name":"jh","code":"ab"}`,
+		`{"name":"jh","code":1}`,
+	}
+	for _, document := range documents {
+		t.Run(document, func(t *testing.T) {
+			dec := json.NewDecoder(strings.NewReader(document))
+			dec.UseNumber()
+			var values map[string]any
+			require.Nil(t, dec.Decode(&values))
+			assert.Equal(t, v.Validate(values) == nil, s.Validate(values) == nil)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Params are the parameters of a rule, as written in a rule definition.
+type Params map[string]any
+
+// Int returns the parameter name as an integer, which must be within the range of an int.
+func (p Params) Int(name string) (int, error) {
+	num, err := p.Float(name)
+	if err != nil {
+		return 0, err
+	}
+	// -math.MinInt is not representable as an int, but is exactly representable as a float64 unlike math.MaxInt.
+	if num != math.Trunc(num) || num < math.MinInt || num >= -float64(math.MinInt) {
+		return 0, p.invalid(name, "an integer")
+	}
+	return int(num), nil
+}
+
+// Float returns the parameter name as a number.
+func (p Params) Float(name string) (float64, error) {
+	val, err := p.get(name)
+	if err != nil {
+		return 0, err
+	}
+	if num, ok := toFloat(val); ok {
+		return num, nil
+	}
+	return 0, p.invalid(name, "a number")
+}
+
+// String returns the parameter name as a string.
+func (p Params) String(name string) (string, error) {
+	val, err := p.get(name)
+	if err != nil {
+		return "", err
+	}
+	str, ok := val.(string)
+	if !ok {
+		return "", p.invalid(name, "a string")
+	}
+	return str, nil
+}
+
+// Strings returns the parameter name as a list of strings.
+func (p Params) Strings(name string) ([]string, error) {
+	vals, err := p.List(name)
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, 0, len(vals))
+	for _, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			return nil, p.invalid(name, "a list of strings")
+		}
+		strs = append(strs, str)
+	}
+	return strs, nil
+}
+
+// List returns the parameter name as a list.
+func (p Params) List(name string) ([]any, error) {
+	val, err := p.get(name)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := val.([]any)
+	if !ok {
+		return nil, p.invalid(name, "a list")
+	}
+	return list, nil
+}
+
+// Has reports whether the parameter name is set.
+func (p Params) Has(name string) bool {
+	_, ok := p[name]
+	return ok
+}
+
+func (p Params) get(name string) (any, error) {
+	val, ok := p[name]
+	if !ok {
+		return nil, p.missing(name)
+	}
+	return val, nil
+}
+
+func (p Params) missing(name string) error {
+	return fmt.Errorf("%w: missing parameter %q", ErrInvalidParams, name)
+}
+
+// bounds returns an error if the "min" parameter is greater than the "max" parameter.
+func (p Params) bounds(minVal, maxVal float64) error {
+	if minVal > maxVal {
+		return fmt.Errorf("%w: parameter \"min\" must not be greater than \"max\"", ErrInvalidParams)
+	}
+	return nil
+}
+
+func (p Params) invalid(name, expected string) error {
+	return fmt.Errorf("%w: parameter %q must be %s", ErrInvalidParams, name, expected)
+}
+
+// toFloat converts a number decoded from JSON, YAML or Go code into a float64.
+func toFloat(val any) (float64, bool) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		num, err := v.Float64()
+		return num, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParams(t *testing.T) {
+	params := Params{
+		"int":     3,
+		"float":   1.5,
+		"number":  json.Number("2"),
+		"uint":    uint8(4),
+		"string":  "str",
+		"strings": []any{"a", "b"},
+		"mixed":   []any{"a", 1},
+		"huge":    1e19,
+		"inf":     math.Inf(-1),
+		"nan":     math.NaN(),
+	}
+
+	t.Run("Int", func(t *testing.T) {
+		val, err := params.Int("int")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, val)
+
+		val, err = params.Int("number")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, val)
+
+		_, err = params.Int("float")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = params.Int("huge")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = params.Int("inf")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = params.Int("nan")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = params.Int("string")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = params.Int("missing")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+	})
+
+	t.Run("Float", func(t *testing.T) {
+		val, err := params.Float("uint")
+		assert.Nil(t, err)
+		assert.Equal(t, 4.0, val)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		val, err := params.String("string")
+		assert.Nil(t, err)
+		assert.Equal(t, "str", val)
+
+		_, err = params.String("int")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = params.String("missing")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+	})
+
+	t.Run("Strings", func(t *testing.T) {
+		val, err := params.Strings("strings")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, val)
+
+		_, err = params.Strings("mixed")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+		_, err = params.Strings("string")
+		assert.ErrorIs(t, err, ErrInvalidParams)
+	})
+
+	t.Run("Has", func(t *testing.T) {
+		assert.True(t, params.Has("int"))
+		assert.False(t, params.Has("missing"))
+	})
+}
+
+func TestToFloat(t *testing.T) {
+	for _, val := range []any{
+		int(1), int8(1), int16(1), int32(1), int64(1),
+		uint(1), uint8(1), uint16(1), uint32(1), uint64(1),
+		float32(1), float64(1), json.Number("1"),
+	} {
+		num, ok := toFloat(val)
+		assert.True(t, ok)
+		assert.Equal(t, 1.0, num)
+	}
+
+	_, ok := toFloat(json.Number("a"))
+	assert.False(t, ok)
+	_, ok = toFloat("1")
+	assert.False(t, ok)
+}
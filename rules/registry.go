@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Jh123x/go-validate/internal/scope"
+	"github.com/Jh123x/go-validate/schema"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+var (
+	// ErrUnknownRule is returned when a rule name is not registered.
+	ErrUnknownRule = errors.New("unknown rule")
+	// ErrInvalidParams is returned when the parameters of a rule are missing or have the wrong type.
+	ErrInvalidParams = errors.New("invalid rule parameters")
+	// ErrDuplicateRule is returned when a rule name is registered twice.
+	ErrDuplicateRule = errors.New("duplicate rule")
+	// ErrInvalidRule is returned when a rule cannot be registered.
+	ErrInvalidRule = errors.New("invalid rule")
+)
+
+// Factory builds a rule from its parameters.
+// The parameters have been checked against the parameter specs of the rule.
+type Factory func(Params) (ttypes.ValTest[any], error)
+
+// Rule describes a named rule.
+// Schema, if set, returns the JSON Schema of the values which the rule built from the parameters accepts,
+// so that the validators using the rule are described by Validator.Schema.
+type Rule struct {
+	Name        string
+	Description string
+	Params      []ParamSpec
+	Factory     Factory
+	Schema      func(Params) *schema.Builder
+}
+
+// Registry maps rule names to the rules which build them.
+// A registry is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// Default is the registry holding the built-in rules.
+var Default = registerBuiltins(NewRegistry())
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{rules: make(map[string]Rule)}
+}
+
+// Register adds the rule to the registry.
+// It returns ErrDuplicateRule if a rule with the same name is already registered.
+func (r *Registry) Register(rule Rule) error {
+	if rule.Name == "" || rule.Factory == nil {
+		return fmt.Errorf("%w: a rule needs a name and a factory", ErrInvalidRule)
+	}
+	seen := make(map[string]struct{}, len(rule.Params))
+	for _, spec := range rule.Params {
+		if _, ok := seen[spec.Name]; ok {
+			return fmt.Errorf("%w: %q has parameter %q twice", ErrInvalidRule, rule.Name, spec.Name)
+		}
+		seen[spec.Name] = struct{}{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rules[rule.Name]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateRule, rule.Name)
+	}
+	rule.Params = append([]ParamSpec(nil), rule.Params...)
+	r.rules[rule.Name] = rule
+	return nil
+}
+
+// MustRegister is like Register but panics if the rule cannot be registered.
+func (r *Registry) MustRegister(rule Rule) *Registry {
+	if err := r.Register(rule); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Lookup returns the rule registered under name.
+func (r *Registry) Lookup(name string) (Rule, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rule, ok := r.rules[name]
+	return rule, ok
+}
+
+// List returns the registered rules sorted by name.
+func (r *Registry) List() []Rule {
+	r.mu.RLock()
+	rules := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules
+}
+
+// Build checks the parameters and builds the rule registered under name.
+func (r *Registry) Build(name string, params Params) (ttypes.ValTest[any], error) {
+	rule, ok := r.Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRule, name)
+	}
+	if err := checkParams(rule.Params, params); err != nil {
+		return nil, err
+	}
+	test, err := rule.Factory(params)
+	if err != nil {
+		return nil, err
+	}
+	return scope.ValueOption[any](built{rule: scope.Rule{Name: rule.Name, Description: rule.Description}, test: test}), nil
+}
+
+// built is the node of a rule built from the registry, named after the rule.
+type built struct {
+	rule scope.Rule
+	test ttypes.ValTest[any]
+}
+
+func (b built) Rule() scope.Rule { return b.rule }
+
+func (b built) Evaluate(s *scope.Scope, val any) error {
+	return scope.EvalValue(s, val, b.test)
+}
@@ -0,0 +1,158 @@
+package rules
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func alwaysValid(Params) (ttypes.ValTest[any], error) {
+	return func(any) error { return nil }, nil
+}
+
+func TestRegistry_Register(t *testing.T) {
+	tests := map[string]struct {
+		rule        Rule
+		expectedErr error
+	}{
+		"success": {
+			rule: Rule{Name: "valid", Factory: alwaysValid, Params: []ParamSpec{{Name: "a"}, {Name: "b"}}},
+		},
+		"duplicate name": {
+			rule:        Rule{Name: "email", Factory: alwaysValid},
+			expectedErr: ErrDuplicateRule,
+		},
+		"missing name": {
+			rule:        Rule{Factory: alwaysValid},
+			expectedErr: ErrInvalidRule,
+		},
+		"missing factory": {
+			rule:        Rule{Name: "valid"},
+			expectedErr: ErrInvalidRule,
+		},
+		"duplicate parameter": {
+			rule:        Rule{Name: "valid", Factory: alwaysValid, Params: []ParamSpec{{Name: "a"}, {Name: "a"}}},
+			expectedErr: ErrInvalidRule,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			registry := NewRegistry().MustRegister(Rule{Name: "email", Factory: alwaysValid})
+			assert.ErrorIs(t, registry.Register(tc.rule), tc.expectedErr)
+		})
+	}
+}
+
+func TestRegistry_MustRegisterPanics(t *testing.T) {
+	assert.Panics(t, func() { Default.MustRegister(Rule{Name: "email", Factory: alwaysValid}) })
+}
+
+func TestRegistry_RegisterConcurrently(t *testing.T) {
+	registry := NewRegistry()
+	var wg sync.WaitGroup
+	errCh := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("rule%d", i%10)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errCh <- registry.Register(Rule{Name: name, Factory: alwaysValid})
+			_, _ = registry.Build(name, nil)
+			_ = registry.List()
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+
+	duplicates := 0
+	for err := range errCh {
+		if err != nil {
+			assert.ErrorIs(t, err, ErrDuplicateRule)
+			duplicates++
+		}
+	}
+	assert.Equal(t, 10, duplicates)
+	assert.Len(t, registry.List(), 10)
+}
+
+func TestRegistry_List(t *testing.T) {
+	names := make([]string, 0)
+	for _, rule := range Default.List() {
+		assert.NotEmpty(t, rule.Description)
+		names = append(names, rule.Name)
+	}
+	assert.Equal(t, []string{"allowed", "contains", "email", "json", "length", "not_empty", "pattern", "range", "required", "uri"}, names)
+
+	rule, ok := Default.Lookup("length")
+	require.True(t, ok)
+	assert.Equal(t, []ParamSpec{minLengthParam, maxLengthParam}, rule.Params)
+
+	_, ok = Default.Lookup("unknown")
+	assert.False(t, ok)
+}
+
+func TestRegistry_BuildChecksParams(t *testing.T) {
+	registry := NewRegistry().MustRegister(Rule{
+		Name:    "typed",
+		Factory: alwaysValid,
+		Params: []ParamSpec{
+			{Name: "int", Type: IntParam, Required: true},
+			{Name: "number", Type: NumberParam},
+			{Name: "string", Type: StringParam},
+			{Name: "strings", Type: StringsParam},
+			{Name: "list", Type: ListParam},
+			{Name: "any", Type: AnyParam},
+		},
+	})
+	tests := map[string]struct {
+		params      Params
+		expectedErr error
+	}{
+		"required only": {params: Params{"int": 1}},
+		"all params": {
+			params: Params{"int": 1, "number": 1.5, "string": "a", "strings": []any{"a"}, "list": []any{1}, "any": nil},
+		},
+		"missing required": {params: Params{}, expectedErr: ErrInvalidParams},
+		"unknown param":    {params: Params{"int": 1, "other": 1}, expectedErr: ErrInvalidParams},
+		"not an integer":   {params: Params{"int": 1.5}, expectedErr: ErrInvalidParams},
+		"not a number":     {params: Params{"int": 1, "number": "1"}, expectedErr: ErrInvalidParams},
+		"not a string":     {params: Params{"int": 1, "string": 1}, expectedErr: ErrInvalidParams},
+		"not strings":      {params: Params{"int": 1, "strings": []any{1}}, expectedErr: ErrInvalidParams},
+		"not a list":       {params: Params{"int": 1, "list": "a"}, expectedErr: ErrInvalidParams},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			test, err := registry.Build("typed", tc.params)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedErr == nil, test != nil)
+		})
+	}
+}
+
+func TestFactories_InvalidParams(t *testing.T) {
+	tests := map[string]struct {
+		factory Factory
+		params  Params
+	}{
+		"length min": {factory: length, params: Params{"min": "1"}},
+		"length max": {factory: length, params: Params{"max": "1"}},
+		"range min":  {factory: numberRange, params: Params{"min": "1"}},
+		"range max":  {factory: numberRange, params: Params{"max": "1"}},
+		"allowed":    {factory: allowed, params: Params{}},
+		"pattern":    {factory: pattern, params: Params{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			test, err := tc.factory(tc.params)
+			assert.Nil(t, test)
+			assert.ErrorIs(t, err, ErrInvalidParams)
+		})
+	}
+}
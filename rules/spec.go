@@ -0,0 +1,74 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ParamType is the type of a rule parameter.
+type ParamType string
+
+const (
+	IntParam     ParamType = "integer"
+	NumberParam  ParamType = "number"
+	StringParam  ParamType = "string"
+	StringsParam ParamType = "strings"
+	ListParam    ParamType = "list"
+	AnyParam     ParamType = "any"
+)
+
+// ParamSpec describes a parameter of a rule.
+type ParamSpec struct {
+	Name        string
+	Type        ParamType
+	Required    bool
+	Description string
+}
+
+// checkParams checks that params only has the parameters in specs, with the right types,
+// and that the required parameters are set.
+func checkParams(specs []ParamSpec, params Params) error {
+	known := make(map[string]struct{}, len(specs))
+	for _, spec := range specs {
+		known[spec.Name] = struct{}{}
+		if !params.Has(spec.Name) {
+			if spec.Required {
+				return params.missing(spec.Name)
+			}
+			continue
+		}
+		if err := spec.check(params); err != nil {
+			return err
+		}
+	}
+
+	unknown := make([]string, 0)
+	for name := range params {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown parameter %q", ErrInvalidParams, unknown[0])
+	}
+	return nil
+}
+
+// check checks the type of the parameter in params.
+func (s ParamSpec) check(params Params) error {
+	var err error
+	switch s.Type {
+	case IntParam:
+		_, err = params.Int(s.Name)
+	case NumberParam:
+		_, err = params.Float(s.Name)
+	case StringParam:
+		_, err = params.String(s.Name)
+	case StringsParam:
+		_, err = params.Strings(s.Name)
+	case ListParam:
+		_, err = params.List(s.Name)
+	}
+	return err
+}
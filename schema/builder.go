@@ -0,0 +1,358 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Builder builds a JSON Schema document in Go.
+// Builder methods modify and return the builder so that calls can be chained.
+type Builder struct {
+	keywords   map[string]any
+	properties map[string]*Builder
+	items      *Builder
+	contains   *Builder
+	not        *Builder
+	subschemas map[string][]*Builder
+	defs       map[string]*Builder
+	ref        string
+	nullable   bool
+}
+
+func newBuilder(typeName string) *Builder {
+	b := &Builder{keywords: make(map[string]any)}
+	if typeName != "" {
+		b.keywords["type"] = typeName
+	}
+	return b
+}
+
+// Any returns a builder for a schema which accepts any value.
+func Any() *Builder { return newBuilder("") }
+
+// String returns a builder for a string schema.
+func String() *Builder { return newBuilder("string") }
+
+// Integer returns a builder for an integer schema.
+func Integer() *Builder { return newBuilder("integer") }
+
+// Number returns a builder for a number schema.
+func Number() *Builder { return newBuilder("number") }
+
+// Boolean returns a builder for a boolean schema.
+func Boolean() *Builder { return newBuilder("boolean") }
+
+// Null returns a builder for a null schema.
+func Null() *Builder { return newBuilder("null") }
+
+// Array returns a builder for an array schema whose items match items.
+// If items is nil, the items are not checked.
+func Array(items *Builder) *Builder {
+	b := newBuilder("array")
+	b.items = items
+	return b
+}
+
+// Object returns a builder for an object schema.
+func Object() *Builder { return newBuilder("object") }
+
+// Ref returns a builder referring to the schema defined as name in a Definitions.
+func Ref(name string) *Builder {
+	b := newBuilder("")
+	b.ref = name
+	return b
+}
+
+// Not returns a builder for a schema which matches the values that schema does not match.
+func Not(schema *Builder) *Builder {
+	b := newBuilder("")
+	b.not = schema
+	return b
+}
+
+// AllOf returns a builder for a schema which matches all of the schemas.
+func AllOf(schemas ...*Builder) *Builder { return combine("allOf", schemas) }
+
+// AnyOf returns a builder for a schema which matches at least one of the schemas.
+func AnyOf(schemas ...*Builder) *Builder { return combine("anyOf", schemas) }
+
+// OneOf returns a builder for a schema which matches exactly one of the schemas.
+func OneOf(schemas ...*Builder) *Builder { return combine("oneOf", schemas) }
+
+func combine(keyword string, schemas []*Builder) *Builder {
+	b := newBuilder("")
+	b.subschemas = map[string][]*Builder{keyword: schemas}
+	return b
+}
+
+// Title sets the title of the schema.
+func (b *Builder) Title(title string) *Builder { return b.set("title", title) }
+
+// Description sets the description of the schema.
+func (b *Builder) Description(description string) *Builder {
+	return b.set("description", description)
+}
+
+// Format sets the format of a string, such as "email", "uri", "date-time" or "uuid".
+func (b *Builder) Format(format string) *Builder { return b.set("format", format) }
+
+// MinLength sets the minimum number of characters of a string.
+func (b *Builder) MinLength(n int) *Builder { return b.set("minLength", n) }
+
+// MaxLength sets the maximum number of characters of a string.
+func (b *Builder) MaxLength(n int) *Builder { return b.set("maxLength", n) }
+
+// Pattern sets the regular expression a string must match.
+func (b *Builder) Pattern(pattern string) *Builder { return b.set("pattern", pattern) }
+
+// Minimum sets the inclusive lower bound of a number.
+func (b *Builder) Minimum(n float64) *Builder { return b.set("minimum", n) }
+
+// Maximum sets the inclusive upper bound of a number.
+func (b *Builder) Maximum(n float64) *Builder { return b.set("maximum", n) }
+
+// ExclusiveMinimum sets the exclusive lower bound of a number.
+func (b *Builder) ExclusiveMinimum(n float64) *Builder { return b.set("exclusiveMinimum", n) }
+
+// ExclusiveMaximum sets the exclusive upper bound of a number.
+func (b *Builder) ExclusiveMaximum(n float64) *Builder { return b.set("exclusiveMaximum", n) }
+
+// MultipleOf sets the number a number must be a multiple of.
+func (b *Builder) MultipleOf(n float64) *Builder { return b.set("multipleOf", n) }
+
+// Enum sets the values which are allowed.
+func (b *Builder) Enum(values ...any) *Builder { return b.set("enum", values) }
+
+// Const sets the only value which is allowed.
+func (b *Builder) Const(value any) *Builder { return b.set("const", value) }
+
+// MinItems sets the minimum number of items of an array.
+func (b *Builder) MinItems(n int) *Builder { return b.set("minItems", n) }
+
+// MaxItems sets the maximum number of items of an array.
+func (b *Builder) MaxItems(n int) *Builder { return b.set("maxItems", n) }
+
+// UniqueItems requires the items of an array to be unique.
+func (b *Builder) UniqueItems() *Builder { return b.set("uniqueItems", true) }
+
+// Contains requires an array to have at least one item matching schema.
+func (b *Builder) Contains(schema *Builder) *Builder {
+	b.contains = schema
+	return b
+}
+
+// MinProperties sets the minimum number of properties of an object.
+func (b *Builder) MinProperties(n int) *Builder { return b.set("minProperties", n) }
+
+// MaxProperties sets the maximum number of properties of an object.
+func (b *Builder) MaxProperties(n int) *Builder { return b.set("maxProperties", n) }
+
+// ContentMediaType sets the media type of the content of a string, such as "application/json".
+func (b *Builder) ContentMediaType(mediaType string) *Builder {
+	return b.set("contentMediaType", mediaType)
+}
+
+// Property adds an optional property to an object.
+func (b *Builder) Property(name string, schema *Builder) *Builder {
+	if b.properties == nil {
+		b.properties = make(map[string]*Builder)
+	}
+	b.properties[name] = schema
+	return b
+}
+
+// RequiredProperty adds a required property to an object.
+func (b *Builder) RequiredProperty(name string, schema *Builder) *Builder {
+	return b.Property(name, schema).Required(name)
+}
+
+// Required marks properties of an object as required.
+func (b *Builder) Required(names ...string) *Builder {
+	required, _ := b.keywords["required"].([]string)
+	return b.set("required", append(required[:len(required):len(required)], names...))
+}
+
+// AdditionalProperties sets whether an object may have properties which were not added to the builder.
+func (b *Builder) AdditionalProperties(allowed bool) *Builder {
+	return b.set("additionalProperties", allowed)
+}
+
+// Nullable allows the value to also be null.
+// A schema with a single type also allows the "null" type, and any other schema is wrapped in an "anyOf" with a null schema.
+func (b *Builder) Nullable() *Builder {
+	if typeName, ok := b.keywords["type"].(string); ok {
+		return b.set("type", []string{typeName, "null"})
+	}
+	b.nullable = true
+	return b
+}
+
+// Define adds the schema under name to the "$defs" of the document, so that Ref(name) refers to it.
+// The definitions of the builders used by the schema are included in the document as well.
+func (b *Builder) Define(name string, schema *Builder) *Builder {
+	if b.defs == nil {
+		b.defs = make(map[string]*Builder)
+	}
+	b.defs[name] = schema
+	return b
+}
+
+// Compile compiles the schema with format assertion enabled, so that the rules
+// enforced are the ones described by the document.
+func (b *Builder) Compile() (*Schema, error) {
+	return NewDefinitions().Define("", b).Compile("")
+}
+
+// MarshalJSON marshals the schema as a document, with references pointing into "$defs",
+// which holds the schemas defined with Define.
+// It returns an error wrapping ErrInvalidSchema if a reference is not defined.
+func (b *Builder) MarshalJSON() ([]byte, error) {
+	defs := b.definitions(nil)
+	if err := checkRefs(defs, b); err != nil {
+		return nil, err
+	}
+	doc := b.render(defsPrefix)
+	if len(defs) > 0 {
+		doc["$defs"] = renderAll(defs, defsPrefix)
+	}
+	return json.Marshal(doc)
+}
+
+func (b *Builder) set(keyword string, value any) *Builder {
+	b.keywords[keyword] = value
+	return b
+}
+
+// render returns the schema as a JSON value, with references prefixed by refPrefix.
+func (b *Builder) render(refPrefix string) map[string]any {
+	doc := make(map[string]any, len(b.keywords)+5)
+	for keyword, value := range b.keywords {
+		doc[keyword] = value
+	}
+	if b.ref != "" {
+		doc["$ref"] = refPrefix + b.ref
+	}
+	if b.items != nil {
+		doc["items"] = b.items.render(refPrefix)
+	}
+	if b.contains != nil {
+		doc["contains"] = b.contains.render(refPrefix)
+	}
+	if b.not != nil {
+		doc["not"] = b.not.render(refPrefix)
+	}
+	if b.properties != nil {
+		properties := make(map[string]any, len(b.properties))
+		for name, schema := range b.properties {
+			properties[name] = schema.render(refPrefix)
+		}
+		doc["properties"] = properties
+	}
+	for keyword, schemas := range b.subschemas {
+		rendered := make([]any, 0, len(schemas))
+		for _, schema := range schemas {
+			rendered = append(rendered, schema.render(refPrefix))
+		}
+		doc[keyword] = rendered
+	}
+	if b.nullable {
+		return map[string]any{"anyOf": []any{doc, map[string]any{"type": "null"}}}
+	}
+	return doc
+}
+
+// subschemaList returns the builders used by the schema, other than its definitions.
+func (b *Builder) subschemaList() []*Builder {
+	list := make([]*Builder, 0, len(b.properties)+2)
+	for _, schema := range b.properties {
+		list = append(list, schema)
+	}
+	for _, schema := range []*Builder{b.items, b.contains, b.not} {
+		if schema != nil {
+			list = append(list, schema)
+		}
+	}
+	for _, schemas := range b.subschemas {
+		list = append(list, schemas...)
+	}
+	return list
+}
+
+// definitions adds the schemas defined with Define on b and on the builders it uses to defs, returning defs.
+// The definitions already in defs are kept.
+func (b *Builder) definitions(defs map[string]*Builder) map[string]*Builder {
+	if defs == nil {
+		defs = make(map[string]*Builder)
+	}
+	seen := make(map[*Builder]struct{})
+	var walk func(*Builder)
+	walk = func(schema *Builder) {
+		if schema == nil {
+			return
+		}
+		if _, ok := seen[schema]; ok {
+			return
+		}
+		seen[schema] = struct{}{}
+		for name, def := range schema.defs {
+			if _, ok := defs[name]; !ok {
+				defs[name] = def
+			}
+			walk(def)
+		}
+		for _, sub := range schema.subschemaList() {
+			walk(sub)
+		}
+	}
+	walk(b)
+	return defs
+}
+
+// checkRefs returns an error wrapping ErrInvalidSchema if one of the schemas, or of the definitions,
+// refers to a name which is not in the definitions.
+func checkRefs(defs map[string]*Builder, schemas ...*Builder) error {
+	seen := make(map[*Builder]struct{})
+	var check func(*Builder) error
+	check = func(schema *Builder) error {
+		if schema == nil {
+			return nil
+		}
+		if _, ok := seen[schema]; ok {
+			return nil
+		}
+		seen[schema] = struct{}{}
+		if _, ok := defs[schema.ref]; schema.ref != "" && !ok {
+			return fmt.Errorf("%w: %q is not defined", ErrInvalidSchema, schema.ref)
+		}
+		for _, sub := range schema.subschemaList() {
+			if err := check(sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		schemas = append(schemas, defs[name])
+	}
+	for _, schema := range schemas {
+		if err := check(schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderAll renders the schemas with references prefixed by refPrefix.
+func renderAll(schemas map[string]*Builder, refPrefix string) map[string]any {
+	rendered := make(map[string]any, len(schemas))
+	for name, schema := range schemas {
+		rendered[name] = schema.render(refPrefix)
+	}
+	return rendered
+}
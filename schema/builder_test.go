@@ -0,0 +1,312 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDefinitions() *Definitions {
+	return NewDefinitions().
+		Define("Address", Object().
+			RequiredProperty("country", String().Enum("SG", "MY")).
+			Property("postal", String().Pattern("^[0-9]{6}$"))).
+		Define("Person", Object().
+			RequiredProperty("name", String().MinLength(1).MaxLength(10)).
+			RequiredProperty("email", String().Format("email")).
+			Property("age", Integer().Minimum(0).ExclusiveMaximum(150)).
+			Property("score", Number().ExclusiveMinimum(0).Maximum(1).MultipleOf(0.25)).
+			Property("nickname", String().Nullable()).
+			Property("address", Ref("Address")).
+			Property("tags", Array(String()).MinItems(1).MaxItems(3).UniqueItems()).
+			AdditionalProperties(false))
+}
+
+func TestDefinitions_JSONSchema(t *testing.T) {
+	doc, err := newTestDefinitions().JSONSchema("Person")
+	require.Nil(t, err)
+
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["name", "email"],
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string", "minLength": 1, "maxLength": 10},
+			"email": {"type": "string", "format": "email"},
+			"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+			"score": {"type": "number", "exclusiveMinimum": 0, "maximum": 1, "multipleOf": 0.25},
+			"nickname": {"type": ["string", "null"]},
+			"address": {"$ref": "#/$defs/Address"},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 3, "uniqueItems": true}
+		},
+		"$defs": {
+			"Address": {
+				"type": "object",
+				"required": ["country"],
+				"properties": {
+					"country": {"type": "string", "enum": ["SG", "MY"]},
+					"postal": {"type": "string", "pattern": "^[0-9]{6}$"}
+				}
+			},
+			"Person": {
+				"type": "object",
+				"required": ["name", "email"],
+				"additionalProperties": false,
+				"properties": {
+					"name": {"type": "string", "minLength": 1, "maxLength": 10},
+					"email": {"type": "string", "format": "email"},
+					"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+					"score": {"type": "number", "exclusiveMinimum": 0, "maximum": 1, "multipleOf": 0.25},
+					"nickname": {"type": ["string", "null"]},
+					"address": {"$ref": "#/$defs/Address"},
+					"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 3, "uniqueItems": true}
+				}
+			}
+		}
+	}`
+	assert.JSONEq(t, expected, string(doc))
+}
+
+func TestDefinitions_OpenAPIComponents(t *testing.T) {
+	doc, err := NewDefinitions().
+		Define("Pet", Object().
+			RequiredProperty("kind", OneOf(Ref("Cat"), Ref("Dog"))).
+			Property("born", String().Format("date").Title("Birthday").Description("Date of birth"))).
+		Define("Cat", Object().Property("kind", Any().Const("cat"))).
+		Define("Dog", AllOf(Object(), AnyOf(Boolean(), Null()))).
+		OpenAPIComponents()
+	require.Nil(t, err)
+
+	expected := `{
+		"schemas": {
+			"Pet": {
+				"type": "object",
+				"required": ["kind"],
+				"properties": {
+					"kind": {"oneOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Dog"}]},
+					"born": {"type": "string", "format": "date", "title": "Birthday", "description": "Date of birth"}
+				}
+			},
+			"Cat": {"type": "object", "properties": {"kind": {"const": "cat"}}},
+			"Dog": {"allOf": [{"type": "object"}, {"anyOf": [{"type": "boolean"}, {"type": "null"}]}]}
+		}
+	}`
+	assert.JSONEq(t, expected, string(doc))
+}
+
+func TestDefinitions_Compile(t *testing.T) {
+	s, err := newTestDefinitions().Compile("Person")
+	require.Nil(t, err)
+
+	tests := map[string]struct {
+		json        string
+		expectedErr error
+	}{
+		"valid": {
+			json: `{"name":"a","email":"a@b.c","age":1,"score":0.75,"nickname":null,"address":{"country":"SG"},"tags":["a"]}`,
+		},
+		"invalid format": {
+			json:        `{"name":"a","email":"abc"}`,
+			expectedErr: errs.PathError{Pointer: "/email", Err: errs.SchemaFormatError},
+		},
+		"invalid reference": {
+			json:        `{"name":"a","email":"a@b.c","address":{"country":"US"}}`,
+			expectedErr: errs.PathError{Pointer: "/address/country", Err: errs.SchemaEnumError},
+		},
+		"invalid multiple": {
+			json:        `{"name":"a","email":"a@b.c","score":0.3}`,
+			expectedErr: errs.PathError{Pointer: "/score", Err: errs.SchemaMultipleOfError},
+		},
+		"exclusive maximum": {
+			json:        `{"name":"a","email":"a@b.c","age":150}`,
+			expectedErr: errs.PathError{Pointer: "/age", Err: errs.SchemaExclusiveMaximumError},
+		},
+		"too many items": {
+			json:        `{"name":"a","email":"a@b.c","tags":["a","b","c","d"]}`,
+			expectedErr: errs.PathError{Pointer: "/tags", Err: errs.SchemaMaxItemsError},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, s.ValidateJSON([]byte(tc.json)))
+		})
+	}
+}
+
+func TestDefinitions_Undefined(t *testing.T) {
+	s, err := NewDefinitions().Compile("Person")
+	assert.Nil(t, s)
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	defs := NewDefinitions().Define("Person", Object().Property("address", Ref("Address")))
+	_, err = defs.JSONSchema("Person")
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+	_, err = defs.OpenAPIComponents()
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+}
+
+func TestBuilder_Compile(t *testing.T) {
+	s, err := String().Format("uuid").Compile()
+	require.Nil(t, err)
+	assert.Nil(t, s.Validate("123e4567-e89b-12d3-a456-426614174000"))
+	assert.Equal(t, errs.SchemaFormatError, s.Validate("123"))
+}
+
+func TestBuilder_MarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		schema      *Builder
+		expected    string
+		expectedErr error
+	}{
+		"required": {
+			schema:   Object().Required("a").Required("b"),
+			expected: `{"type":"object","required":["a","b"]}`,
+		},
+		"definitions": {
+			schema: Object().Property("c", Ref("C")).Define("C", Object().Property("d", Ref("D"))).
+				Define("D", String()),
+			expected: `{
+				"type": "object",
+				"properties": {"c": {"$ref": "#/$defs/C"}},
+				"$defs": {
+					"C": {"type": "object", "properties": {"d": {"$ref": "#/$defs/D"}}},
+					"D": {"type": "string"}
+				}
+			}`,
+		},
+		"definitions of subschemas": {
+			schema:   Array(Ref("C").Define("C", Integer())),
+			expected: `{"type":"array","items":{"$ref":"#/$defs/C"},"$defs":{"C":{"type":"integer"}}}`,
+		},
+		"undefined reference": {
+			schema:      Object().Property("c", Ref("C")),
+			expectedErr: ErrInvalidSchema,
+		},
+		"undefined reference in definition": {
+			schema:      Ref("C").Define("C", Not(Ref("D"))),
+			expectedErr: ErrInvalidSchema,
+		},
+		"nullable type": {
+			schema:   Integer().Nullable(),
+			expected: `{"type":["integer","null"]}`,
+		},
+		"nullable any": {
+			schema:   Any().Nullable(),
+			expected: `{"anyOf":[{},{"type":"null"}]}`,
+		},
+		"nullable reference": {
+			schema:   Ref("C").Nullable().Define("C", String()),
+			expected: `{"anyOf":[{"$ref":"#/$defs/C"},{"type":"null"}],"$defs":{"C":{"type":"string"}}}`,
+		},
+		"nullable combinator": {
+			schema:   OneOf(String(), Integer()).Nullable(),
+			expected: `{"anyOf":[{"oneOf":[{"type":"string"},{"type":"integer"}]},{"type":"null"}]}`,
+		},
+		"contains and not": {
+			schema:   Array(nil).Contains(Any().Const(1)).MinProperties(1).MaxProperties(2),
+			expected: `{"type":"array","contains":{"const":1},"minProperties":1,"maxProperties":2}`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc, err := json.Marshal(tc.schema)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.Nil(t, err)
+			assert.JSONEq(t, tc.expected, string(doc))
+		})
+	}
+}
+
+func TestBuilder_RoundTrip(t *testing.T) {
+	tree := Object().
+		RequiredProperty("value", Integer()).
+		Property("children", Array(Ref("Tree"))).
+		Property("label", Ref("Label").Nullable()).
+		Property("kind", AnyOf(Ref("Label"), Integer().Minimum(0)).Nullable()).
+		Property("tags", Array(String()).Contains(Any().Const("root"))).
+		Property("meta", Not(Null()).MinProperties(1)).
+		Define("Label", String().MinLength(1))
+	tree.Define("Tree", tree)
+	doc, err := json.Marshal(tree)
+	require.Nil(t, err)
+	s, err := Compile(doc)
+	require.Nil(t, err)
+
+	tests := map[string]struct {
+		json        string
+		expectedErr error
+	}{
+		"valid": {
+			json: `{"value":1,"children":[{"value":2,"children":[]}],"label":null,"kind":null,"tags":["root"],"meta":{"a":1}}`,
+		},
+		"nullable reference": {
+			json: `{"value":1,"label":"a","kind":"b"}`,
+		},
+		"invalid reference": {
+			json:        `{"value":1,"label":""}`,
+			expectedErr: errs.PathError{Pointer: "/label", Err: errs.OrError},
+		},
+		"invalid recursive reference": {
+			json:        `{"value":1,"children":[{"value":"a"}]}`,
+			expectedErr: errs.PathError{Pointer: "/children/0/value", Err: errs.SchemaTypeError},
+		},
+		"invalid contains": {
+			json:        `{"value":1,"tags":["a"]}`,
+			expectedErr: errs.PathError{Pointer: "/tags", Err: errs.SchemaContainsError},
+		},
+		"invalid not": {
+			json:        `{"value":1,"meta":null}`,
+			expectedErr: errs.PathError{Pointer: "/meta", Err: errs.SchemaNotError},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, s.ValidateJSON([]byte(tc.json)))
+		})
+	}
+}
+
+func TestFormatAssertion(t *testing.T) {
+	tests := map[string]struct {
+		format  string
+		valid   []string
+		invalid []string
+	}{
+		"email":     {format: "email", valid: []string{"a@b.c"}, invalid: []string{"a"}},
+		"uri":       {format: "uri", valid: []string{"https://github.com"}, invalid: []string{"github"}},
+		"date-time": {format: "date-time", valid: []string{"2024-01-02T03:04:05Z"}, invalid: []string{"2024-01-02"}},
+		"date":      {format: "date", valid: []string{"2024-01-02"}, invalid: []string{"2024-13-02"}},
+		"time":      {format: "time", valid: []string{"03:04:05+08:00"}, invalid: []string{"25:00:00Z"}},
+		"ipv4":      {format: "ipv4", valid: []string{"127.0.0.1"}, invalid: []string{"::1", "abc"}},
+		"ipv6":      {format: "ipv6", valid: []string{"::1"}, invalid: []string{"127.0.0.1", "abc"}},
+		"unknown":   {format: "hostname", valid: []string{"!"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			doc := []byte(`{"format":"` + tc.format + `"}`)
+			annotation := MustCompile(doc)
+			assertion, err := Compile(doc, WithFormatAssertion())
+			require.Nil(t, err)
+
+			for _, val := range tc.valid {
+				assert.Nil(t, assertion.Validate(val))
+			}
+			for _, val := range tc.invalid {
+				assert.Equal(t, errs.SchemaFormatError, assertion.Validate(val))
+				assert.Nil(t, annotation.Validate(val))
+			}
+			assert.Nil(t, assertion.Validate(1))
+		})
+	}
+}
@@ -0,0 +1,873 @@
+package schema
+
+import (
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+// node is a compiled schema. Its test is filled in after compilation so that
+// references can point at schemas which are still being compiled.
+type node struct {
+	test ttypes.ValTest[any]
+}
+
+func (n *node) validate(val any) error {
+	return n.test(val)
+}
+
+var (
+	pass ttypes.ValTest[any] = func(any) error { return nil }
+	fail ttypes.ValTest[any] = func(any) error { return errs.SchemaFalseError }
+)
+
+// unsupportedKeywords are assertions which are not implemented.
+// Compiling them is an error rather than silently accepting more values than the schema allows.
+var unsupportedKeywords = []string{"$dynamicRef", "$recursiveRef", "unevaluatedItems", "unevaluatedProperties"}
+
+// inPlaceKeywords are the keywords whose subschemas apply to the instance itself, rather than to its items or properties.
+var inPlaceKeywords = map[string]bool{
+	"allOf": true, "anyOf": true, "oneOf": true, "not": true,
+	"if": true, "then": true, "else": true, "dependentSchemas": true,
+}
+
+// compiler compiles a JSON Schema document into a tree of ttypes.ValTest.
+type compiler struct {
+	root         any
+	nodes        map[string]*node
+	anchors      map[string]string
+	inPlace      map[string][]string
+	assertFormat bool
+}
+
+func newCompiler(root any) *compiler {
+	c := &compiler{root: root, nodes: make(map[string]*node), anchors: make(map[string]string), inPlace: make(map[string][]string)}
+	c.collectAnchors(root, "", "")
+	return c
+}
+
+// collectAnchors records the location of every $anchor in the document, keyed by its schema resource.
+func (c *compiler) collectAnchors(s any, ptr, resource string) {
+	obj, ok := s.(map[string]any)
+	if !ok {
+		return
+	}
+	if _, ok := obj["$id"]; ok && ptr != "" {
+		resource = ptr
+	}
+	for _, keyword := range []string{"$anchor", "$dynamicAnchor"} {
+		if anchor, ok := obj[keyword].(string); ok {
+			c.anchors[resource+"#"+anchor] = ptr
+		}
+	}
+	forEachSubschema(obj, ptr, func(sub any, subPtr string) {
+		c.collectAnchors(sub, subPtr, resource)
+	})
+}
+
+// forEachSubschema calls fn with every schema directly nested in obj.
+func forEachSubschema(obj map[string]any, ptr string, fn func(any, string)) {
+	for key, val := range obj {
+		keyPtr := ptr + "/" + errs.EscapePointerToken(key)
+		switch key {
+		case "items", "additionalProperties", "contains", "not", "if", "then", "else", "propertyNames":
+			fn(val, keyPtr)
+		case "prefixItems", "allOf", "anyOf", "oneOf":
+			arr, _ := val.([]any)
+			for i, sub := range arr {
+				fn(sub, keyPtr+"/"+strconv.Itoa(i))
+			}
+		case "properties", "patternProperties", "$defs", "dependentSchemas":
+			subs, _ := val.(map[string]any)
+			for name, sub := range subs {
+				fn(sub, keyPtr+"/"+errs.EscapePointerToken(name))
+			}
+		}
+	}
+}
+
+// compile compiles the schema s found at ptr, inside the schema resource found at resource.
+func (c *compiler) compile(s any, ptr, resource string) (*node, error) {
+	if n, ok := c.nodes[ptr]; ok {
+		return n, nil
+	}
+	n := &node{}
+	c.nodes[ptr] = n
+
+	switch v := s.(type) {
+	case bool:
+		n.test = pass
+		if !v {
+			n.test = fail
+		}
+		return n, nil
+	case map[string]any:
+		if _, ok := v["$id"]; ok && ptr != "" {
+			resource = ptr
+		}
+		tests, err := c.keywords(v, ptr, resource)
+		if err != nil {
+			return nil, err
+		}
+		n.test = options.VAnd(tests...)
+		return n, nil
+	default:
+		return nil, invalidf(ptr, "schema must be an object or a boolean")
+	}
+}
+
+// keywords compiles each keyword of a schema object into a test.
+func (c *compiler) keywords(obj map[string]any, ptr, resource string) ([]ttypes.ValTest[any], error) {
+	for _, keyword := range unsupportedKeywords {
+		if _, ok := obj[keyword]; ok {
+			return nil, fmt.Errorf("%w: %q at %q", ErrUnsupported, keyword, ptr)
+		}
+	}
+
+	compilers := []func(map[string]any, string, string) ([]ttypes.ValTest[any], error){
+		c.ref,
+		c.generic,
+		c.numeric,
+		c.strings,
+		c.arrays,
+		c.objects,
+		c.applicators,
+	}
+	var tests []ttypes.ValTest[any]
+	for _, compileFn := range compilers {
+		keywordTests, err := compileFn(obj, ptr, resource)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, keywordTests...)
+	}
+	return tests, nil
+}
+
+// ref compiles $ref. Only references within the document are supported.
+func (c *compiler) ref(obj map[string]any, ptr, resource string) ([]ttypes.ValTest[any], error) {
+	val, ok := obj["$ref"]
+	if !ok {
+		return nil, nil
+	}
+	ref, ok := val.(string)
+	if !ok {
+		return nil, invalidf(ptr, "$ref must be a string")
+	}
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("%w: $ref %q at %q", ErrUnsupported, ref, ptr)
+	}
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, invalidf(ptr, "$ref %q is not a valid uri fragment", ref)
+	}
+
+	targetPtr := resource + fragment
+	if fragment != "" && fragment[0] != '/' {
+		if targetPtr, ok = c.anchors[resource+"#"+fragment]; !ok {
+			return nil, invalidf(ptr, "$ref %q does not match any $anchor", ref)
+		}
+	}
+	target, ok := lookup(c.root, targetPtr)
+	if !ok {
+		return nil, invalidf(ptr, "$ref %q does not resolve", ref)
+	}
+	targetNode, err := c.compile(target, targetPtr, resource)
+	if err != nil {
+		return nil, err
+	}
+	c.inPlace[ptr] = append(c.inPlace[ptr], targetPtr)
+	return []ttypes.ValTest[any]{targetNode.validate}, nil
+}
+
+// checkCycles returns an error if a schema applies itself to the same instance, such as through a $ref
+// to itself or to a schema referring back to it, as validating any value against it would never end.
+func (c *compiler) checkCycles() error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(c.inPlace))
+	var visit func(ptr string) error
+	visit = func(ptr string) error {
+		switch state[ptr] {
+		case visiting:
+			return invalidf(ptr, "schema applies itself to the same instance")
+		case visited:
+			return nil
+		}
+		state[ptr] = visiting
+		for _, next := range c.inPlace[ptr] {
+			if err := visit(next); err != nil {
+				return err
+			}
+		}
+		state[ptr] = visited
+		return nil
+	}
+	for _, ptr := range sortedKeys(c.inPlace) {
+		if err := visit(ptr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generic compiles the keywords which apply to every type.
+func (c *compiler) generic(obj map[string]any, ptr, _ string) ([]ttypes.ValTest[any], error) {
+	var tests []ttypes.ValTest[any]
+	if val, ok := obj["type"]; ok {
+		names, err := typeNames(val, ptr)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, func(val any) error {
+			for _, name := range names {
+				if hasType(val, name) {
+					return nil
+				}
+			}
+			return errs.SchemaTypeError
+		})
+	}
+	if val, ok := obj["enum"]; ok {
+		allowed, ok := val.([]any)
+		if !ok {
+			return nil, invalidf(ptr, "enum must be an array")
+		}
+		tests = append(tests, func(val any) error {
+			for _, elem := range allowed {
+				if jsonEqual(val, elem) {
+					return nil
+				}
+			}
+			return errs.SchemaEnumError
+		})
+	}
+	if constVal, ok := obj["const"]; ok {
+		tests = append(tests, func(val any) error {
+			if !jsonEqual(val, constVal) {
+				return errs.SchemaConstError
+			}
+			return nil
+		})
+	}
+	return tests, nil
+}
+
+// numeric compiles the keywords which apply to numbers.
+func (c *compiler) numeric(obj map[string]any, ptr, _ string) ([]ttypes.ValTest[any], error) {
+	bounds := []struct {
+		keyword string
+		err     error
+		ok      func(cmp int) bool
+	}{
+		{"minimum", errs.SchemaMinimumError, func(cmp int) bool { return cmp >= 0 }},
+		{"maximum", errs.SchemaMaximumError, func(cmp int) bool { return cmp <= 0 }},
+		{"exclusiveMinimum", errs.SchemaExclusiveMinimumError, func(cmp int) bool { return cmp > 0 }},
+		{"exclusiveMaximum", errs.SchemaExclusiveMaximumError, func(cmp int) bool { return cmp < 0 }},
+	}
+
+	var tests []ttypes.ValTest[any]
+	for _, bound := range bounds {
+		val, ok := obj[bound.keyword]
+		if !ok {
+			continue
+		}
+		limit, ok := toRat(val)
+		if !ok {
+			return nil, invalidf(ptr, "%s must be a number", bound.keyword)
+		}
+		err, okFn := bound.err, bound.ok
+		tests = append(tests, onNumber(func(num *big.Rat) error {
+			if !okFn(num.Cmp(limit)) {
+				return err
+			}
+			return nil
+		}))
+	}
+
+	if val, ok := obj["multipleOf"]; ok {
+		divisor, ok := toRat(val)
+		if !ok || divisor.Sign() <= 0 {
+			return nil, invalidf(ptr, "multipleOf must be a number greater than 0")
+		}
+		tests = append(tests, onNumber(func(num *big.Rat) error {
+			if !new(big.Rat).Quo(num, divisor).IsInt() {
+				return errs.SchemaMultipleOfError
+			}
+			return nil
+		}))
+	}
+	return tests, nil
+}
+
+// strings compiles the keywords which apply to strings.
+func (c *compiler) strings(obj map[string]any, ptr, _ string) ([]ttypes.ValTest[any], error) {
+	var tests []ttypes.ValTest[any]
+	if val, ok := obj["minLength"]; ok {
+		minLen, err := nonNegativeInt(val, "minLength", ptr)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, onString(func(str string) error {
+			if utf8.RuneCountInString(str) < minLen {
+				return errs.SchemaMinLengthError
+			}
+			return nil
+		}))
+	}
+	if val, ok := obj["maxLength"]; ok {
+		maxLen, err := nonNegativeInt(val, "maxLength", ptr)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, onString(func(str string) error {
+			if utf8.RuneCountInString(str) > maxLen {
+				return errs.SchemaMaxLengthError
+			}
+			return nil
+		}))
+	}
+	if val, ok := obj["pattern"]; ok {
+		pattern, ok := val.(string)
+		if !ok {
+			return nil, invalidf(ptr, "pattern must be a string")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, invalidf(ptr, "pattern %q is not a valid regular expression", pattern)
+		}
+		tests = append(tests, onString(func(str string) error {
+			if !re.MatchString(str) {
+				return errs.SchemaPatternError
+			}
+			return nil
+		}))
+	}
+	if format, ok := obj["format"].(string); ok && c.assertFormat && formats[format] != nil {
+		isFormat := formats[format]
+		tests = append(tests, onString(func(str string) error {
+			if !isFormat(str) {
+				return errs.SchemaFormatError
+			}
+			return nil
+		}))
+	}
+	return tests, nil
+}
+
+// arrays compiles the keywords which apply to arrays.
+func (c *compiler) arrays(obj map[string]any, ptr, resource string) ([]ttypes.ValTest[any], error) {
+	var tests []ttypes.ValTest[any]
+
+	prefix, err := c.schemaArray(obj, "prefixItems", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	items, err := c.subschema(obj, "items", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != nil || items != nil {
+		tests = append(tests, onArray(func(arr []any) error {
+			for i, elem := range arr {
+				itemNode := items
+				if i < len(prefix) {
+					itemNode = prefix[i]
+				}
+				if itemNode == nil {
+					continue
+				}
+				if err := itemNode.validate(elem); err != nil {
+					return errs.WithPath(strconv.Itoa(i), err)
+				}
+			}
+			return nil
+		}))
+	}
+
+	contains, err := c.subschema(obj, "contains", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	if contains != nil {
+		minContains, maxContains := 1, -1
+		if val, ok := obj["minContains"]; ok {
+			if minContains, err = nonNegativeInt(val, "minContains", ptr); err != nil {
+				return nil, err
+			}
+		}
+		if val, ok := obj["maxContains"]; ok {
+			if maxContains, err = nonNegativeInt(val, "maxContains", ptr); err != nil {
+				return nil, err
+			}
+		}
+		tests = append(tests, onArray(func(arr []any) error {
+			matches := 0
+			for _, elem := range arr {
+				if contains.validate(elem) == nil {
+					matches++
+				}
+			}
+			if matches < minContains {
+				return errs.SchemaContainsError
+			}
+			if maxContains >= 0 && matches > maxContains {
+				return errs.SchemaMaxContainsError
+			}
+			return nil
+		}))
+	}
+
+	if val, ok := obj["minItems"]; ok {
+		minItems, err := nonNegativeInt(val, "minItems", ptr)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, onArray(func(arr []any) error {
+			if len(arr) < minItems {
+				return errs.SchemaMinItemsError
+			}
+			return nil
+		}))
+	}
+	if val, ok := obj["maxItems"]; ok {
+		maxItems, err := nonNegativeInt(val, "maxItems", ptr)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, onArray(func(arr []any) error {
+			if len(arr) > maxItems {
+				return errs.SchemaMaxItemsError
+			}
+			return nil
+		}))
+	}
+	if unique, ok := obj["uniqueItems"].(bool); ok && unique {
+		tests = append(tests, onArray(func(arr []any) error {
+			for i := range arr {
+				for j := i + 1; j < len(arr); j++ {
+					if jsonEqual(arr[i], arr[j]) {
+						return errs.WithPath(strconv.Itoa(j), errs.SchemaUniqueItemsError)
+					}
+				}
+			}
+			return nil
+		}))
+	}
+	return tests, nil
+}
+
+// objects compiles the keywords which apply to objects.
+func (c *compiler) objects(obj map[string]any, ptr, resource string) ([]ttypes.ValTest[any], error) {
+	var tests []ttypes.ValTest[any]
+
+	properties, err := c.schemaMap(obj, "properties", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	patternProperties, err := c.schemaMap(obj, "patternProperties", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	patterns := make(map[string]*regexp.Regexp, len(patternProperties))
+	for pattern := range patternProperties {
+		if patterns[pattern], err = regexp.Compile(pattern); err != nil {
+			return nil, invalidf(ptr, "patternProperties %q is not a valid regular expression", pattern)
+		}
+	}
+	additional, err := c.subschema(obj, "additionalProperties", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	if properties != nil || patternProperties != nil || additional != nil {
+		tests = append(tests, onObject(func(val map[string]any) error {
+			for _, key := range sortedKeys(val) {
+				matched := false
+				if propNode, ok := properties[key]; ok {
+					matched = true
+					if err := propNode.validate(val[key]); err != nil {
+						return errs.WithPath(key, err)
+					}
+				}
+				for _, pattern := range sortedKeys(patterns) {
+					if !patterns[pattern].MatchString(key) {
+						continue
+					}
+					matched = true
+					if err := patternProperties[pattern].validate(val[key]); err != nil {
+						return errs.WithPath(key, err)
+					}
+				}
+				if !matched && additional != nil {
+					if err := additional.validate(val[key]); err != nil {
+						return errs.WithPath(key, err)
+					}
+				}
+			}
+			return nil
+		}))
+	}
+
+	if val, ok := obj["required"]; ok {
+		required, err := stringArray(val, "required", ptr)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, onObject(func(val map[string]any) error {
+			return missingProperty(val, required, errs.SchemaRequiredError)
+		}))
+	}
+	if val, ok := obj["dependentRequired"]; ok {
+		deps, ok := val.(map[string]any)
+		if !ok {
+			return nil, invalidf(ptr, "dependentRequired must be an object")
+		}
+		dependentRequired := make(map[string][]string, len(deps))
+		for key, dep := range deps {
+			if dependentRequired[key], err = stringArray(dep, "dependentRequired", ptr); err != nil {
+				return nil, err
+			}
+		}
+		tests = append(tests, onObject(func(val map[string]any) error {
+			for _, key := range sortedKeys(dependentRequired) {
+				if _, ok := val[key]; !ok {
+					continue
+				}
+				if err := missingProperty(val, dependentRequired[key], errs.SchemaDependentRequiredError); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	}
+	dependentSchemas, err := c.schemaMap(obj, "dependentSchemas", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	if dependentSchemas != nil {
+		tests = append(tests, onObject(func(val map[string]any) error {
+			for _, key := range sortedKeys(dependentSchemas) {
+				if _, ok := val[key]; !ok {
+					continue
+				}
+				if err := dependentSchemas[key].validate(val); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	}
+	propertyNames, err := c.subschema(obj, "propertyNames", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	if propertyNames != nil {
+		tests = append(tests, onObject(func(val map[string]any) error {
+			for _, key := range sortedKeys(val) {
+				if err := propertyNames.validate(key); err != nil {
+					return errs.WithPath(key, err)
+				}
+			}
+			return nil
+		}))
+	}
+
+	if val, ok := obj["minProperties"]; ok {
+		minProps, err := nonNegativeInt(val, "minProperties", ptr)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, onObject(func(val map[string]any) error {
+			if len(val) < minProps {
+				return errs.SchemaMinPropertiesError
+			}
+			return nil
+		}))
+	}
+	if val, ok := obj["maxProperties"]; ok {
+		maxProps, err := nonNegativeInt(val, "maxProperties", ptr)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, onObject(func(val map[string]any) error {
+			if len(val) > maxProps {
+				return errs.SchemaMaxPropertiesError
+			}
+			return nil
+		}))
+	}
+	return tests, nil
+}
+
+// applicators compiles the keywords which combine subschemas, using the option combinators.
+func (c *compiler) applicators(obj map[string]any, ptr, resource string) ([]ttypes.ValTest[any], error) {
+	var tests []ttypes.ValTest[any]
+	combinators := []struct {
+		keyword string
+		combine func(...ttypes.ValTest[any]) ttypes.ValTest[any]
+	}{
+		{"allOf", options.VAnd[any]},
+		{"anyOf", options.VOr[any]},
+		{"oneOf", options.VExactlyOne[any]},
+	}
+	for _, combinator := range combinators {
+		nodes, err := c.schemaArray(obj, combinator.keyword, ptr, resource)
+		if err != nil {
+			return nil, err
+		}
+		if nodes == nil {
+			continue
+		}
+		if len(nodes) == 0 {
+			return nil, invalidf(ptr, "%s must not be empty", combinator.keyword)
+		}
+		subTests := make([]ttypes.ValTest[any], 0, len(nodes))
+		for _, subNode := range nodes {
+			subTests = append(subTests, subNode.validate)
+		}
+		tests = append(tests, combinator.combine(subTests...))
+	}
+
+	not, err := c.subschema(obj, "not", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	if not != nil {
+		tests = append(tests, options.VNot(not.validate, errs.SchemaNotError))
+	}
+
+	ifNode, err := c.subschema(obj, "if", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	thenNode, err := c.subschema(obj, "then", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	elseNode, err := c.subschema(obj, "else", ptr, resource)
+	if err != nil {
+		return nil, err
+	}
+	if ifNode != nil {
+		tests = append(tests, func(val any) error {
+			branch := elseNode
+			if ifNode.validate(val) == nil {
+				branch = thenNode
+			}
+			if branch == nil {
+				return nil
+			}
+			return branch.validate(val)
+		})
+	}
+	return tests, nil
+}
+
+// subschema compiles the schema under keyword, returning nil if it is absent.
+func (c *compiler) subschema(obj map[string]any, keyword, ptr, resource string) (*node, error) {
+	val, ok := obj[keyword]
+	if !ok {
+		return nil, nil
+	}
+	return c.compileSub(val, ptr, keyword, ptr+"/"+keyword, resource)
+}
+
+// compileSub compiles the subschema s under the keyword of the schema at ptr, found at subPtr.
+func (c *compiler) compileSub(s any, ptr, keyword, subPtr, resource string) (*node, error) {
+	if inPlaceKeywords[keyword] {
+		c.inPlace[ptr] = append(c.inPlace[ptr], subPtr)
+	}
+	return c.compile(s, subPtr, resource)
+}
+
+// schemaArray compiles the array of schemas under keyword, returning nil if it is absent.
+func (c *compiler) schemaArray(obj map[string]any, keyword, ptr, resource string) ([]*node, error) {
+	val, ok := obj[keyword]
+	if !ok {
+		return nil, nil
+	}
+	arr, ok := val.([]any)
+	if !ok {
+		return nil, invalidf(ptr, "%s must be an array", keyword)
+	}
+	nodes := make([]*node, 0, len(arr))
+	for i, sub := range arr {
+		subNode, err := c.compileSub(sub, ptr, keyword, ptr+"/"+keyword+"/"+strconv.Itoa(i), resource)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, subNode)
+	}
+	return nodes, nil
+}
+
+// schemaMap compiles the object of schemas under keyword, returning nil if it is absent.
+func (c *compiler) schemaMap(obj map[string]any, keyword, ptr, resource string) (map[string]*node, error) {
+	val, ok := obj[keyword]
+	if !ok {
+		return nil, nil
+	}
+	subs, ok := val.(map[string]any)
+	if !ok {
+		return nil, invalidf(ptr, "%s must be an object", keyword)
+	}
+	nodes := make(map[string]*node, len(subs))
+	for name, sub := range subs {
+		subNode, err := c.compileSub(sub, ptr, keyword, ptr+"/"+keyword+"/"+errs.EscapePointerToken(name), resource)
+		if err != nil {
+			return nil, err
+		}
+		nodes[name] = subNode
+	}
+	return nodes, nil
+}
+
+// lookup resolves a JSON Pointer against a decoded JSON document.
+func lookup(doc any, ptr string) (any, bool) {
+	if ptr == "" {
+		return doc, true
+	}
+	if ptr[0] != '/' {
+		return nil, false
+	}
+	for _, token := range strings.Split(ptr[1:], "/") {
+		token = errs.UnescapePointerToken(token)
+		switch v := doc.(type) {
+		case map[string]any:
+			val, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			doc = val
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			doc = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+// missingProperty returns err at the location of the first property in names which val lacks.
+func missingProperty(val map[string]any, names []string, err error) error {
+	for _, name := range names {
+		if _, ok := val[name]; !ok {
+			return errs.WithPath(name, err)
+		}
+	}
+	return nil
+}
+
+func typeNames(val any, ptr string) ([]string, error) {
+	var names []string
+	switch v := val.(type) {
+	case string:
+		names = []string{v}
+	case []any:
+		for _, name := range v {
+			str, ok := name.(string)
+			if !ok {
+				return nil, invalidf(ptr, "type must be a string or an array of strings")
+			}
+			names = append(names, str)
+		}
+	default:
+		return nil, invalidf(ptr, "type must be a string or an array of strings")
+	}
+	for _, name := range names {
+		switch name {
+		case "null", "boolean", "object", "array", "number", "string", "integer":
+		default:
+			return nil, invalidf(ptr, "unknown type %q", name)
+		}
+	}
+	return names, nil
+}
+
+func stringArray(val any, keyword, ptr string) ([]string, error) {
+	arr, ok := val.([]any)
+	if !ok {
+		return nil, invalidf(ptr, "%s must be an array of strings", keyword)
+	}
+	strs := make([]string, 0, len(arr))
+	for _, elem := range arr {
+		str, ok := elem.(string)
+		if !ok {
+			return nil, invalidf(ptr, "%s must be an array of strings", keyword)
+		}
+		strs = append(strs, str)
+	}
+	return strs, nil
+}
+
+func nonNegativeInt(val any, keyword, ptr string) (int, error) {
+	r, ok := toRat(val)
+	if !ok || !r.IsInt() || r.Sign() < 0 || !r.Num().IsInt64() {
+		return 0, invalidf(ptr, "%s must be a non-negative integer", keyword)
+	}
+	return int(r.Num().Int64()), nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func invalidf(ptr, format string, args ...any) error {
+	return fmt.Errorf("%w: %s at %q", ErrInvalidSchema, fmt.Sprintf(format, args...), ptr)
+}
+
+func onNumber(test func(*big.Rat) error) ttypes.ValTest[any] {
+	return func(val any) error {
+		if num, ok := toRat(val); ok {
+			return test(num)
+		}
+		return nil
+	}
+}
+
+func onString(test func(string) error) ttypes.ValTest[any] {
+	return func(val any) error {
+		if str, ok := val.(string); ok {
+			return test(str)
+		}
+		return nil
+	}
+}
+
+func onArray(test func([]any) error) ttypes.ValTest[any] {
+	return func(val any) error {
+		if arr, ok := val.([]any); ok {
+			return test(arr)
+		}
+		return nil
+	}
+}
+
+func onObject(test func(map[string]any) error) ttypes.ValTest[any] {
+	return func(val any) error {
+		if obj, ok := val.(map[string]any); ok {
+			return test(obj)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	draft2020Uri     = "https://json-schema.org/draft/2020-12/schema"
+	defsPrefix       = "#/$defs/"
+	componentsPrefix = "#/components/schemas/"
+)
+
+// Definitions is a set of named schemas which can refer to each other with Ref.
+// The same definitions are compiled into validators and exported as JSON Schema
+// and OpenAPI documents, so the published documents match the rules enforced.
+type Definitions struct {
+	schemas map[string]*Builder
+}
+
+// NewDefinitions returns an empty set of definitions.
+func NewDefinitions() *Definitions {
+	return &Definitions{schemas: make(map[string]*Builder)}
+}
+
+// Define adds the schema under name, replacing any schema already defined with that name.
+func (d *Definitions) Define(name string, schema *Builder) *Definitions {
+	d.schemas[name] = schema
+	return d
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document for the schema defined as name.
+// Every other definition, and the ones added to the builders with Builder.Define, are included under "$defs"
+// so that references resolve. It returns an error wrapping ErrInvalidSchema if a reference is not defined.
+func (d *Definitions) JSONSchema(name string) ([]byte, error) {
+	root, ok := d.schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not defined", ErrInvalidSchema, name)
+	}
+	defs, err := d.definitions()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := root.render(defsPrefix)
+	doc["$schema"] = draft2020Uri
+	if len(defs) > 0 {
+		doc["$defs"] = renderAll(defs, defsPrefix)
+	}
+	return json.Marshal(doc)
+}
+
+// OpenAPIComponents returns an OpenAPI 3.1 components object with every definition under "schemas".
+// It returns an error wrapping ErrInvalidSchema if a reference is not defined.
+func (d *Definitions) OpenAPIComponents() ([]byte, error) {
+	defs, err := d.definitions()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]any{"schemas": renderAll(defs, componentsPrefix)})
+}
+
+// Compile compiles the schema defined as name, with format assertion enabled.
+func (d *Definitions) Compile(name string) (*Schema, error) {
+	doc, err := d.JSONSchema(name)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(doc, WithFormatAssertion())
+}
+
+// definitions returns every named definition, along with the ones added to the builders with Builder.Define,
+// checking that the references are defined.
+func (d *Definitions) definitions() (map[string]*Builder, error) {
+	defs := make(map[string]*Builder, len(d.schemas))
+	for name, schema := range d.schemas {
+		if name != "" {
+			defs[name] = schema
+		}
+	}
+	names := make([]string, 0, len(d.schemas))
+	for name := range d.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		defs = d.schemas[name].definitions(defs)
+	}
+	roots := make([]*Builder, 0, 1)
+	if root, ok := d.schemas[""]; ok {
+		roots = append(roots, root)
+	}
+	if err := checkRefs(defs, roots...); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Jh123x/go-validate/options"
+)
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// formats are the checks used for "format" when format assertion is enabled.
+var formats = map[string]func(string) bool{
+	"email":     func(s string) bool { return options.VIsValidEmail(s) == nil },
+	"uri":       func(s string) bool { return options.VIsValidURI(s) == nil },
+	"date-time": isTimeFormat(time.RFC3339),
+	"date":      isTimeFormat(time.DateOnly),
+	"time":      isTimeFormat("15:04:05Z07:00"),
+	"uuid":      uuidRegex.MatchString,
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && !strings.Contains(s, ":")
+	},
+	"ipv6": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	},
+}
+
+func isTimeFormat(layout string) func(string) bool {
+	return func(s string) bool {
+		_, err := time.Parse(layout, s)
+		return err == nil
+	}
+}
@@ -0,0 +1,113 @@
+// Package schema compiles JSON Schema (draft 2020-12) documents into validation rules,
+// and exports the schemas defined with its builder as JSON Schema and OpenAPI documents.
+//
+// Compiling a document which uses one of the following features returns ErrUnsupported:
+//   - the "$dynamicRef", "$recursiveRef", "unevaluatedItems" and "unevaluatedProperties" keywords,
+//   - a "$ref" which is not a fragment of the same document, such as a reference to another document or to an "$id".
+//
+// A document whose schema applies itself to the same value, such as {"$ref": "#"}, returns ErrInvalidSchema.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+var (
+	// ErrInvalidSchema is returned when a schema document does not follow JSON Schema draft 2020-12.
+	ErrInvalidSchema = errors.New("invalid schema")
+	// ErrUnsupported is returned when a schema document uses a feature which is not implemented.
+	ErrUnsupported = errors.New("unsupported schema feature")
+)
+
+// Schema is a JSON Schema (draft 2020-12) document compiled into ttypes.ValTest rules.
+type Schema struct {
+	root *node
+}
+
+// CompileOption configures how a schema is compiled.
+type CompileOption func(*compiler)
+
+// WithFormatAssertion makes "format" an assertion instead of an annotation.
+// The formats checked are "email", "uri", "date-time", "date", "time", "uuid", "ipv4" and "ipv6".
+func WithFormatAssertion() CompileOption {
+	return func(c *compiler) { c.assertFormat = true }
+}
+
+// Compile compiles a JSON Schema document.
+func Compile(doc []byte, opts ...CompileOption) (*Schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var root any
+	if err := dec.Decode(&root); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchema, err)
+	}
+	return CompileValue(root, opts...)
+}
+
+// CompileValue compiles a JSON Schema document which has already been decoded.
+func CompileValue(doc any, opts ...CompileOption) (*Schema, error) {
+	c := newCompiler(doc)
+	for _, opt := range opts {
+		opt(c)
+	}
+	root, err := c.compile(doc, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkCycles(); err != nil {
+		return nil, err
+	}
+	return &Schema{root: root}, nil
+}
+
+// MustCompile is like Compile but panics if the document cannot be compiled.
+func MustCompile(doc []byte) *Schema {
+	s, err := Compile(doc)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Validate validates a decoded JSON value against the schema.
+// Errors found inside the value are wrapped in an errs.PathError.
+func (s *Schema) Validate(val any) error {
+	if s == nil {
+		return nil
+	}
+	return s.root.validate(val)
+}
+
+// ValidateJSON decodes a JSON document and validates it against the schema.
+func (s *Schema) ValidateJSON(data []byte) error {
+	if s == nil {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var val any
+	if err := dec.Decode(&val); err != nil {
+		return errs.InvalidJsonError
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errs.InvalidJsonError
+	}
+	return s.root.validate(val)
+}
+
+// ValTest returns the schema as a ttypes.ValTest, so that it can be used with the V options and wrappers.
+func (s *Schema) ValTest() ttypes.ValTest[any] {
+	return s.Validate
+}
+
+// ToOption returns a Validate which validates val against the schema.
+func (s *Schema) ToOption(val any) ttypes.Validate {
+	return func() error { return s.Validate(val) }
+}
@@ -0,0 +1,215 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/wrapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const personSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["name", "age"],
+	"properties": {
+		"name": {"type": "string", "minLength": 1},
+		"age": {"type": "integer", "minimum": 0},
+		"email": {"$ref": "#/$defs/email"},
+		"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true}
+	},
+	"additionalProperties": false,
+	"$defs": {
+		"email": {"type": "string", "pattern": "^[^@]+@[^@]+$"}
+	}
+}`
+
+func TestSchema_ValidateJSON(t *testing.T) {
+	s := MustCompile([]byte(personSchema))
+	tests := map[string]struct {
+		json        string
+		expectedErr error
+	}{
+		"valid": {
+			json: `{"name":"jh123x","age":20,"email":"a@b.c","tags":["a","b"]}`,
+		},
+		"missing required property": {
+			json:        `{"name":"jh123x"}`,
+			expectedErr: errs.PathError{Pointer: "/age", Err: errs.SchemaRequiredError},
+		},
+		"wrong type": {
+			json:        `{"name":"jh123x","age":1.5}`,
+			expectedErr: errs.PathError{Pointer: "/age", Err: errs.SchemaTypeError},
+		},
+		"ref error": {
+			json:        `{"name":"jh123x","age":1,"email":"invalid"}`,
+			expectedErr: errs.PathError{Pointer: "/email", Err: errs.SchemaPatternError},
+		},
+		"nested error": {
+			json:        `{"name":"jh123x","age":1,"tags":["a",1]}`,
+			expectedErr: errs.PathError{Pointer: "/tags/1", Err: errs.SchemaTypeError},
+		},
+		"duplicate items": {
+			json:        `{"name":"jh123x","age":1,"tags":["a","a"]}`,
+			expectedErr: errs.PathError{Pointer: "/tags/1", Err: errs.SchemaUniqueItemsError},
+		},
+		"additional property": {
+			json:        `{"name":"jh123x","age":1,"a/b":1}`,
+			expectedErr: errs.PathError{Pointer: "/a~1b", Err: errs.SchemaFalseError},
+		},
+		"not an object": {
+			json:        `[]`,
+			expectedErr: errs.SchemaTypeError,
+		},
+		"invalid json": {
+			json:        `{"name":`,
+			expectedErr: errs.InvalidJsonError,
+		},
+		"trailing data": {
+			json:        `{} {}`,
+			expectedErr: errs.InvalidJsonError,
+		},
+		"trailing closing delimiter": {
+			json:        `{} }`,
+			expectedErr: errs.InvalidJsonError,
+		},
+		"trailing invalid data": {
+			json:        `{} xx`,
+			expectedErr: errs.InvalidJsonError,
+		},
+		"trailing whitespace": {
+			json: `{"name":"jh123x","age":20} ` + "\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, s.ValidateJSON([]byte(tc.json)))
+		})
+	}
+}
+
+func TestSchema_GoValues(t *testing.T) {
+	s := MustCompile([]byte(`{"type":"array","items":{"type":"integer","maximum":10,"multipleOf":2}}`))
+	tests := map[string]struct {
+		value       any
+		expectedErr error
+	}{
+		"ints": {
+			value: []any{int(2), int8(4), int16(6), int32(8), int64(10), uint(0), uint8(2), uint16(2), uint32(2), uint64(2)},
+		},
+		"floats": {
+			value: []any{float32(2), float64(4)},
+		},
+		"json numbers": {
+			value: []any{json.Number("2"), json.Number("4.0")},
+		},
+		"fraction": {
+			value:       []any{2.5},
+			expectedErr: errs.PathError{Pointer: "/0", Err: errs.SchemaTypeError},
+		},
+		"too large": {
+			value:       []any{json.Number("12")},
+			expectedErr: errs.PathError{Pointer: "/0", Err: errs.SchemaMaximumError},
+		},
+		"not a multiple": {
+			value:       []any{3},
+			expectedErr: errs.PathError{Pointer: "/0", Err: errs.SchemaMultipleOfError},
+		},
+		"unsupported go type": {
+			value:       []any{[]int{1}},
+			expectedErr: errs.PathError{Pointer: "/0", Err: errs.SchemaTypeError},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, s.Validate(tc.value))
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := map[string]struct {
+		schema      string
+		expectedErr error
+	}{
+		"not json":                  {schema: `{`, expectedErr: ErrInvalidSchema},
+		"not a schema":              {schema: `1`, expectedErr: ErrInvalidSchema},
+		"unknown type":              {schema: `{"type":"float"}`, expectedErr: ErrInvalidSchema},
+		"type not a string":         {schema: `{"type":1}`, expectedErr: ErrInvalidSchema},
+		"type array not strings":    {schema: `{"type":[1]}`, expectedErr: ErrInvalidSchema},
+		"enum not an array":         {schema: `{"enum":1}`, expectedErr: ErrInvalidSchema},
+		"minimum not a number":      {schema: `{"minimum":"1"}`, expectedErr: ErrInvalidSchema},
+		"multipleOf zero":           {schema: `{"multipleOf":0}`, expectedErr: ErrInvalidSchema},
+		"negative minLength":        {schema: `{"minLength":-1}`, expectedErr: ErrInvalidSchema},
+		"fractional maxLength":      {schema: `{"maxLength":1.5}`, expectedErr: ErrInvalidSchema},
+		"invalid pattern":           {schema: `{"pattern":"("}`, expectedErr: ErrInvalidSchema},
+		"pattern not a string":      {schema: `{"pattern":1}`, expectedErr: ErrInvalidSchema},
+		"invalid patternProperties": {schema: `{"patternProperties":{"(":{}}}`, expectedErr: ErrInvalidSchema},
+		"properties not an object":  {schema: `{"properties":[]}`, expectedErr: ErrInvalidSchema},
+		"allOf not an array":        {schema: `{"allOf":{}}`, expectedErr: ErrInvalidSchema},
+		"empty anyOf":               {schema: `{"anyOf":[]}`, expectedErr: ErrInvalidSchema},
+		"required not strings":      {schema: `{"required":[1]}`, expectedErr: ErrInvalidSchema},
+		"required not an array":     {schema: `{"required":"a"}`, expectedErr: ErrInvalidSchema},
+		"invalid dependentRequired": {schema: `{"dependentRequired":[]}`, expectedErr: ErrInvalidSchema},
+		"invalid dependency":        {schema: `{"dependentRequired":{"a":"b"}}`, expectedErr: ErrInvalidSchema},
+		"invalid subschema":         {schema: `{"items":1}`, expectedErr: ErrInvalidSchema},
+		"invalid nested subschema":  {schema: `{"allOf":[{"not":1}]}`, expectedErr: ErrInvalidSchema},
+		"invalid minContains":       {schema: `{"contains":{},"minContains":"1"}`, expectedErr: ErrInvalidSchema},
+		"invalid maxContains":       {schema: `{"contains":{},"maxContains":-1}`, expectedErr: ErrInvalidSchema},
+		"ref not a string":          {schema: `{"$ref":1}`, expectedErr: ErrInvalidSchema},
+		"ref does not resolve":      {schema: `{"$ref":"#/$defs/a"}`, expectedErr: ErrInvalidSchema},
+		"ref into array":            {schema: `{"$ref":"#/allOf/1","allOf":[{}]}`, expectedErr: ErrInvalidSchema},
+		"ref into scalar":           {schema: `{"$ref":"#/minimum/a","minimum":1}`, expectedErr: ErrInvalidSchema},
+		"ref not a pointer":         {schema: `{"$ref":"#a%zz"}`, expectedErr: ErrInvalidSchema},
+		"unknown anchor":            {schema: `{"$ref":"#a"}`, expectedErr: ErrInvalidSchema},
+		"ref to itself":             {schema: `{"$ref":"#"}`, expectedErr: ErrInvalidSchema},
+		"ref cycle":                 {schema: `{"$defs":{"a":{"$ref":"#/$defs/a"}},"$ref":"#/$defs/a"}`, expectedErr: ErrInvalidSchema},
+		"ref cycle through allOf":   {schema: `{"$defs":{"a":{"allOf":[{"$ref":"#/$defs/b"}]},"b":{"not":{"$ref":"#/$defs/a"}}},"$ref":"#/$defs/b"}`, expectedErr: ErrInvalidSchema},
+		"nested ref cycle":          {schema: `{"properties":{"a":{"$ref":"#/properties/a/anyOf/0","anyOf":[{"$ref":"#/properties/a"}]}}}`, expectedErr: ErrInvalidSchema},
+		"remote ref":                {schema: `{"$ref":"http://example.com/schema"}`, expectedErr: ErrUnsupported},
+		"unevaluatedProperties":     {schema: `{"unevaluatedProperties":false}`, expectedErr: ErrUnsupported},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := Compile([]byte(tc.schema))
+			assert.Nil(t, s)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+// TestCompile_RecursiveRef tests that a schema can refer to itself for the values inside of the instance.
+func TestCompile_RecursiveRef(t *testing.T) {
+	s, err := Compile([]byte(`{"$defs":{"node":{"type":"object","properties":{"next":{"$ref":"#/$defs/node"}}}},"allOf":[{"$ref":"#/$defs/node"}]}`))
+	require.Nil(t, err)
+	assert.Nil(t, s.ValidateJSON([]byte(`{"next":{"next":{}}}`)))
+	assert.Equal(t, errs.PathError{Pointer: "/next/next", Err: errs.SchemaTypeError}, s.ValidateJSON([]byte(`{"next":{"next":1}}`)))
+}
+
+func TestMustCompile_Panics(t *testing.T) {
+	assert.Panics(t, func() { MustCompile([]byte(`{"type":"float"}`)) })
+}
+
+func TestSchema_Nil(t *testing.T) {
+	var s *Schema
+	assert.Nil(t, s.Validate(1))
+	assert.Nil(t, s.ValidateJSON([]byte(`1`)))
+	assert.Nil(t, s.ToOption(1)())
+}
+
+func TestSchema_Options(t *testing.T) {
+	s, err := CompileValue(map[string]any{"type": "string", "maxLength": 3})
+	require.Nil(t, err)
+
+	assert.Nil(t, s.ToOption("abc")())
+	assert.Equal(t, errs.SchemaMaxLengthError, s.ToOption("abcd")())
+
+	valueWrapper := wrapper.NewValueWrapper[any]().WithOptions(s.ValTest())
+	assert.Nil(t, valueWrapper.Validate("abc"))
+	assert.Equal(t, errs.SchemaTypeError, valueWrapper.Validate(1))
+}
@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// suiteDir contains files from the official JSON-Schema-Test-Suite (https://github.com/json-schema-org/JSON-Schema-Test-Suite).
+const suiteDir = "testdata/draft2020-12"
+
+// The optional features which Compile reports as ErrUnsupported, see the package documentation.
+const (
+	uriRefs     = "$ref to a URI resolved against $id"
+	remoteRefs  = "$ref to a remote document"
+	unevaluated = "unevaluatedProperties"
+)
+
+// skippedSuiteGroups are the groups of the suite which use one of the unsupported features, by file and description.
+// The files of the keywords which are not supported at all, such as unevaluatedItems.json, are not vendored.
+var skippedSuiteGroups = map[string]map[string]string{
+	"not.json": {
+		"collect annotations inside a 'not', even if collection is disabled": unevaluated,
+	},
+	"ref.json": {
+		"remote ref, containing refs itself":                                     remoteRefs,
+		"Recursive references between schemas":                                   uriRefs,
+		"ref creates new scope when adjacent to keywords":                        unevaluated,
+		"refs with relative uris and defs":                                       uriRefs,
+		"relative refs with absolute uris and defs":                              uriRefs,
+		"$id must be resolved against nearest parent, not just immediate parent": uriRefs,
+		"order of evaluation: $id and $ref":                                      uriRefs,
+		"simple URN base URI with $ref via the URN":                              uriRefs,
+		"URN base URI with URN and JSON pointer ref":                             uriRefs,
+		"URN base URI with URN and anchor ref":                                   uriRefs,
+		"URN ref with nested pointer ref":                                        uriRefs,
+		"ref to if":                                                              uriRefs,
+		"ref to then":                                                            uriRefs,
+		"ref to else":                                                            uriRefs,
+		"ref with absolute-path-reference":                                       uriRefs,
+	},
+}
+
+type suiteGroup struct {
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+	Tests       []struct {
+		Description string          `json:"description"`
+		Data        json.RawMessage `json:"data"`
+		Valid       bool            `json:"valid"`
+	} `json:"tests"`
+}
+
+// TestOfficialSuite runs the vendored JSON Schema test suite.
+// Only the groups listed in skippedSuiteGroups are skipped, and they must be reported as ErrUnsupported.
+func TestOfficialSuite(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(suiteDir, "*.json"))
+	require.Nil(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		name := filepath.Base(file)
+		t.Run(strings.TrimSuffix(name, ".json"), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.Nil(t, err)
+			var groups []suiteGroup
+			require.Nil(t, json.Unmarshal(data, &groups))
+
+			for _, group := range groups {
+				group := group
+				t.Run(group.Description, func(t *testing.T) {
+					s, err := Compile(group.Schema)
+					if feature, ok := skippedSuiteGroups[name][group.Description]; ok {
+						require.ErrorIs(t, err, ErrUnsupported)
+						t.Skip(feature)
+					}
+					require.Nil(t, err)
+
+					for _, tc := range group.Tests {
+						dec := json.NewDecoder(bytes.NewReader(tc.Data))
+						dec.UseNumber()
+						var val any
+						require.Nil(t, dec.Decode(&val))
+						err := s.Validate(val)
+						assert.Equal(t, tc.Valid, err == nil, "%s: %v", tc.Description, err)
+					}
+				})
+			}
+		})
+	}
+}
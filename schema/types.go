@@ -0,0 +1,194 @@
+package schema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// For returns a builder for the schema of the JSON encoding of the values of type T, as FromType does.
+func For[T any]() *Builder {
+	return FromType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// FromType returns a builder for the schema of the JSON encoding of the values of type t, as encoding/json encodes them:
+//   - a struct is an object with a property for each exported field, named after its json tag. A property is required
+//     unless its tag has the "omitempty" option, and fields tagged "-" are left out. The fields of embedded structs
+//     without a name in their tag are properties of the object.
+//   - a named struct is defined under "$defs" with its type name and referred to, so that recursive types are described.
+//   - pointers, interfaces, slices and maps allow null, []byte is a string, and a map is an object.
+//   - time.Time is a "date-time" string, a type implementing encoding.TextMarshaler is a string,
+//     and a type implementing json.Marshaler accepts any value.
+//
+// The builder can be refined with its methods, such as with bounds, before being exported or compiled.
+func FromType(t reflect.Type) *Builder {
+	r := &reflector{root: newBuilder(""), names: make(map[reflect.Type]string), taken: make(map[string]bool)}
+	b := r.schema(t)
+	if b.ref != "" && !b.nullable {
+		// A named struct is described in place, and defined with its name as well in case it refers to itself.
+		b = r.root.defs[b.ref]
+	}
+	for name, def := range r.root.defs {
+		b.Define(name, def)
+	}
+	return b
+}
+
+// reflector builds the schemas of types, holding the definitions of the named structs.
+type reflector struct {
+	root  *Builder
+	names map[reflect.Type]string
+	taken map[string]bool
+}
+
+func (r *reflector) schema(t reflect.Type) *Builder {
+	switch {
+	case t == timeType:
+		return String().Format("date-time")
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return Any()
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return String()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Integer()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Integer().Minimum(0)
+	case reflect.Float32, reflect.Float64:
+		return Number()
+	case reflect.String:
+		return String()
+	case reflect.Pointer:
+		return r.schema(t.Elem()).Nullable()
+	case reflect.Interface:
+		return Any()
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return String().Nullable()
+		}
+		return Array(r.schema(t.Elem())).Nullable()
+	case reflect.Array:
+		return Array(r.schema(t.Elem())).MinItems(t.Len()).MaxItems(t.Len())
+	case reflect.Map:
+		return Object().Nullable()
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.object(t)
+		}
+		return Ref(r.define(t))
+	}
+	return Any()
+}
+
+// define defines the named struct under "$defs" once, returning its name.
+func (r *reflector) define(t reflect.Type) string {
+	if name, ok := r.names[t]; ok {
+		return name
+	}
+	name := strings.Map(func(c rune) rune {
+		if c == '_' || c == '-' || c == '.' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+			return c
+		}
+		return '_'
+	}, t.Name())
+	for i := 2; r.taken[name]; i++ {
+		name = strings.TrimSuffix(name, "_"+strconv.Itoa(i-1)) + "_" + strconv.Itoa(i)
+	}
+	r.names[t] = name
+	r.taken[name] = true
+	def := Object()
+	r.root.Define(name, def)
+	r.fields(def, t, true)
+	return name
+}
+
+func (r *reflector) object(t reflect.Type) *Builder {
+	b := Object()
+	r.fields(b, t, true)
+	return b
+}
+
+// fields adds the fields of the struct to the object, as required properties if required is set.
+// The properties already added are kept, as the fields of embedded structs are hidden by the fields of the outer struct.
+func (r *reflector) fields(b *Builder, t reflect.Type, required bool) {
+	embedded := make([]reflect.StructField, 0)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				embedded = append(embedded, field)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := b.properties[name]; ok {
+			continue
+		}
+		fieldSchema := r.schema(field.Type)
+		if hasOption(opts, "string") && isQuotable(field.Type) {
+			fieldSchema = String()
+		}
+		b.Property(name, fieldSchema)
+		if required && !hasOption(opts, "omitempty") {
+			b.Required(name)
+		}
+	}
+	for _, field := range embedded {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			r.fields(b, fieldType.Elem(), false)
+			continue
+		}
+		r.fields(b, fieldType, required)
+	}
+}
+
+// hasOption reports whether the options of a json tag hold option.
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isQuotable reports whether the "string" option of a json tag applies to the type.
+func isQuotable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAudit struct {
+	CreatedAt time.Time `json:"created_at"`
+	Note      string    `json:"note,omitempty"`
+}
+
+type testCategory struct {
+	Name   string        `json:"name"`
+	Parent *testCategory `json:"parent,omitempty"`
+}
+
+type testProduct struct {
+	testAudit
+	ID         uint              `json:"id"`
+	Name       string            `json:"name"`
+	Price      float64           `json:"price,string"`
+	Tags       []string          `json:"tags,omitempty"`
+	Code       [2]int            `json:"code"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Category   *testCategory     `json:"category"`
+	Data       []byte            `json:"data,omitempty"`
+	Extra      any               `json:"extra,omitempty"`
+	Raw        json.RawMessage   `json:"raw,omitempty"`
+	Internal   string            `json:"-"`
+	Untagged   bool
+	unexported int
+}
+
+func TestFor(t *testing.T) {
+	doc, err := json.Marshal(For[testProduct]())
+	require.Nil(t, err)
+
+	category := `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string"},
+			"parent": {"anyOf": [{"$ref": "#/$defs/testCategory"}, {"type": "null"}]}
+		}
+	}`
+	product := `
+		"type": "object",
+		"required": ["id", "name", "price", "code", "category", "Untagged", "created_at"],
+		"properties": {
+			"created_at": {"type": "string", "format": "date-time"},
+			"note": {"type": "string"},
+			"id": {"type": "integer", "minimum": 0},
+			"name": {"type": "string"},
+			"price": {"type": "string"},
+			"tags": {"type": ["array", "null"], "items": {"type": "string"}},
+			"code": {"type": "array", "items": {"type": "integer"}, "minItems": 2, "maxItems": 2},
+			"labels": {"type": ["object", "null"]},
+			"category": {"anyOf": [{"$ref": "#/$defs/testCategory"}, {"type": "null"}]},
+			"data": {"type": ["string", "null"]},
+			"extra": {},
+			"raw": {},
+			"Untagged": {"type": "boolean"}
+		}`
+	expected := fmt.Sprintf(`{%s, "$defs": {"testProduct": {%s}, "testCategory": %s}}`, product, product, category)
+	assert.JSONEq(t, expected, string(doc))
+}
+
+func TestFor_NotAStruct(t *testing.T) {
+	doc, err := json.Marshal(For[[]*testCategory]())
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"type": ["array", "null"],
+		"items": {"anyOf": [{"$ref": "#/$defs/testCategory"}, {"type": "null"}]},
+		"$defs": {
+			"testCategory": {
+				"type": "object",
+				"required": ["name"],
+				"properties": {
+					"name": {"type": "string"},
+					"parent": {"anyOf": [{"$ref": "#/$defs/testCategory"}, {"type": "null"}]}
+				}
+			}
+		}
+	}`, string(doc))
+}
+
+func TestFor_RoundTrip(t *testing.T) {
+	s, err := For[testProduct]().Compile()
+	require.Nil(t, err)
+
+	product := testProduct{
+		testAudit: testAudit{CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		ID:        1,
+		Name:      "pen",
+		Price:     1.5,
+		Tags:      []string{"a"},
+		Category:  &testCategory{Name: "office", Parent: &testCategory{Name: "root"}},
+		Data:      []byte("data"),
+		Raw:       json.RawMessage(`[1]`),
+	}
+	encoded, err := json.Marshal(product)
+	require.Nil(t, err)
+	assert.Nil(t, s.ValidateJSON(encoded))
+
+	encoded, err = json.Marshal(testProduct{})
+	require.Nil(t, err)
+	assert.Nil(t, s.ValidateJSON(encoded))
+
+	tests := map[string]struct {
+		json        string
+		expectedErr error
+	}{
+		"missing property": {
+			json:        `{"id":1,"name":"pen","price":"1","code":[1,2],"category":null,"Untagged":true}`,
+			expectedErr: errs.PathError{Pointer: "/created_at", Err: errs.SchemaRequiredError},
+		},
+		"invalid nested property": {
+			json:        `{"created_at":"2024-01-02T03:04:05Z","id":1,"name":"pen","price":"1","code":[1,2],"category":{"name":"a","parent":{"name":1}},"Untagged":true}`,
+			expectedErr: errs.PathError{Pointer: "/category", Err: errs.OrError},
+		},
+		"negative unsigned": {
+			json:        `{"created_at":"2024-01-02T03:04:05Z","id":-1,"name":"pen","price":"1","code":[1,2],"category":null,"Untagged":true}`,
+			expectedErr: errs.PathError{Pointer: "/id", Err: errs.SchemaMinimumError},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, s.ValidateJSON([]byte(tc.json)))
+		})
+	}
+}
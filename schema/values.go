@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// typeOf returns the JSON Schema type name of a decoded JSON value.
+// Integers are reported as "number"; use isInteger to tell them apart.
+func typeOf(val any) string {
+	switch val.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	if _, ok := toRat(val); ok {
+		return "number"
+	}
+	return ""
+}
+
+// hasType reports whether val is an instance of the JSON Schema type name.
+func hasType(val any, name string) bool {
+	switch name {
+	case "integer":
+		return isInteger(val)
+	case "number":
+		return typeOf(val) == "number"
+	default:
+		return typeOf(val) == name
+	}
+}
+
+// isInteger reports whether val is a number with no fractional part.
+func isInteger(val any) bool {
+	r, ok := toRat(val)
+	return ok && r.IsInt()
+}
+
+// toRat converts a decoded JSON number into an exact rational.
+func toRat(val any) (*big.Rat, bool) {
+	switch v := val.(type) {
+	case json.Number:
+		return new(big.Rat).SetString(v.String())
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(v), true
+	case float32:
+		return toRat(float64(v))
+	case int:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int8:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int16:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int32:
+		return new(big.Rat).SetInt64(int64(v)), true
+	case int64:
+		return new(big.Rat).SetInt64(v), true
+	case uint:
+		return new(big.Rat).SetUint64(uint64(v)), true
+	case uint8:
+		return new(big.Rat).SetUint64(uint64(v)), true
+	case uint16:
+		return new(big.Rat).SetUint64(uint64(v)), true
+	case uint32:
+		return new(big.Rat).SetUint64(uint64(v)), true
+	case uint64:
+		return new(big.Rat).SetUint64(v), true
+	}
+	return nil, false
+}
+
+// jsonEqual reports whether two decoded JSON values are equal.
+// Numbers are compared by value, so 1 and 1.0 are equal.
+func jsonEqual(a, b any) bool {
+	if ra, ok := toRat(a); ok {
+		rb, ok := toRat(b)
+		return ok && ra.Cmp(rb) == 0
+	}
+
+	switch av := a.(type) {
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, val := range av {
+			other, ok := bv[key]
+			if !ok || !jsonEqual(val, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
@@ -0,0 +1,143 @@
+// Package trace explains a validation, returning a tree of the rules it evaluated which mirrors
+// how they are composed, with the input, outcome, error and duration of each of them.
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/internal/scope"
+)
+
+// Node is a rule evaluated during a validation.
+// Rules without a name, such as custom options, are named after their position ("#0").
+type Node struct {
+	Name        string
+	Description string
+	Input       any
+	Passed      bool
+	Err         error
+	Duration    time.Duration
+	Children    []*Node
+}
+
+// Explain evaluates the rule, returning the trace of the rules it evaluated.
+// The rules evaluated by custom options, such as a validator called by the option, are not traced.
+func Explain(name string, rule func() error) *Node {
+	root := &Node{Name: name}
+	start := time.Now()
+	err := scope.New([]scope.Observer{&tracer{}}, []any{root}).Eval(rule)
+	root.exit(err, time.Since(start))
+	return root
+}
+
+// ExplainValue evaluates the rule on val, returning the trace of the rules it evaluated.
+func ExplainValue[T any](name string, val T, rule func(T) error) *Node {
+	root := &Node{Name: name, Input: val}
+	start := time.Now()
+	err := scope.EvalValue(scope.New([]scope.Observer{&tracer{}}, []any{root}), val, rule)
+	root.exit(err, time.Since(start))
+	return root
+}
+
+// tracer builds the tree of nodes.
+type tracer struct {
+	mu sync.Mutex
+}
+
+// Enter adds the node of the rule to its parent.
+func (t *tracer) Enter(parent any, rule scope.Rule, input any) any {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	node := parent.(*Node)
+	child := &Node{Name: rule.Name, Description: rule.Description, Input: input}
+	if child.Name == "" {
+		child.Name = "#" + strconv.Itoa(len(node.Children))
+	}
+	node.Children = append(node.Children, child)
+	return child
+}
+
+// Exit records the outcome of the rule.
+func (t *tracer) Exit(token any, err error, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	token.(*Node).exit(err, duration)
+}
+
+func (n *Node) exit(err error, duration time.Duration) {
+	n.Err = err
+	n.Passed = errs.OnlyErrors(err) == nil
+	n.Duration = duration
+}
+
+// String renders the tree as indented text, one rule per line.
+func (n *Node) String() string {
+	var b strings.Builder
+	n.write(&b, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (n *Node) write(b *strings.Builder, depth int) {
+	b.WriteString(strings.Repeat("  ", depth))
+	b.WriteString(n.status())
+	b.WriteByte(' ')
+	b.WriteString(n.Name)
+	if n.Description != "" {
+		fmt.Fprintf(b, " %q", n.Description)
+	}
+	if n.Input != nil {
+		fmt.Fprintf(b, " input=%v", n.Input)
+	}
+	fmt.Fprintf(b, " (%s)", n.Duration)
+	if n.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(n.Err.Error())
+	}
+	b.WriteByte('\n')
+	for _, child := range n.Children {
+		child.write(b, depth+1)
+	}
+}
+
+// status returns PASS, FAIL, or WARN for rules which only found warnings and infos.
+func (n *Node) status() string {
+	switch {
+	case !n.Passed:
+		return "FAIL"
+	case n.Err != nil:
+		return "WARN"
+	default:
+		return "PASS"
+	}
+}
+
+// MarshalJSON marshals the tree, with the error as its message and the duration in nanoseconds.
+// Inputs which cannot be marshalled are written as text.
+func (n *Node) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		Input       json.RawMessage `json:"input,omitempty"`
+		Passed      bool            `json:"passed"`
+		Error       string          `json:"error,omitempty"`
+		DurationNS  int64           `json:"duration_ns"`
+		Children    []*Node         `json:"children,omitempty"`
+	}{Name: n.Name, Description: n.Description, Passed: n.Passed, DurationNS: n.Duration.Nanoseconds(), Children: n.Children}
+	if n.Input != nil {
+		input, err := json.Marshal(n.Input)
+		if err != nil {
+			input, _ = json.Marshal(fmt.Sprint(n.Input))
+		}
+		out.Input = input
+	}
+	if n.Err != nil {
+		out.Error = n.Err.Error()
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,234 @@
+package trace
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var durationRe = regexp.MustCompile(`\([^()]*s\)`)
+
+// outline returns the text of the node without durations.
+func outline(n *Node) string {
+	return durationRe.ReplaceAllString(n.String(), "(-)")
+}
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func TestExplain(t *testing.T) {
+	tests := map[string]struct {
+		node     func() *Node
+		expected string
+	}{
+		"passed": {
+			node: func() *Node {
+				return Explain("root", options.And(options.IsNotEmpty("a"), options.IsEmpty("")))
+			},
+			expected: "PASS root (-)\n" +
+				"  PASS And (-)\n" +
+				"    PASS IsNotEmpty (-)\n" +
+				"    PASS IsEmpty (-)",
+		},
+		"failed branches": {
+			node: func() *Node {
+				return Explain("root", options.Or(options.IsEmpty("a"), options.IsLength([]int{}, 1, 2)))
+			},
+			expected: "FAIL root (-): " + errs.OrError.Error() + "\n" +
+				"  FAIL Or (-): " + errs.OrError.Error() + "\n" +
+				"    FAIL IsEmpty (-): " + errs.IsEmptyError.Error() + "\n" +
+				"    FAIL IsLength (-): " + errs.InvalidLengthError.Error(),
+		},
+		"custom error": {
+			node: func() *Node {
+				return Explain("root", options.ExactlyOne(options.IsEmpty(""), options.WithRequire(func() bool { return false }, assert.AnError)))
+			},
+			expected: "PASS root (-)\n" +
+				"  PASS ExactlyOne (-)\n" +
+				"    PASS IsEmpty (-)\n" +
+				"    FAIL #1 (-): " + assert.AnError.Error(),
+		},
+		"warnings": {
+			node: func() *Node {
+				return Explain("root", options.And(options.Warn(options.IsEmpty("a"))))
+			},
+			expected: "WARN root (-): " + errs.IsEmptyError.Error() + "\n" +
+				"  WARN And (-): " + errs.IsEmptyError.Error() + "\n" +
+				"    WARN Warn (-): " + errs.IsEmptyError.Error() + "\n" +
+				"      FAIL IsEmpty (-): " + errs.IsEmptyError.Error(),
+		},
+		"values": {
+			node: func() *Node {
+				return ExplainValue("user", user{Name: "jh", Age: 1}, options.VAll(
+					options.VField("name", func(u user) string { return u.Name }, options.VIsStringLength(1, 5)),
+					options.VField("age", func(u user) int { return u.Age }, options.VNot(options.VIsInRange(0, 17), errs.OutOfRangeError)),
+				))
+			},
+			expected: "FAIL user input={jh 1} (-): /age: " + errs.OutOfRangeError.Error() + "\n" +
+				"  FAIL VAll input={jh 1} (-): /age: " + errs.OutOfRangeError.Error() + "\n" +
+				"    PASS /name input=jh (-)\n" +
+				"      PASS VIsStringLength input=jh (-)\n" +
+				"    FAIL /age input=1 (-): " + errs.OutOfRangeError.Error() + "\n" +
+				"      FAIL VNot input=1 (-): " + errs.OutOfRangeError.Error() + "\n" +
+				"        PASS VIsInRange input=1 (-)",
+		},
+		"value combinators": {
+			node: func() *Node {
+				return ExplainValue("root", 1, options.VOr(options.VExactlyOne(options.VIsDefault[int]()), options.VAnd(options.VIsNotDefault[int]())))
+			},
+			expected: "PASS root input=1 (-)\n" +
+				"  PASS VOr input=1 (-)\n" +
+				"    FAIL VExactlyOne input=1 (-): " + errs.ExactlyOneError.Error() + "\n" +
+				"      FAIL VIsDefault input=1 (-): " + errs.IsDefaultErr.Error() + "\n" +
+				"    PASS VAnd input=1 (-)\n" +
+				"      PASS VIsNotDefault input=1 (-)",
+		},
+		"named": {
+			node: func() *Node {
+				return ExplainValue("root", 1, options.VDescribed("age-adult", "must be 18 or over", options.VIsInRange(18, 150)))
+			},
+			expected: "FAIL root input=1 (-): [age-adult] " + errs.OutOfRangeError.Error() + "\n" +
+				"  FAIL age-adult \"must be 18 or over\" input=1 (-): " + errs.OutOfRangeError.Error() + "\n" +
+				"    FAIL VIsInRange input=1 (-): " + errs.OutOfRangeError.Error(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, outline(tc.node()))
+		})
+	}
+}
+
+// tree returns the names of the node and of its descendants, with "!" after the rules which failed.
+func tree(n *Node) string {
+	name := n.Name
+	if !n.Passed {
+		name += "!"
+	}
+	if len(n.Children) == 0 {
+		return name
+	}
+	children := make([]string, 0, len(n.Children))
+	for _, child := range n.Children {
+		children = append(children, tree(child))
+	}
+	return name + "(" + strings.Join(children, " ") + ")"
+}
+
+// TestExplain_FailingOr tests that the operands of a failing Or are traced as its children,
+// with the options they are composed of as their own children.
+func TestExplain_FailingOr(t *testing.T) {
+	node := Explain("root", options.Or(
+		options.And(options.IsNotEmpty("a"), options.IsEmpty("b")),
+		options.IsNotEmpty("a").Not(errs.IsEmptyError),
+		options.ExactlyOne(options.IsEmpty(""), options.IsEmpty(0)),
+	))
+
+	assert.Equal(t, "root!(Or!(And!(IsNotEmpty IsEmpty!) Not!(IsNotEmpty) ExactlyOne!(IsEmpty IsEmpty)))", tree(node))
+	or := node.Children[0]
+	assert.Equal(t, errs.OrError, or.Err)
+	assert.Equal(t, errs.IsEmptyError, or.Children[0].Err)
+	assert.Equal(t, errs.IsEmptyError, or.Children[1].Err)
+	assert.Equal(t, errs.ExactlyOneError, or.Children[2].Err)
+}
+
+// TestExplain_Nested tests that the rules evaluated by a custom option are only traced by the trace they are explained in.
+func TestExplain_Nested(t *testing.T) {
+	var inner *Node
+	outer := Explain("outer", func() error {
+		inner = Explain("inner", options.Or(options.IsEmpty("")))
+		return nil
+	})
+	assert.Equal(t, "PASS inner (-)\n  PASS Or (-)\n    PASS IsEmpty (-)", outline(inner))
+	assert.Equal(t, "PASS outer (-)\n  PASS #0 (-)", outline(outer))
+}
+
+func TestNode_MarshalJSON(t *testing.T) {
+	node := &Node{
+		Name:     "root",
+		Input:    map[string]any{"a": 1},
+		Err:      errs.IsEmptyError,
+		Duration: time.Microsecond,
+		Children: []*Node{
+			{Name: "#0", Input: func() {}, Passed: true, Duration: time.Nanosecond},
+		},
+	}
+	data, err := json.Marshal(node)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"name": "root",
+		"input": {"a": 1},
+		"passed": false,
+		"error": "`+errs.IsEmptyError.Error()+`",
+		"duration_ns": 1000,
+		"children": [{"name": "#0", "input": "`+"0x"+`", "passed": true, "duration_ns": 1}]
+	}`, regexp.MustCompile(`"0x[0-9a-f]+"`).ReplaceAllString(string(data), `"0x"`))
+}
+
+// TestExplain_BuiltinNames tests that the built-in options are named after themselves, whether they pass or fail.
+func TestExplain_BuiltinNames(t *testing.T) {
+	email := "jh@example.com"
+	tests := map[string]func() *Node{
+		"IsNotEmpty":            func() *Node { return Explain("root", options.IsNotEmpty("a")) },
+		"IsEmpty":               func() *Node { return Explain("root", options.IsEmpty("a")) },
+		"IsLength":              func() *Node { return Explain("root", options.IsLength([]int{1}, 1, 2)) },
+		"IsInRange":             func() *Node { return Explain("root", options.IsInRange(1, 2, 3)) },
+		"Contains":              func() *Node { return Explain("root", options.Contains([]int{1}, 1)) },
+		"IsStringLength":        func() *Node { return Explain("root", options.IsStringLength("a", 1, 2)) },
+		"Matches":               func() *Node { return Explain("root", options.Matches("a", regexp.MustCompile("b"))) },
+		"IsValidURI":            func() *Node { return Explain("root", options.IsValidURI("https://example.com")) },
+		"IsValidJson":           func() *Node { return Explain("root", options.IsValidJson("{")) },
+		"IsValidEmail":          func() *Node { return Explain("root", options.IsValidEmail(email)) },
+		"IsJsonKind":            func() *Node { return Explain("root", options.IsJsonKind("{}", options.JsonObject)) },
+		"IsJsonNoDuplicateKeys": func() *Node { return Explain("root", options.IsJsonNoDuplicateKeys(`{"a":1,"a":2}`)) },
+		"OneOf":                 func() *Node { return Explain("root", options.OneOf(1, 2, 3)) },
+		"NoneOfFold":            func() *Node { return Explain("root", options.NoneOfFold("A", "a")) },
+		"IsValidPassword": func() *Node {
+			return Explain("root", options.IsValidPassword("a", options.PasswordPolicy{MinLength: 2}))
+		},
+		"VIsNotDefault":          func() *Node { return ExplainValue("root", 1, options.VIsNotDefault[int]()) },
+		"VIsDefault":             func() *Node { return ExplainValue("root", 1, options.VIsDefault[int]()) },
+		"VIsEmpty":               func() *Node { return ExplainValue("root", []int{1}, options.VIsEmpty[int]) },
+		"VIsNotEmpty":            func() *Node { return ExplainValue("root", []int{1}, options.VIsNotEmpty[int]) },
+		"VIsLength":              func() *Node { return ExplainValue("root", []int{1}, options.VIsLength[int](1, 2)) },
+		"VIsInRange":             func() *Node { return ExplainValue("root", 1, options.VIsInRange(2, 3)) },
+		"VContains":              func() *Node { return ExplainValue("root", []int{1}, options.VContains(1)) },
+		"VIsStringLength":        func() *Node { return ExplainValue("root", "a", options.VIsStringLength(2, 3)) },
+		"VMatches":               func() *Node { return ExplainValue("root", "a", options.VMatches(regexp.MustCompile("a"))) },
+		"VIsValidURI":            func() *Node { return ExplainValue("root", "a", options.VIsValidURI) },
+		"VIsValidJson":           func() *Node { return ExplainValue("root", "{}", options.VIsValidJson) },
+		"VIsValidEmail":          func() *Node { return ExplainValue("root", email, options.VIsValidEmail) },
+		"VHasJsonKeys":           func() *Node { return ExplainValue("root", "{}", options.VHasJsonKeys("a")) },
+		"VIsJsonMaxDepth":        func() *Node { return ExplainValue("root", "{}", options.VIsJsonMaxDepth(1)) },
+		"VOneOf":                 func() *Node { return ExplainValue("root", 1, options.VOneOf(2)) },
+		"VNoneOfFold":            func() *Node { return ExplainValue("root", "a", options.VNoneOfFold("b")) },
+		"Enum":                   func() *Node { return ExplainValue("root", "c", options.NewEnum("a", "b").Validate) },
+		"VUnique":                func() *Node { return ExplainValue("root", []int{1, 1}, options.VUnique[int]()) },
+		"VSorted":                func() *Node { return ExplainValue("root", []int{1, 2}, options.VSorted[int]()) },
+		"VSubsetOf":              func() *Node { return ExplainValue("root", []int{1}, options.VSubsetOf(1)) },
+		"VNoNil":                 func() *Node { return ExplainValue("root", []*int{nil}, options.VNoNil[int]) },
+		"VNil":                   func() *Node { return ExplainValue[*int]("root", nil, options.VNil[int]) },
+		"VNotNil":                func() *Node { return ExplainValue[*int]("root", nil, options.VNotNil[int]) },
+		"VIsValidPassword":       func() *Node { return ExplainValue("root", "a", options.VIsValidPassword(options.PasswordPolicy{})) },
+		"VIsJsonNoTrailingData":  func() *Node { return ExplainValue("root", "{} 1", options.VIsJsonNoTrailingData) },
+		"VIsJsonNoDuplicateKeys": func() *Node { return ExplainValue("root", "{}", options.VIsJsonNoDuplicateKeys) },
+	}
+
+	for name, node := range tests {
+		t.Run(name, func(t *testing.T) {
+			root := node()
+			require.Len(t, root.Children, 1)
+			assert.Equal(t, name, root.Children[0].Name)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package transform provides transforms normalising strings before they are validated,
+// such as with the WithTransforms method of wrapper.ValueValidator.
+package transform
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Jh123x/go-validate/ttypes"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	_ ttypes.Transform[string] = TrimSpace
+	_ ttypes.Transform[string] = ToLower
+	_ ttypes.Transform[string] = ToUpper
+	_ ttypes.Transform[string] = CollapseSpace
+	_ ttypes.Transform[string] = StripControl
+	_ ttypes.Transform[string] = NFC
+	_ ttypes.Transform[string] = NFKC
+)
+
+// TrimSpace removes the leading and trailing white space of s.
+func TrimSpace(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// ToLower maps the letters of s to lower case.
+func ToLower(s string) string {
+	return strings.ToLower(s)
+}
+
+// ToUpper maps the letters of s to upper case.
+func ToUpper(s string) string {
+	return strings.ToUpper(s)
+}
+
+// CollapseSpace replaces each run of white space in s with a single space, and trims the white space around s.
+func CollapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// StripControl removes the control characters of s, including tabs and newlines.
+func StripControl(s string) string {
+	if strings.IndexFunc(s, unicode.IsControl) < 0 {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// NFC returns s in the Unicode normalization form C, in which characters such as "e" followed by
+// a combining acute accent are composed into "é".
+func NFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// NFKC returns s in the Unicode normalization form KC, which also replaces compatibility characters
+// such as "ｆｕｌｌｗｉｄｔｈ" and "ﬁ" by their canonical equivalents, "fullwidth" and "fi".
+func NFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// Chain returns the transform applying each of the transforms in order.
+func Chain[T any](transforms ...ttypes.Transform[T]) ttypes.Transform[T] {
+	return func(val T) T {
+		for _, transform := range transforms {
+			if transform != nil {
+				val = transform(val)
+			}
+		}
+		return val
+	}
+}
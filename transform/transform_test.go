@@ -0,0 +1,41 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransforms(t *testing.T) {
+	tests := map[string]struct {
+		transform ttypes.Transform[string]
+		input     string
+		expected  string
+	}{
+		"TrimSpace":                 {transform: TrimSpace, input: " \t test \n", expected: "test"},
+		"ToLower":                   {transform: ToLower, input: "TeSt ÉCOLE", expected: "test école"},
+		"ToUpper":                   {transform: ToUpper, input: "TeSt école", expected: "TEST ÉCOLE"},
+		"CollapseSpace":             {transform: CollapseSpace, input: "  a \t b\n\nc  ", expected: "a b c"},
+		"CollapseSpace empty":       {transform: CollapseSpace, input: " \t ", expected: ""},
+		"StripControl":              {transform: StripControl, input: "a\x00b\tc\nd\u200be\x7f", expected: "abcd\u200be"},
+		"StripControl unchanged":    {transform: StripControl, input: "école", expected: "école"},
+		"NFC composes":              {transform: NFC, input: "e\u0301cole", expected: "\u00e9cole"},
+		"NFC keeps compatibility":   {transform: NFC, input: "\ufb01le", expected: "\ufb01le"},
+		"NFKC composes":             {transform: NFKC, input: "e\u0301cole", expected: "\u00e9cole"},
+		"NFKC replaces ligatures":   {transform: NFKC, input: "\ufb01le", expected: "file"},
+		"NFKC replaces full widths": {transform: NFKC, input: "ｔｅｓｔ１", expected: "test1"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.transform(tc.input))
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	chain := Chain[string](NFKC, nil, CollapseSpace, ToLower)
+	assert.Equal(t, "test user", chain("  ＴＥＳＴ \t User "))
+	assert.Equal(t, "test", Chain[string]()("test"))
+}
@@ -12,6 +12,13 @@ type Validator[T any] interface {
 	Validate() error
 }
 
+// Ordered is a type which supports the ordering operators.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
 // VTest
 type VTest[T any] func(T) bool
 
@@ -25,3 +32,6 @@ type ValValidator[T any] interface {
 	Validate() error
 	ToOption() Validate
 }
+
+// Transform returns the value of type T normalised, such as a string with its spaces trimmed.
+type Transform[T any] func(T) T
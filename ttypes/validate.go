@@ -1,25 +1,55 @@
 package ttypes
 
+import (
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/internal/scope"
+)
+
 // Validate returns true, nil if the validation passes, false, error otherwise.
 // By default, the error returned is a Validate
 type Validate func() error
 
 // WithError changes the error returned by the validation.
+// A validation returning only warnings and infos passes, so its result is returned unchanged.
 func (v Validate) WithError(err error) Validate {
 	return func() error {
-		if oldErr := v(); oldErr != nil {
+		oldErr := v()
+		if errs.OnlyErrors(oldErr) != nil {
 			return err
 		}
-		return nil
+		return oldErr
 	}
 }
 
 // Not changes the error returned by the validation to the provided error.
+// A validation returning only warnings and infos passes, so Not returns the provided error.
 func (v Validate) Not(err error) Validate {
-	return func() error {
-		if oldErr := v(); oldErr != nil {
-			return nil
+	return scope.Option(not{option: v, err: err})
+}
+
+// not is the node of Not.
+type not struct {
+	option Validate
+	err    error
+}
+
+func (not) Rule() scope.Rule { return scope.Rule{Name: "Not"} }
+
+func (n not) Evaluate(s *scope.Scope) error {
+	if oldErr := s.Eval(n.option); errs.OnlyErrors(oldErr) != nil {
+		return nil
+	}
+	return n.err
+}
+
+// WithError changes the error returned by the test.
+// A test returning only warnings and infos passes, so its result is returned unchanged.
+func (v ValTest[T]) WithError(err error) ValTest[T] {
+	return func(val T) error {
+		oldErr := v(val)
+		if errs.OnlyErrors(oldErr) != nil {
+			return err
 		}
-		return err
+		return oldErr
 	}
 }
@@ -3,6 +3,7 @@ package ttypes
 import (
 	"testing"
 
+	"github.com/Jh123x/go-validate/errs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,11 +29,21 @@ func TestValidate_WithError(t *testing.T) {
 			withError:   errTest2,
 			expectedErr: nil,
 		},
+		"WithError keeps only warnings": {
+			validate:    func() error { return errs.WithSeverity(errs.SeverityWarning, errTest) },
+			withError:   errTest2,
+			expectedErr: errs.WithSeverity(errs.SeverityWarning, errTest),
+		},
+		"WithError replaces warnings with an error": {
+			validate:    func() error { return errs.Errors{errs.WithSeverity(errs.SeverityWarning, errTest), errTest} },
+			withError:   errTest2,
+			expectedErr: errTest2,
+		},
 	}
 
 	for testName, testCase := range tests {
 		t.Run(testName, func(t *testing.T) {
-			validateFn := testCase.validate.WithError(testCase.expectedErr)
+			validateFn := testCase.validate.WithError(testCase.withError)
 			assert.Equal(t, testCase.expectedErr, validateFn())
 		})
 	}
@@ -55,6 +66,11 @@ func TestValidate_Not(t *testing.T) {
 			err:         errTest,
 			expectedErr: errTest,
 		},
+		"Not on warning validate should throw error": {
+			validate:    func() error { return errs.WithSeverity(errs.SeverityWarning, errTest2) },
+			err:         errTest,
+			expectedErr: errTest,
+		},
 	}
 
 	for testName, testCase := range tests {
@@ -64,3 +80,35 @@ func TestValidate_Not(t *testing.T) {
 		})
 	}
 }
+
+// TestValTest_WithError tests the WithError method of ValTest.
+func TestValTest_WithError(t *testing.T) {
+	tests := map[string]struct {
+		valTest     ValTest[int]
+		withError   error
+		expectedErr error
+	}{
+		"WithError replaces the error": {
+			valTest:     func(int) error { return errTest },
+			withError:   errTest2,
+			expectedErr: errTest2,
+		},
+		"WithError returns no error when the test passes": {
+			valTest:     func(int) error { return nil },
+			withError:   errTest2,
+			expectedErr: nil,
+		},
+		"WithError keeps only infos": {
+			valTest:     func(int) error { return errs.WithSeverity(errs.SeverityInfo, errTest) },
+			withError:   errTest2,
+			expectedErr: errs.WithSeverity(errs.SeverityInfo, errTest),
+		},
+	}
+
+	for testName, testCase := range tests {
+		t.Run(testName, func(t *testing.T) {
+			valTest := testCase.valTest.WithError(testCase.withError)
+			assert.Equal(t, testCase.expectedErr, valTest(1))
+		})
+	}
+}
@@ -1,10 +1,19 @@
 package validator
 
-import "github.com/Jh123x/go-validate/ttypes"
+import (
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
+	"github.com/Jh123x/go-validate/internal/scope"
+	"github.com/Jh123x/go-validate/trace"
+	"github.com/Jh123x/go-validate/ttypes"
+)
 
 // LazyValidator is a validator that lazily evaluates the options provided.
 type LazyValidator struct {
-	options []ttypes.Validate
+	options   []ttypes.Validate
+	formatter errs.Formatter
+	hooks     []hooks.Hooks
+	memo      bool
 }
 
 var _ ttypes.Validator[LazyValidator] = (*LazyValidator)(nil)
@@ -24,15 +33,79 @@ func (l *LazyValidator) WithOptions(opts ...ttypes.Validate) *LazyValidator {
 	return &newValidator
 }
 
+// WithFormatter returns a new LazyValidator whose errors are rendered by the formatter.
+func (l *LazyValidator) WithFormatter(formatter errs.Formatter) *LazyValidator {
+	if l == nil {
+		return nil
+	}
+	newValidator := *l
+	newValidator.formatter = formatter
+	return &newValidator
+}
+
+// WithHooks returns a new LazyValidator calling the hooks around each rule it evaluates.
+func (l *LazyValidator) WithHooks(h hooks.Hooks) *LazyValidator {
+	if l == nil {
+		return nil
+	}
+	newValidator := *l
+	newValidator.hooks = append(l.hooks[:len(l.hooks):len(l.hooks)], h)
+	return &newValidator
+}
+
+// WithMemo returns a new LazyValidator evaluating each memoized rule at most once per validation,
+// such as the options wrapped in options.Memo and the named options.
+func (l *LazyValidator) WithMemo() *LazyValidator {
+	if l == nil {
+		return nil
+	}
+	newValidator := *l
+	newValidator.memo = true
+	return &newValidator
+}
+
 // Validate validates the options provided.
+// Warnings and infos are not returned, and can be retrieved with Check.
 func (l *LazyValidator) Validate() error {
+	return l.validate(nil)
+}
+
+// validate validates the options in the scope, with the hooks and memoization of the validator.
+func (l *LazyValidator) validate(s *scope.Scope) error {
 	if l == nil {
 		return nil
 	}
+	return run(s, l.memo, l.hooks, l.evaluate)
+}
+
+func (l *LazyValidator) evaluate(s *scope.Scope) error {
 	for _, opt := range l.options {
-		if err := opt(); err != nil {
-			return err
+		if err := errs.OnlyErrors(s.Eval(opt)); err != nil {
+			return errs.Formatted(err, l.formatter)
 		}
 	}
 	return nil
 }
+
+// Check evaluates the options until the first error, returning it along with the warnings and infos found.
+func (l *LazyValidator) Check() errs.Result {
+	var result errs.Result
+	if l == nil {
+		return result
+	}
+	_ = run(nil, l.memo, l.hooks, func(s *scope.Scope) error {
+		for _, opt := range l.options {
+			result = result.Merge(errs.NewResult(s.Eval(opt)))
+			if !result.Valid() {
+				break
+			}
+		}
+		return nil
+	})
+	return result
+}
+
+// Explain validates the options provided, returning the trace of the rules evaluated.
+func (l *LazyValidator) Explain() *trace.Node {
+	return trace.Explain("LazyValidator", scope.Option(node(l.validate)))
+}
@@ -1,9 +1,15 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
 	"github.com/Jh123x/go-validate/options"
 	"github.com/Jh123x/go-validate/ttypes"
 	"github.com/stretchr/testify/assert"
@@ -65,3 +71,127 @@ func TestLazyValidator_ReadMeExample(t *testing.T) {
 	).Validate()
 	assert.Equal(t, fmt.Errorf("empty string"), err)
 }
+
+// TestLazyValidator_WithFormatter tests that the formatter renders the errors of the LazyValidator.
+func TestLazyValidator_WithFormatter(t *testing.T) {
+	validator := NewLazyValidator().WithFormatter(errs.CompactFormatter)
+	assert.Nil(t, validator.WithOptions(validateWNil).Validate())
+
+	err := validator.WithOptions(validateWNil, options.IsNotEmpty("")).WithOptions(validateWErr).Validate()
+	assert.Equal(t, "is_not_empty", err.Error())
+	assert.True(t, errors.Is(err, errs.IsNotEmptyErr))
+
+	assert.Equal(t, errTest, validator.WithOptions(validateWErr).WithFormatter(nil).Validate())
+	assert.Nil(t, (*LazyValidator)(nil).WithFormatter(errs.CompactFormatter))
+}
+
+// TestLazyValidator_Check tests that warnings and infos do not fail the LazyValidator.
+func TestLazyValidator_Check(t *testing.T) {
+	warning := errs.WithSeverity(errs.SeverityWarning, errs.IsEmptyError)
+	info := errs.WithSeverity(errs.SeverityInfo, errs.IsDefaultErr)
+	validator := NewLazyValidator().WithOptions(
+		options.Warn(options.IsEmpty("a")),
+		options.Info(func() error { return errs.IsDefaultErr }),
+		validateWNil,
+	)
+	assert.Nil(t, validator.Validate())
+	assert.Equal(t, errs.Result{Warnings: errs.Errors{warning}, Infos: errs.Errors{info}}, validator.Check())
+
+	validator = validator.WithOptions(validateWErr, options.Warn(options.IsEmpty("a")), options.IsNotEmpty(""))
+	assert.Equal(t, errTest, validator.Validate())
+	assert.Equal(t, errs.Result{Errors: errs.Errors{errTest}, Warnings: errs.Errors{warning}, Infos: errs.Errors{info}}, validator.Check())
+	assert.Equal(t, errs.Result{}, (*LazyValidator)(nil).Check())
+}
+
+// TestLazyValidator_Explain tests that the trace lists the options in order.
+func TestLazyValidator_Explain(t *testing.T) {
+	node := NewLazyValidator().WithOptions(
+		options.IsNotEmpty("a"),
+		options.Or(options.IsEmpty("a"), options.IsLength([]int{}, 1, 2)),
+	).Explain()
+
+	assert.Equal(t, "LazyValidator", node.Name)
+	assert.False(t, node.Passed)
+	assert.Equal(t, errs.OrError, node.Err)
+	assert.Len(t, node.Children, 2)
+	assert.Equal(t, "IsNotEmpty", node.Children[0].Name)
+	assert.True(t, node.Children[0].Passed)
+	assert.Equal(t, "Or", node.Children[1].Name)
+	assert.Len(t, node.Children[1].Children, 2)
+	assert.Equal(t, "IsEmpty", node.Children[1].Children[0].Name)
+	assert.Equal(t, "IsLength", node.Children[1].Children[1].Name)
+	assert.True(t, strings.HasPrefix(node.String(), "FAIL LazyValidator ("))
+
+	assert.True(t, (*LazyValidator)(nil).Explain().Passed)
+}
+
+// TestLazyValidator_WithHooks tests that the hooks are called for each named rule evaluated.
+func TestLazyValidator_WithHooks(t *testing.T) {
+	var mu sync.Mutex
+	failures := map[string]int{}
+	counter := hooks.Hooks{OnFailure: func(rule hooks.Rule, _ error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures[rule.Name]++
+	}}
+	validator := NewLazyValidator().WithOptions(
+		options.IsNotEmpty("a"),
+		options.Named("nickname", options.Or(options.IsEmpty("a"), options.IsEmpty("b"))),
+	)
+	withHooks := validator.WithHooks(counter).WithHooks(counter)
+
+	assert.ErrorIs(t, withHooks.Validate(), errs.OrError)
+	assert.False(t, withHooks.Check().Valid())
+	assert.ErrorIs(t, validator.Validate(), errs.OrError)
+	assert.Equal(t, map[string]int{"nickname": 4, "Or": 4, "IsEmpty": 8}, failures)
+
+	node := withHooks.Explain()
+	assert.Equal(t, "nickname", node.Children[1].Name)
+	assert.Equal(t, map[string]int{"nickname": 6, "Or": 6, "IsEmpty": 12}, failures)
+	assert.Nil(t, (*LazyValidator)(nil).WithHooks(counter))
+}
+
+// TestLazyValidator_WithMemo_PerValidation tests that the memoized and named options are evaluated once
+// in each validation, and again in the next one.
+func TestLazyValidator_WithMemo_PerValidation(t *testing.T) {
+	var memoCalls, namedCalls atomic.Int32
+	memo := options.Memo(func() error {
+		memoCalls.Add(1)
+		return nil
+	})
+	named := func() ttypes.Validate {
+		return options.Named("expensive", func() error {
+			namedCalls.Add(1)
+			return nil
+		})
+	}
+	validator := NewLazyValidator().WithOptions(memo, named(), options.And(memo, named())).WithMemo()
+
+	assert.Nil(t, validator.Validate())
+	assert.Equal(t, int32(1), memoCalls.Load())
+	assert.Equal(t, int32(1), namedCalls.Load())
+
+	assert.Nil(t, validator.Validate())
+	assert.Equal(t, int32(2), memoCalls.Load())
+	assert.Equal(t, int32(2), namedCalls.Load())
+}
+
+// TestLazyValidator_WithMemo tests that memoized options are evaluated once per validation.
+func TestLazyValidator_WithMemo(t *testing.T) {
+	var calls atomic.Int32
+	expensive := options.Memo(func() error {
+		calls.Add(1)
+		return nil
+	})
+	validator := NewLazyValidator().WithOptions(expensive, options.Or(options.IsEmpty("a"), expensive), expensive)
+
+	assert.Nil(t, validator.Validate())
+	assert.Equal(t, int32(3), calls.Swap(0))
+
+	memoized := validator.WithMemo()
+	assert.Nil(t, memoized.Validate())
+	assert.Nil(t, memoized.WithHooks(hooks.Hooks{}).Validate())
+	assert.True(t, memoized.Check().Valid())
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Nil(t, (*LazyValidator)(nil).WithMemo())
+}
@@ -1,6 +1,10 @@
 package validator
 
 import (
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
+	"github.com/Jh123x/go-validate/internal/scope"
+	"github.com/Jh123x/go-validate/trace"
 	"github.com/Jh123x/go-validate/ttypes"
 	lop "github.com/gozelle/lo/parallel"
 )
@@ -10,7 +14,10 @@ var (
 )
 
 type ParallelLazyValidator struct {
-	options []ttypes.Validate
+	options   []ttypes.Validate
+	formatter errs.Formatter
+	hooks     []hooks.Hooks
+	memo      bool
 }
 
 var _ ttypes.Validator[ParallelLazyValidator] = (*ParallelLazyValidator)(nil)
@@ -30,17 +37,90 @@ func (l *ParallelLazyValidator) WithOptions(opts ...ttypes.Validate) *ParallelLa
 	return &newValidator
 }
 
+// WithFormatter returns a new ParallelLazyValidator whose errors are rendered by the formatter.
+func (l *ParallelLazyValidator) WithFormatter(formatter errs.Formatter) *ParallelLazyValidator {
+	if l == nil {
+		return nil
+	}
+	newValidator := *l
+	newValidator.formatter = formatter
+	return &newValidator
+}
+
+// WithHooks returns a new ParallelLazyValidator calling the hooks around each rule it evaluates.
+func (l *ParallelLazyValidator) WithHooks(h hooks.Hooks) *ParallelLazyValidator {
+	if l == nil {
+		return nil
+	}
+	newValidator := *l
+	newValidator.hooks = append(l.hooks[:len(l.hooks):len(l.hooks)], h)
+	return &newValidator
+}
+
+// WithMemo returns a new ParallelLazyValidator evaluating each memoized rule at most once per validation,
+// such as the options wrapped in options.Memo and the named options.
+func (l *ParallelLazyValidator) WithMemo() *ParallelLazyValidator {
+	if l == nil {
+		return nil
+	}
+	newValidator := *l
+	newValidator.memo = true
+	return &newValidator
+}
+
 // Validate validates the options provided.
+// Warnings and infos are not returned, and can be retrieved with Check.
 func (l *ParallelLazyValidator) Validate() error {
+	return l.validate(nil)
+}
+
+// validate validates the options in the scope, with the hooks and memoization of the validator.
+func (l *ParallelLazyValidator) validate(s *scope.Scope) error {
 	if l == nil {
 		return nil
 	}
+	return run(s, l.memo, l.hooks, l.evaluate)
+}
 
-	for _, err := range lop.Map(l.options, mapperFn) {
-		if err != nil {
-			return err
+func (l *ParallelLazyValidator) evaluate(s *scope.Scope) error {
+	for _, err := range evaluate(s, l.options) {
+		if err = errs.OnlyErrors(err); err != nil {
+			return errs.Formatted(err, l.formatter)
 		}
 	}
 
 	return nil
 }
+
+// Check evaluates every option in parallel, returning all of the errors, warnings and infos found in order.
+func (l *ParallelLazyValidator) Check() errs.Result {
+	var result errs.Result
+	if l == nil {
+		return result
+	}
+	_ = run(nil, l.memo, l.hooks, func(s *scope.Scope) error {
+		for _, err := range evaluate(s, l.options) {
+			result = result.Merge(errs.NewResult(err))
+		}
+		return nil
+	})
+	return result
+}
+
+// Explain validates the options provided, returning the trace of the rules evaluated.
+func (l *ParallelLazyValidator) Explain() *trace.Node {
+	return trace.Explain("ParallelLazyValidator", scope.Option(node(l.validate)))
+}
+
+// evaluate runs the options in parallel in the scope.
+// Observers of the validation are notified of the options in order, before they run.
+func evaluate(s *scope.Scope, options []ttypes.Validate) []error {
+	if s != nil {
+		forks := make([]ttypes.Validate, 0, len(options))
+		for _, opt := range options {
+			forks = append(forks, s.Fork(opt))
+		}
+		options = forks
+	}
+	return lop.Map(options, mapperFn)
+}
@@ -0,0 +1,213 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
+	"github.com/Jh123x/go-validate/internal/scope"
+	"github.com/Jh123x/go-validate/trace"
+	"github.com/Jh123x/go-validate/ttypes"
+	lop "github.com/gozelle/lo/parallel"
+)
+
+// Mode is how a Typed validator evaluates its options.
+type Mode int
+
+const (
+	// Lazy evaluates the options in order until the first error, which is returned.
+	Lazy Mode = iota
+	// Eager evaluates every option in order, returning the first error.
+	Eager
+	// Parallel evaluates every option in parallel, returning the first error in order.
+	Parallel
+	// CollectAll evaluates every option in order, returning all of the errors as errs.Errors.
+	CollectAll
+)
+
+// String returns the name of the mode.
+func (m Mode) String() string {
+	switch m {
+	case Lazy:
+		return "Lazy"
+	case Eager:
+		return "Eager"
+	case Parallel:
+		return "Parallel"
+	case CollectAll:
+		return "CollectAll"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// Typed is a validator of values of type T.
+// Unlike LazyValidator, its options take the value being validated, so it is built once and reused.
+type Typed[T any] struct {
+	options   []ttypes.ValTest[T]
+	mode      Mode
+	formatter errs.Formatter
+	hooks     []hooks.Hooks
+	memo      bool
+}
+
+// NewTyped returns a new Typed validator evaluating its options in the mode.
+func NewTyped[T any](mode Mode) *Typed[T] {
+	return &Typed[T]{mode: mode}
+}
+
+// WithOptions returns a new Typed validator with the given options.
+func (v *Typed[T]) WithOptions(opts ...ttypes.ValTest[T]) *Typed[T] {
+	if v == nil {
+		return nil
+	}
+	newValidator := *v
+	newValidator.options = append(v.options[:len(v.options):len(v.options)], opts...)
+	return &newValidator
+}
+
+// WithFormatter returns a new Typed validator whose errors are rendered by the formatter.
+func (v *Typed[T]) WithFormatter(formatter errs.Formatter) *Typed[T] {
+	if v == nil {
+		return nil
+	}
+	newValidator := *v
+	newValidator.formatter = formatter
+	return &newValidator
+}
+
+// WithHooks returns a new Typed validator calling the hooks around each rule it evaluates.
+func (v *Typed[T]) WithHooks(h hooks.Hooks) *Typed[T] {
+	if v == nil {
+		return nil
+	}
+	newValidator := *v
+	newValidator.hooks = append(v.hooks[:len(v.hooks):len(v.hooks)], h)
+	return &newValidator
+}
+
+// Mode returns the mode of the validator.
+func (v *Typed[T]) Mode() Mode {
+	if v == nil {
+		return Lazy
+	}
+	return v.mode
+}
+
+// WithMemo returns a new Typed validator evaluating each memoized rule at most once per validation,
+// such as the options wrapped in options.Memo and the named options.
+func (v *Typed[T]) WithMemo() *Typed[T] {
+	if v == nil {
+		return nil
+	}
+	newValidator := *v
+	newValidator.memo = true
+	return &newValidator
+}
+
+// Validate validates val with the options provided, according to the mode of the validator.
+// Warnings and infos are not returned, and can be retrieved with Check.
+func (v *Typed[T]) Validate(val T) error {
+	return v.validate(nil, val)
+}
+
+// validate validates val in the scope, with the hooks and memoization of the validator.
+func (v *Typed[T]) validate(s *scope.Scope, val T) error {
+	if v == nil {
+		return nil
+	}
+	if len(v.hooks) == 0 && !v.memo {
+		// The options are evaluated without run, whose closure would allocate.
+		return errs.Formatted(v.evaluateAll(s, val), v.formatter)
+	}
+	return errs.Formatted(run(s, v.memo, v.hooks, func(s *scope.Scope) error { return v.evaluateAll(s, val) }), v.formatter)
+}
+
+func (v *Typed[T]) evaluateAll(s *scope.Scope, val T) error {
+	if v.mode == Parallel {
+		for _, err := range v.evaluate(s, val) {
+			if err = errs.OnlyErrors(err); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// The options are evaluated in place rather than with evaluate, so that valid values do not allocate.
+	var first error
+	var found errs.Errors
+	for _, opt := range v.options {
+		err := errs.OnlyErrors(scope.EvalValue(s, val, opt))
+		switch {
+		case err == nil:
+		case v.mode == Lazy:
+			return err
+		case v.mode == CollectAll:
+			found = append(found, err)
+		case first == nil:
+			first = err
+		}
+	}
+	if len(found) > 0 {
+		return found
+	}
+	return first
+}
+
+// Check validates val, returning the errors, warnings and infos found.
+// A Lazy validator stops at the first error.
+func (v *Typed[T]) Check(val T) errs.Result {
+	var result errs.Result
+	if v == nil {
+		return result
+	}
+	_ = run(nil, v.memo, v.hooks, func(s *scope.Scope) error {
+		if v.mode == Lazy {
+			for _, opt := range v.options {
+				result = result.Merge(errs.NewResult(scope.EvalValue(s, val, opt)))
+				if !result.Valid() {
+					break
+				}
+			}
+			return nil
+		}
+		for _, err := range v.evaluate(s, val) {
+			result = result.Merge(errs.NewResult(err))
+		}
+		return nil
+	})
+	return result
+}
+
+// Explain validates val, returning the trace of the rules evaluated.
+func (v *Typed[T]) Explain(val T) *trace.Node {
+	return trace.ExplainValue("Typed", val, scope.ValueOption[T](valueNode[T](v.validate)))
+}
+
+// ToOption returns the option validating val with the validator.
+func (v *Typed[T]) ToOption(val T) ttypes.Validate {
+	return func() error { return v.Validate(val) }
+}
+
+// evaluate runs every option on val in the scope, in parallel for the Parallel mode.
+func (v *Typed[T]) evaluate(s *scope.Scope, val T) []error {
+	if v.mode == Parallel {
+		options := make([]ttypes.Validate, 0, len(v.options))
+		for _, opt := range v.options {
+			options = append(options, scope.ForkValue(s, val, opt))
+		}
+		return lop.Map(options, mapperFn)
+	}
+	results := make([]error, 0, len(v.options))
+	for _, opt := range v.options {
+		results = append(results, scope.EvalValue(s, val, opt))
+	}
+	return results
+}
+
+// valueNode evaluates a validator of values in the scope of its trace, whose options are reported in its place.
+type valueNode[T any] func(s *scope.Scope, val T) error
+
+func (valueNode[T]) Rule() scope.Rule { return scope.Rule{} }
+
+func (n valueNode[T]) Evaluate(s *scope.Scope, val T) error { return n(s, val) }
@@ -0,0 +1,199 @@
+package validator
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/ttypes"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	rangeErr    = errs.OutOfRangeError.WithParams(map[string]any{"min": 0, "max": 5})
+	warnDefault = errs.WithSeverity(errs.SeverityWarning, errs.IsDefaultErr)
+)
+
+// TestTyped tests the Typed validator in each mode.
+func TestTyped(t *testing.T) {
+	var calls atomic.Int32
+	count := func(int) error {
+		calls.Add(1)
+		return nil
+	}
+	opts := []ttypes.ValTest[int]{
+		options.VWarn(options.VIsDefault[int]()),
+		options.VIsInRange(0, 5),
+		count,
+		options.VIsDefault[int](),
+		count,
+	}
+
+	tests := map[string]struct {
+		mode           Mode
+		val            int
+		expectedErr    error
+		expectedCalls  int32 // Over Validate, ToOption and Check.
+		expectedResult errs.Result
+	}{
+		"lazy valid": {
+			mode:          Lazy,
+			expectedCalls: 6,
+		},
+		"lazy invalid": {
+			mode:           Lazy,
+			val:            6,
+			expectedErr:    rangeErr,
+			expectedResult: errs.Result{Errors: errs.Errors{rangeErr}, Warnings: errs.Errors{warnDefault}},
+		},
+		"eager invalid": {
+			mode:           Eager,
+			val:            6,
+			expectedErr:    rangeErr,
+			expectedCalls:  6,
+			expectedResult: errs.Result{Errors: errs.Errors{rangeErr, errs.IsDefaultErr}, Warnings: errs.Errors{warnDefault}},
+		},
+		"parallel invalid": {
+			mode:           Parallel,
+			val:            6,
+			expectedErr:    rangeErr,
+			expectedCalls:  6,
+			expectedResult: errs.Result{Errors: errs.Errors{rangeErr, errs.IsDefaultErr}, Warnings: errs.Errors{warnDefault}},
+		},
+		"collect all invalid": {
+			mode:           CollectAll,
+			val:            6,
+			expectedErr:    errs.Errors{rangeErr, errs.IsDefaultErr},
+			expectedCalls:  6,
+			expectedResult: errs.Result{Errors: errs.Errors{rangeErr, errs.IsDefaultErr}, Warnings: errs.Errors{warnDefault}},
+		},
+		"collect all valid": {
+			mode:          CollectAll,
+			expectedCalls: 6,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls.Store(0)
+			validator := NewTyped[int](tc.mode).WithOptions(opts...)
+			assert.Equal(t, tc.mode, validator.Mode())
+			assert.Equal(t, tc.expectedErr, validator.Validate(tc.val))
+			assert.Equal(t, tc.expectedErr, validator.ToOption(tc.val)())
+			assert.Equal(t, tc.expectedResult, validator.Check(tc.val))
+			assert.Equal(t, tc.expectedCalls, calls.Load())
+		})
+	}
+}
+
+// TestNilTyped tests the Typed validator with nil.
+func TestNilTyped(t *testing.T) {
+	validator := (*Typed[int])(nil)
+	assert.Nil(t, validator.WithOptions(options.VIsDefault[int]()))
+	assert.Nil(t, validator.WithFormatter(errs.PlainFormatter))
+	assert.Nil(t, validator.WithHooks(hooks.Hooks{}))
+	assert.Nil(t, validator.Validate(1))
+	assert.Equal(t, errs.Result{}, validator.Check(1))
+	assert.Equal(t, Lazy, validator.Mode())
+	assert.True(t, validator.Explain(1).Passed)
+}
+
+// TestTyped_Caching tests that a Typed validator is not changed by adding options.
+func TestTyped_Caching(t *testing.T) {
+	validator := NewTyped[int](Lazy).WithOptions(options.VIsInRange(0, 5))
+	first := validator.WithOptions(options.VIsDefault[int]())
+	second := validator.WithOptions(options.VIsNotDefault[int]())
+
+	assert.Nil(t, validator.Validate(1))
+	assert.Equal(t, errs.IsDefaultErr, first.Validate(1))
+	assert.Nil(t, second.Validate(1))
+}
+
+func TestTyped_WithFormatter(t *testing.T) {
+	validator := NewTyped[int](CollectAll).WithFormatter(errs.PlainFormatter).WithOptions(options.VIsDefault[int](), options.VIsInRange(0, 5))
+	assert.Nil(t, validator.Validate(0))
+	assert.EqualError(t, validator.Validate(6), "value is not default; value is out of range")
+}
+
+func TestTyped_WithHooks(t *testing.T) {
+	for _, mode := range []Mode{Lazy, Eager, Parallel, CollectAll} {
+		t.Run(mode.String(), func(t *testing.T) {
+			var failures atomic.Int32
+			counter := hooks.Hooks{OnFailure: func(hooks.Rule, error) { failures.Add(1) }}
+			validator := NewTyped[int](mode).WithOptions(options.VIsDefault[int](), options.VIsInRange(0, 5)).WithHooks(counter)
+
+			assert.NotNil(t, validator.Validate(6))
+			assert.False(t, validator.Check(6).Valid())
+			if mode == Lazy {
+				assert.Equal(t, int32(2), failures.Load())
+			} else {
+				assert.Equal(t, int32(4), failures.Load())
+			}
+		})
+	}
+}
+
+func TestTyped_Explain(t *testing.T) {
+	for _, mode := range []Mode{Lazy, Parallel} {
+		t.Run(mode.String(), func(t *testing.T) {
+			node := NewTyped[int](mode).WithOptions(options.VIsNotDefault[int](), options.VIsInRange(0, 5)).Explain(6)
+			assert.Equal(t, "Typed", node.Name)
+			assert.Equal(t, 6, node.Input)
+			assert.Len(t, node.Children, 2)
+			assert.Equal(t, "VIsNotDefault", node.Children[0].Name)
+			assert.Equal(t, "VIsInRange", node.Children[1].Name)
+			assert.Equal(t, 6, node.Children[1].Input)
+			assert.True(t, strings.HasPrefix(node.String(), "FAIL Typed input=6 ("))
+		})
+	}
+}
+
+// TestTyped_WithMemo_NestedParallel tests that a memoized rule validating its value with a parallel validator
+// using the same rule does not wait for itself.
+func TestTyped_WithMemo_NestedParallel(t *testing.T) {
+	var calls atomic.Int32
+	var check ttypes.ValTest[int]
+	check = options.VNamed("check", func(val int) error {
+		if calls.Add(1) > 1 {
+			return rangeErr
+		}
+		return NewTyped[int](Parallel).WithOptions(check).Validate(val)
+	})
+
+	done := make(chan error)
+	go func() { done <- NewTyped[int](Lazy).WithOptions(check).WithMemo().Validate(1) }()
+	select {
+	case err := <-done:
+		assert.Equal(t, errs.WithName("check", "", errs.WithName("check", "", rangeErr)), err)
+	case <-time.After(time.Second):
+		t.Fatal("memoized rule waited for itself")
+	}
+}
+
+func TestMode_String(t *testing.T) {
+	assert.Equal(t, "CollectAll", CollectAll.String())
+	assert.Equal(t, "Eager", Eager.String())
+	assert.Equal(t, "Mode(9)", Mode(9).String())
+}
+
+func TestTyped_WithMemo(t *testing.T) {
+	for _, mode := range []Mode{Lazy, Eager, Parallel, CollectAll} {
+		t.Run(mode.String(), func(t *testing.T) {
+			var calls atomic.Int32
+			expensive := options.VNamed("expensive", func(int) error {
+				calls.Add(1)
+				return nil
+			})
+			validator := NewTyped[int](mode).WithOptions(expensive, expensive, options.VOr(options.VIsDefault[int](), expensive)).WithMemo()
+
+			assert.Nil(t, validator.Validate(1))
+			assert.True(t, validator.Check(1).Valid())
+			assert.Equal(t, int32(2), calls.Load())
+			assert.Nil(t, (*Typed[int])(nil).WithMemo())
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package validator
 
-import "github.com/Jh123x/go-validate/ttypes"
+import (
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
+	"github.com/Jh123x/go-validate/internal/scope"
+	"github.com/Jh123x/go-validate/ttypes"
+)
 
 type Validator struct {
-	currErr error
+	currErr   error
+	findings  errs.Result
+	formatter errs.Formatter
 }
 
 var _ ttypes.Validator[Validator] = (*Validator)(nil)
@@ -18,21 +25,64 @@ func (l *Validator) WithOptions(opts ...ttypes.Validate) *Validator {
 	if l == nil {
 		return nil
 	}
+	newValidator := &Validator{currErr: l.currErr, findings: l.findings, formatter: l.formatter}
 	for _, opt := range opts {
-		if l.currErr != nil {
-			return &Validator{currErr: l.currErr}
-		}
-		if err := opt(); err != nil {
-			return &Validator{currErr: err}
+		if newValidator.currErr != nil {
+			break
 		}
+		err := opt()
+		newValidator.currErr = errs.OnlyErrors(err)
+		newValidator.findings = newValidator.findings.Merge(findingsOf(err))
+	}
+	return newValidator
+}
+
+// WithFormatter returns a new Validator whose errors are rendered by the formatter.
+func (l *Validator) WithFormatter(formatter errs.Formatter) *Validator {
+	if l == nil {
+		return nil
 	}
-	return NewValidator()
+	return &Validator{currErr: l.currErr, findings: l.findings, formatter: formatter}
 }
 
 // Validate validates the options provided.
+// Warnings and infos are not returned, and can be retrieved with Check.
 func (l *Validator) Validate() error {
 	if l == nil {
 		return nil
 	}
-	return l.currErr
+	return errs.Formatted(l.currErr, l.formatter)
+}
+
+// Check returns the first error along with the warnings and infos found before it.
+func (l *Validator) Check() errs.Result {
+	if l == nil {
+		return errs.Result{}
+	}
+	return l.findings.Merge(errs.NewResult(l.currErr))
+}
+
+// run calls fn in the scope joined with the hooks, with memoization if memo is set.
+func run(s *scope.Scope, memo bool, h []hooks.Hooks, fn func(s *scope.Scope) error) error {
+	if memo {
+		s = scope.WithMemo(s)
+	}
+	if len(h) == 0 {
+		return fn(s)
+	}
+	return hooks.Run(scope.Option(node(func(hooked *scope.Scope) error { return fn(scope.Join(s, hooked)) })), h...)
+}
+
+// node evaluates a validator in the scope of its trace, whose options are reported in its place.
+type node func(s *scope.Scope) error
+
+func (node) Rule() scope.Rule { return scope.Rule{} }
+
+func (n node) Evaluate(s *scope.Scope) error { return n(s) }
+
+// findingsOf returns the warnings and infos in err.
+func findingsOf(err error) errs.Result {
+	result := errs.NewResult(err)
+	result.Errors = nil
+	return result
 }
@@ -1,9 +1,11 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/Jh123x/go-validate/errs"
 	"github.com/Jh123x/go-validate/options"
 	"github.com/Jh123x/go-validate/ttypes"
 	"github.com/stretchr/testify/assert"
@@ -77,3 +79,34 @@ func TestValidator_ErrorInBetween(t *testing.T) {
 	).Validate()
 	assert.Equal(t, fmt.Errorf("empty string"), err)
 }
+
+// TestValidator_WithFormatter tests that the formatter renders the errors of the Validator.
+func TestValidator_WithFormatter(t *testing.T) {
+	validator := NewValidator().WithFormatter(errs.CompactFormatter)
+	assert.Nil(t, validator.WithOptions(validateWNil).Validate())
+
+	err := validator.WithOptions(validateWNil, options.IsNotEmpty("")).WithOptions(validateWErr).Validate()
+	assert.Equal(t, "is_not_empty", err.Error())
+	assert.True(t, errors.Is(err, errs.IsNotEmptyErr))
+
+	assert.Equal(t, errTest, validator.WithOptions(validateWErr).WithFormatter(nil).Validate())
+	assert.Nil(t, (*Validator)(nil).WithFormatter(errs.CompactFormatter))
+}
+
+// TestValidator_Check tests that warnings and infos do not fail the Validator.
+func TestValidator_Check(t *testing.T) {
+	warning := errs.WithSeverity(errs.SeverityWarning, errs.IsEmptyError)
+	info := errs.WithSeverity(errs.SeverityInfo, errs.IsDefaultErr)
+	validator := NewValidator().WithOptions(
+		options.Warn(options.IsEmpty("a")),
+		options.Info(func() error { return errs.IsDefaultErr }),
+		validateWNil,
+	)
+	assert.Nil(t, validator.Validate())
+	assert.Equal(t, errs.Result{Warnings: errs.Errors{warning}, Infos: errs.Errors{info}}, validator.Check())
+
+	validator = validator.WithOptions(validateWErr, options.Warn(options.IsEmpty("a")), options.IsNotEmpty(""))
+	assert.Equal(t, errTest, validator.Validate())
+	assert.Equal(t, errs.Result{Errors: errs.Errors{errTest}, Warnings: errs.Errors{warning}, Infos: errs.Errors{info}}, validator.Check())
+	assert.Equal(t, errs.Result{}, (*Validator)(nil).Check())
+}
@@ -0,0 +1,72 @@
+package wrapper
+
+import (
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/ttypes"
+)
+
+// Pipeline validates values of type In in stages, returning the value of type Out of its last stage.
+// Each stage converts the value of the stage before it and validates the converted value with a ValueValidator,
+// such as a stage converting a string to an int and checking that the int is within a range.
+// The errors of a stage are wrapped in an errs.StageError with the name of the stage.
+type Pipeline[In, Out any] struct {
+	run func(In) (Out, error)
+}
+
+// NewPipeline returns the pipeline of a single stage, validating the values with v as v.Normalize does.
+func NewPipeline[T any](stage string, v *ValueValidator[T]) *Pipeline[T, T] {
+	return &Pipeline[T, T]{run: func(val T) (T, error) {
+		return stageResult(stage, val, v)
+	}}
+}
+
+// Then returns the pipeline p followed by the stage converting its values with convert, such as strconv.Atoi,
+// and validating the converted values with v as v.Normalize does.
+// The stage only runs if the stages before it passed. If the value cannot be converted, the error of
+// convert is returned as errs.ConvertError.
+func Then[In, Mid, Out any](p *Pipeline[In, Mid], stage string, convert func(Mid) (Out, error), v *ValueValidator[Out]) *Pipeline[In, Out] {
+	if p == nil {
+		return nil
+	}
+	return &Pipeline[In, Out]{run: func(val In) (Out, error) {
+		var zero Out
+		mid, err := p.run(val)
+		if err != nil {
+			return zero, err
+		}
+		converted, err := convert(mid)
+		if err != nil {
+			return zero, errs.WithStage(stage, errs.ConvertError.WithParams(map[string]any{"error": err.Error()}))
+		}
+		return stageResult(stage, converted, v)
+	}}
+}
+
+// Parse validates val, returning the value of the last stage, or the zero Out and the error of the stage which failed.
+func (p *Pipeline[In, Out]) Parse(val In) (Out, error) {
+	if p == nil {
+		var zero Out
+		return zero, nil
+	}
+	return p.run(val)
+}
+
+// Validate validates val, returning the error of the stage which failed.
+func (p *Pipeline[In, Out]) Validate(val In) error {
+	_, err := p.Parse(val)
+	return err
+}
+
+func (p *Pipeline[In, Out]) ToOption(val In) ttypes.Validate {
+	return func() error { return p.Validate(val) }
+}
+
+// stageResult validates val with v, returning the normalised value, or the zero T and the error of the stage.
+func stageResult[T any](stage string, val T, v *ValueValidator[T]) (T, error) {
+	out, err := v.Normalize(val)
+	if err != nil {
+		var zero T
+		return zero, errs.WithStage(stage, err)
+	}
+	return out, nil
+}
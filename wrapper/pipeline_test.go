@@ -0,0 +1,72 @@
+package wrapper
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/transform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	minutes := Then(
+		NewPipeline("input", NewValueWrapper[string]().WithTransforms(transform.TrimSpace).WithOptions(options.VIsStringLength(1, 3))),
+		"minutes", strconv.Atoi,
+		NewValueWrapper[int]().WithOptions(options.VIsInRange(1, 100)),
+	)
+	pipeline := Then(minutes, "duration", func(m int) (time.Duration, error) { return time.Duration(m) * time.Minute, nil }, nil)
+
+	tests := map[string]struct {
+		value         string
+		expected      time.Duration
+		expectedErr   error
+		expectedStage string
+	}{
+		"valid": {
+			value:    " 90 ",
+			expected: 90 * time.Minute,
+		},
+		"first stage fails": {
+			value:         "1000",
+			expectedErr:   errs.StageError{Stage: "input", Err: errs.InvalidLengthError.WithParams(map[string]any{"min": 1, "max": 3})},
+			expectedStage: "input",
+		},
+		"conversion fails": {
+			value:         "1h",
+			expectedErr:   errs.StageError{Stage: "minutes", Err: errs.ConvertError.WithParams(map[string]any{"error": `strconv.Atoi: parsing "1h": invalid syntax`})},
+			expectedStage: "minutes",
+		},
+		"converted value fails": {
+			value:         "0",
+			expectedErr:   errs.StageError{Stage: "minutes", Err: errs.OutOfRangeError.WithParams(map[string]any{"min": 1, "max": 100})},
+			expectedStage: "minutes",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			val, err := pipeline.Parse(tc.value)
+			assert.Equal(t, tc.expected, val)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedStage, errs.StageOf(err))
+			assert.Equal(t, tc.expectedErr, pipeline.Validate(tc.value))
+			assert.Equal(t, tc.expectedErr, pipeline.ToOption(tc.value)())
+		})
+	}
+}
+
+func TestPipeline_NilBehaviour(t *testing.T) {
+	var pipeline *Pipeline[string, string]
+	val, err := pipeline.Parse("abc")
+	assert.Equal(t, "", val)
+	assert.Nil(t, err)
+	assert.Nil(t, pipeline.Validate("abc"))
+	assert.Nil(t, Then(pipeline, "int", strconv.Atoi, NewValueWrapper[int]()))
+
+	val, err = NewPipeline[string]("input", nil).Parse("abc")
+	assert.Equal(t, "abc", val)
+	assert.Nil(t, err)
+}
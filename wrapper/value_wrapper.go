@@ -1,40 +1,151 @@
 package wrapper
 
 import (
+	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
+	"github.com/Jh123x/go-validate/internal/scope"
 	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/trace"
+	"github.com/Jh123x/go-validate/transform"
 	"github.com/Jh123x/go-validate/ttypes"
 )
 
 // ValueValidator is a wrapper for a value of type T.
 // You can use repeated Tests on the wrapper to check for the same boolean.
 type ValueValidator[T any] struct {
-	option ttypes.ValTest[T]
+	options    []ttypes.ValTest[T]
+	option     ttypes.ValTest[T]
+	transforms []ttypes.Transform[T]
+	transform  ttypes.Transform[T]
+	formatter  errs.Formatter
+	hooks      []hooks.Hooks
+	memo       bool
 }
 
 func NewValueWrapper[T any]() *ValueValidator[T] {
-	return &ValueValidator[T]{
-		option: options.VWithRequire[T](func(T) bool { return true }, nil),
-	}
+	return &ValueValidator[T]{option: options.VAnd[T]()}
 }
 
 func (v *ValueValidator[T]) WithOptions(valOptions ...ttypes.ValTest[T]) *ValueValidator[T] {
 	if v == (*ValueValidator[T])(nil) {
 		return nil
 	}
-	v.option = options.VAnd(v.option, options.VAnd(valOptions...))
+	v.options = append(v.options, valOptions...)
+	v.option = options.VAnd(v.options...)
 	return v
 }
 
-func (v *ValueValidator[T]) Validate(val T) error {
+// WithTransforms normalises the values with the transforms, in order, before the options validate them,
+// such as with transform.TrimSpace.
+func (v *ValueValidator[T]) WithTransforms(transforms ...ttypes.Transform[T]) *ValueValidator[T] {
+	if v == nil {
+		return nil
+	}
+	v.transforms = append(v.transforms, transforms...)
+	v.transform = transform.Chain(v.transforms...)
+	return v
+}
+
+// WithFormatter renders the errors of the validator with the formatter.
+func (v *ValueValidator[T]) WithFormatter(formatter errs.Formatter) *ValueValidator[T] {
+	if v == nil {
+		return nil
+	}
+	v.formatter = formatter
+	return v
+}
+
+// WithHooks calls the hooks around each rule evaluated by the validator.
+func (v *ValueValidator[T]) WithHooks(h hooks.Hooks) *ValueValidator[T] {
+	if v == nil {
+		return nil
+	}
+	v.hooks = append(v.hooks, h)
+	return v
+}
+
+// WithMemo evaluates each memoized rule at most once per validation,
+// such as the options wrapped in options.VMemo and the named options.
+func (v *ValueValidator[T]) WithMemo() *ValueValidator[T] {
 	if v == nil {
 		return nil
 	}
-	return v.option(val)
+	v.memo = true
+	return v
+}
+
+func (v *ValueValidator[T]) Validate(val T) error {
+	_, err := v.Normalize(val)
+	return err
+}
+
+// Normalize transforms val with the transforms of the validator and validates it,
+// returning the normalised value along with the error of the validation.
+func (v *ValueValidator[T]) Normalize(val T) (T, error) {
+	if v == nil {
+		return val, nil
+	}
+	val = v.normalize(val)
+	return val, errs.Formatted(errs.OnlyErrors(v.evaluate(nil, val)), v.formatter)
+}
+
+// Check validates val, returning the first error along with the warnings and infos found.
+func (v *ValueValidator[T]) Check(val T) errs.Result {
+	if v == nil {
+		return errs.Result{}
+	}
+	return errs.NewResult(v.evaluate(nil, v.normalize(val)))
+}
+
+// normalize transforms val with the transforms of the validator.
+func (v *ValueValidator[T]) normalize(val T) T {
+	if v.transform == nil {
+		return val
+	}
+	return v.transform(val)
+}
+
+// evaluate runs the options on val in the scope joined with the hooks of the validator, with memoization if it is enabled.
+func (v *ValueValidator[T]) evaluate(s *scope.Scope, val T) error {
+	if v.memo {
+		s = scope.WithMemo(s)
+	}
+	if len(v.hooks) == 0 {
+		return scope.EvalValue(s, val, v.option)
+	}
+	return hooks.Run(scope.Option(hooked(func(h *scope.Scope) error { return scope.EvalValue(scope.Join(s, h), val, v.option) })), v.hooks...)
+}
+
+// Explain validates val, returning the trace of the rules evaluated.
+func (v *ValueValidator[T]) Explain(val T) *trace.Node {
+	return trace.ExplainValue("ValueValidator", val, scope.ValueOption[T](node[T]{validator: v}))
 }
 
 func (v *ValueValidator[T]) ToOption(val T) ttypes.Validate {
 	if v == nil {
 		return func() error { return nil }
 	}
-	return func() error { return v.option(val) }
+	return func() error { return v.Validate(val) }
+}
+
+// node evaluates a validator in the scope of its trace, whose options are reported in its place.
+type node[T any] struct {
+	validator *ValueValidator[T]
 }
+
+func (node[T]) Rule() scope.Rule { return scope.Rule{} }
+
+func (n node[T]) Evaluate(s *scope.Scope, val T) error {
+	v := n.validator
+	if v == nil {
+		return nil
+	}
+	return errs.Formatted(errs.OnlyErrors(v.evaluate(s, v.normalize(val))), v.formatter)
+}
+
+// hooked evaluates a validator in the scope of its hooks, whose options are reported in its place.
+type hooked func(s *scope.Scope) error
+
+func (hooked) Rule() scope.Rule { return scope.Rule{} }
+
+func (h hooked) Evaluate(s *scope.Scope) error { return h(s) }
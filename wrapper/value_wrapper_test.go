@@ -1,11 +1,14 @@
 package wrapper
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/Jh123x/go-validate/errs"
+	"github.com/Jh123x/go-validate/hooks"
 	"github.com/Jh123x/go-validate/options"
+	"github.com/Jh123x/go-validate/transform"
 	"github.com/Jh123x/go-validate/ttypes"
 	"github.com/stretchr/testify/assert"
 )
@@ -102,7 +105,7 @@ func TestOptionCompatibility_ArrValues(t *testing.T) {
 		"IsLength fail": {
 			value:               []int{1, 2, 3},
 			options:             options.VIsLength[int](4, 5),
-			expectedValidateErr: errs.InvalidLengthError,
+			expectedValidateErr: errs.InvalidLengthError.WithParams(map[string]any{"min": 4, "max": 5}),
 		},
 		"Contains success": {
 			value:   []int{1, 2, 3},
@@ -152,7 +155,7 @@ func TestOptionCompatibility_ArrValues(t *testing.T) {
 				options.VIsLength[int](4, 5), // Fail
 				options.VContains(4),         // Fail
 			),
-			expectedValidateErr: errs.InvalidLengthError,
+			expectedValidateErr: errs.InvalidLengthError.WithParams(map[string]any{"min": 4, "max": 5}),
 		},
 		"or success": {
 			value: []int{1, 2, 3},
@@ -176,6 +179,88 @@ func TestOptionCompatibility_ArrValues(t *testing.T) {
 			),
 			expectedValidateErr: errs.OrError,
 		},
+		"exactly one success": {
+			value: []int{1, 2, 3},
+			options: options.VExactlyOne(
+				options.VIsLength[int](4, 5), // Fail
+				options.VContains(1),         // Success
+			),
+		},
+		"exactly one fail when all success": {
+			value: []int{1, 2, 3, 4},
+			options: options.VExactlyOne(
+				options.VIsLength[int](4, 5), // Success
+				options.VContains(1),         // Success
+				nil,
+			),
+			expectedValidateErr: errs.ExactlyOneError,
+		},
+		"not success": {
+			value:   []int{1, 2, 3},
+			options: options.VNot(options.VContains(4), errs.NotError),
+		},
+		"not fail": {
+			value:               []int{1, 2, 3},
+			options:             options.VNot(options.VContains(1), errs.NotError),
+			expectedValidateErr: errs.NotError,
+		},
+		"not fail on warning": {
+			value:               []int{1, 2, 3},
+			options:             options.VNot(options.VWarn(options.VContains(4)), errs.NotError),
+			expectedValidateErr: errs.NotError,
+		},
+		"exactly one success with info": {
+			value: []int{1, 2, 3},
+			options: options.VExactlyOne(
+				options.VIsLength[int](4, 5),                // Fail
+				options.VInfo(options.VIsLength[int](4, 5)), // Info
+			),
+		},
+		"exactly one fail with warning and success": {
+			value: []int{1, 2, 3},
+			options: options.VExactlyOne(
+				options.VWarn(options.VContains(4)), // Warn
+				options.VContains(1),                // Success
+			),
+			expectedValidateErr: errs.ExactlyOneError,
+		},
+		"or success with warning": {
+			value: []int{1, 2, 3},
+			options: options.VOr(
+				options.VContains(4),                // Fail
+				options.VWarn(options.VContains(4)), // Warn
+			),
+		},
+		"not with nil option": {
+			value:   []int{1, 2, 3},
+			options: options.VNot[[]int](nil, errs.NotError),
+		},
+		"all success": {
+			value:   []int{1, 2, 3},
+			options: options.VAll(options.VContains(1), nil, options.VIsLength[int](1, 3)),
+		},
+		"all returns every error": {
+			value: []int{1, 2, 3},
+			options: options.VAll(
+				options.VContains(4),         // Fail
+				options.VContains(1),         // Success
+				options.VIsLength[int](4, 5), // Fail
+			),
+			expectedValidateErr: errs.Errors{errs.ContainsError, errs.InvalidLengthError.WithParams(map[string]any{"min": 4, "max": 5})},
+		},
+		"field success": {
+			value:   []int{1, 2, 3},
+			options: options.VField("0", func(v []int) int { return v[0] }, options.VIsInRange(1, 3)),
+		},
+		"field fail": {
+			value:               []int{1, 2, 3},
+			options:             options.VField("2", func(v []int) int { return v[2] }, options.VIsInRange(1, 2)),
+			expectedValidateErr: errs.PathError{Pointer: "/2", Err: errs.OutOfRangeError.WithParams(map[string]any{"min": 1, "max": 2})},
+		},
+		"field with nil option": {
+			value:   []int{1, 2, 3},
+			options: options.VField[[]int, int]("0", func(v []int) int { return v[0] }, nil),
+		},
 	}
 
 	for name, tc := range tests {
@@ -248,3 +333,153 @@ func TestValueWrapper_DoubleWrap(t *testing.T) {
 	valueWrapper = valueWrapper.WithOptions(options.VIsNotDefault[int]()) // Should not be used
 	assert.Equal(t, errs.IsDefaultErr, valueWrapper.Validate(1))
 }
+
+func TestValueWrapper_WithFormatter(t *testing.T) {
+	valueWrapper := NewValueWrapper[int]().WithFormatter(errs.PlainFormatter).WithOptions(options.VIsDefault[int]())
+	assert.Nil(t, valueWrapper.Validate(0))
+
+	err := valueWrapper.Validate(1)
+	assert.Equal(t, "value is not default", err.Error())
+	assert.True(t, errors.Is(err, errs.IsDefaultErr))
+	assert.EqualError(t, valueWrapper.ToOption(1)(), err.Error())
+
+	var nilWrapper *ValueValidator[int]
+	assert.Nil(t, nilWrapper.WithFormatter(errs.PlainFormatter))
+}
+
+func TestValueWrapper_Check(t *testing.T) {
+	warning := errs.WithSeverity(errs.SeverityWarning, errs.IsDefaultErr)
+	valueWrapper := NewValueWrapper[int]().WithOptions(options.VWarn(options.VIsDefault[int]()), options.VIsInRange(0, 5))
+	assert.Nil(t, valueWrapper.Validate(1))
+	assert.Equal(t, errs.Result{Warnings: errs.Errors{warning}}, valueWrapper.Check(1))
+
+	rangeErr := errs.OutOfRangeError.WithParams(map[string]any{"min": 0, "max": 5})
+	assert.Equal(t, rangeErr, valueWrapper.Validate(6))
+	assert.Equal(t, errs.Result{Errors: errs.Errors{rangeErr}, Warnings: errs.Errors{warning}}, valueWrapper.Check(6))
+
+	var nilWrapper *ValueValidator[int]
+	assert.Equal(t, errs.Result{}, nilWrapper.Check(1))
+}
+
+func TestValueWrapper_Explain(t *testing.T) {
+	node := NewValueWrapper[int]().WithOptions(options.VIsNotDefault[int](), options.VIsInRange(0, 5)).Explain(6)
+	assert.Equal(t, "ValueValidator", node.Name)
+	assert.Equal(t, 6, node.Input)
+	assert.False(t, node.Passed)
+	assert.Len(t, node.Children, 1)
+	assert.Equal(t, "VAnd", node.Children[0].Name)
+	assert.Len(t, node.Children[0].Children, 2)
+	assert.True(t, node.Children[0].Children[0].Passed)
+	assert.Equal(t, "VIsInRange", node.Children[0].Children[1].Name)
+
+	var nilWrapper *ValueValidator[int]
+	assert.True(t, nilWrapper.Explain(1).Passed)
+	assert.Nil(t, (&ValueValidator[int]{}).WithOptions().Validate(1))
+}
+
+func TestValueWrapper_WithHooks(t *testing.T) {
+	var failures []string
+	counter := hooks.Hooks{OnFailure: func(rule hooks.Rule, _ error) { failures = append(failures, rule.Name) }}
+	valueWrapper := NewValueWrapper[int]().WithOptions(options.VNamed("adult", options.VIsInRange(18, 150))).WithHooks(counter)
+
+	assert.Nil(t, valueWrapper.Validate(20))
+	assert.NotNil(t, valueWrapper.Validate(1))
+	assert.False(t, valueWrapper.Check(1).Valid())
+	assert.Equal(t, []string{"VIsInRange", "adult", "VAnd", "VIsInRange", "adult", "VAnd"}, failures)
+
+	var nilWrapper *ValueValidator[int]
+	assert.Nil(t, nilWrapper.WithHooks(counter))
+}
+
+func TestValueWrapper_WithMemo(t *testing.T) {
+	var calls int
+	expensive := options.VMemo(func(int) error {
+		calls++
+		return nil
+	})
+	valueWrapper := NewValueWrapper[int]().WithOptions(expensive, options.VOr(options.VIsDefault[int](), expensive))
+	assert.Nil(t, valueWrapper.Validate(1))
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	valueWrapper = valueWrapper.WithMemo()
+	assert.Nil(t, valueWrapper.Validate(1))
+	assert.Nil(t, valueWrapper.Validate(2))
+	assert.Equal(t, 2, calls)
+
+	var nilWrapper *ValueValidator[int]
+	assert.Nil(t, nilWrapper.WithMemo())
+}
+
+func TestValueWrapper_WithTransforms(t *testing.T) {
+	tests := map[string]struct {
+		value       string
+		transforms  []ttypes.Transform[string]
+		expected    string
+		expectedErr error
+	}{
+		"no transforms": {
+			value:       " Test@Test.com ",
+			expected:    " Test@Test.com ",
+			expectedErr: errs.InvalidEmailError,
+		},
+		"trimmed and lowered": {
+			value:      " Test@Test.com ",
+			transforms: []ttypes.Transform[string]{transform.TrimSpace, nil, transform.ToLower},
+			expected:   "test@test.com",
+		},
+		"control characters stripped": {
+			value:      "test@te\x00st.com\n",
+			transforms: []ttypes.Transform[string]{transform.StripControl},
+			expected:   "test@test.com",
+		},
+		"still invalid": {
+			value:       " test.com ",
+			transforms:  []ttypes.Transform[string]{transform.TrimSpace},
+			expected:    "test.com",
+			expectedErr: errs.InvalidEmailError,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			valueWrapper := NewValueWrapper[string]().WithTransforms(tc.transforms...).WithOptions(func(s string) error {
+				return options.VIsValidEmail(s)
+			})
+			val, err := valueWrapper.Normalize(tc.value)
+			assert.Equal(t, tc.expected, val)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedErr, valueWrapper.Validate(tc.value))
+			assert.Equal(t, tc.expectedErr == nil, valueWrapper.Check(tc.value).Valid())
+		})
+	}
+}
+
+func TestValueWrapper_WithTransforms_Order(t *testing.T) {
+	var applied []string
+	record := func(name string) ttypes.Transform[int] {
+		return func(val int) int {
+			applied = append(applied, name)
+			return val * 10
+		}
+	}
+	valueWrapper := NewValueWrapper[int]().
+		WithTransforms(record("first")).
+		WithTransforms(record("second")).
+		WithOptions(options.VIsInRange(0, 100))
+
+	val, err := valueWrapper.Normalize(1)
+	assert.Equal(t, 100, val)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first", "second"}, applied)
+
+	val, err = valueWrapper.Normalize(2)
+	assert.Equal(t, 200, val)
+	assert.Equal(t, errs.OutOfRangeError.WithParams(map[string]any{"min": 0, "max": 100}), err)
+
+	var nilWrapper *ValueValidator[int]
+	assert.Nil(t, nilWrapper.WithTransforms(record("nil")))
+	val, err = nilWrapper.Normalize(3)
+	assert.Equal(t, 3, val)
+	assert.Nil(t, err)
+}